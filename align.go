@@ -0,0 +1,15 @@
+package filesize
+
+// IsAligned reports whether size is a whole multiple of boundary, as block
+// sizes, stripe widths and DMA buffer lengths often must be
+//
+// Zero is aligned to every boundary. A boundary below 1 aligns nothing.
+func IsAligned(size, boundary int64) bool {
+	if boundary <= 0 {
+		return false
+	}
+	if IsPowerOfTwo(boundary) {
+		return size&(boundary-1) == 0
+	}
+	return size%boundary == 0
+}
@@ -0,0 +1,33 @@
+package filesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIsAligned tests alignment to power-of-two and other boundaries
+func TestIsAligned(t *testing.T) {
+	testCases := []struct {
+		size, boundary int64
+		expected       bool
+	}{
+		{8 * KiB, 4 * KiB, true},
+		{6 * KiB, 4 * KiB, false},
+		{0, 4 * KiB, true},
+		{-8 * KiB, 4 * KiB, true},
+		{-KiB, 4 * KiB, false},
+		{3 * 64 * KiB, 3 * 64 * KiB, true},
+		{6 * 64 * KiB, 3 * 64 * KiB, true},
+		{4 * 64 * KiB, 3 * 64 * KiB, false},
+		{math.MaxInt64, 1, true},
+		{math.MinInt64, 1 << 62, true},
+		{KiB, 0, false},
+		{KiB, -512, false},
+	}
+
+	for _, tc := range testCases {
+		if result := IsAligned(tc.size, tc.boundary); result != tc.expected {
+			t.Errorf("IsAligned(%d, %d) = %v, expected %v", tc.size, tc.boundary, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package filesize
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BigSize is a byte count that may exceed the int64 range
+//
+// It backs capacity planning for aggregate storage where totals can reach
+// zettabytes and beyond, and understands every SI prefix up to the ronna (R,
+// 10^27) and quetta (Q, 10^30) prefixes added in 2022, together with their
+// binary analogs RiB and QiB. The zero value is zero bytes. BigSize values
+// are immutable.
+type BigSize struct {
+	bytes *big.Int
+}
+
+// bigUnitTable lists the units beyond EiB that only BigSize understands
+var bigUnitTable = []struct {
+	name       string
+	multiplier *big.Int
+	aliases    []string
+}{
+	{"ZiB", bigPow(2, 70), []string{"z"}},
+	{"YiB", bigPow(2, 80), []string{"y"}},
+	{"RiB", bigPow(2, 90), []string{"r"}},
+	{"QiB", bigPow(2, 100), []string{"q"}},
+	{"ZB", bigPow(10, 21), nil},
+	{"YB", bigPow(10, 24), nil},
+	{"RB", bigPow(10, 27), nil},
+	{"QB", bigPow(10, 30), nil},
+}
+
+// bigUnitMap maps lowercase unit names to multipliers for BigSize parsing,
+// covering the regular units as well as the big ones
+var bigUnitMap = buildBigUnitMap()
+
+// bigIECUnits and bigSIUnits are the formatting ladders for BigSize
+var (
+	bigIECUnits = []scaleUnit{
+		{"QiB", 0x1p100}, {"RiB", 0x1p90}, {"YiB", 0x1p80}, {"ZiB", 0x1p70},
+		{"EiB", 0x1p60}, {"PiB", 0x1p50}, {"TiB", 0x1p40}, {"GiB", 0x1p30},
+		{"MiB", 0x1p20}, {"KiB", 0x1p10},
+	}
+	bigSIUnits = []scaleUnit{
+		{"QB", 1e30}, {"RB", 1e27}, {"YB", 1e24}, {"ZB", 1e21},
+		{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9},
+		{"MB", 1e6}, {"kB", 1e3},
+	}
+)
+
+// bigPow returns base raised to exp as a big.Int
+func bigPow(base, exp int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)
+}
+
+// buildBigUnitMap merges the regular unit table with the big units
+func buildBigUnitMap() map[string]*big.Int {
+	units := make(map[string]*big.Int, len(unitMap)+len(bigUnitTable)*2)
+	for key, unit := range unitMap {
+		units[key] = big.NewInt(unit.Multiplier)
+	}
+	for _, unit := range bigUnitTable {
+		units[strings.ToLower(unit.name)] = unit.multiplier
+		for _, alias := range unit.aliases {
+			units[alias] = unit.multiplier
+		}
+	}
+	return units
+}
+
+// NewBigSize returns a BigSize holding a copy of n
+func NewBigSize(n *big.Int) BigSize {
+	return BigSize{bytes: new(big.Int).Set(n)}
+}
+
+// BigSizeFromInt64 returns a BigSize holding n bytes
+func BigSizeFromInt64(n int64) BigSize {
+	return BigSize{bytes: big.NewInt(n)}
+}
+
+// ParseBigSize converts a size string to a BigSize
+//
+// It accepts the ParseSize syntax plus the units ZB, YB, RB and QB and their
+// binary forms ZiB, YiB, RiB and QiB (short forms z, y, r and q). Fractional
+// numbers are evaluated exactly and truncated to whole bytes, so there is no
+// upper limit and no floating point rounding.
+func ParseBigSize(sizeStr string) (BigSize, error) {
+	// split the input into its number and unit
+	numberStr, unitStr, err := splitSize(sizeStr)
+	if err != nil {
+		return BigSize{}, err
+	}
+	unitStr = strings.ToLower(unitStr)
+
+	// parse the numeric portion exactly as a rational number
+	number, ok := new(big.Rat).SetString(numberStr)
+	if !ok {
+		return BigSize{}, fmt.Errorf("invalid number: %s", numberStr)
+	}
+
+	// scale by the unit, assuming bytes when no unit is specified
+	if unitStr != "" {
+		multiplier, exists := bigUnitMap[unitStr]
+		if !exists {
+			return BigSize{}, fmt.Errorf("unknown unit: %s", unitStr)
+		}
+		number.Mul(number, new(big.Rat).SetInt(multiplier))
+	}
+
+	// truncate to whole bytes
+	return BigSize{bytes: new(big.Int).Quo(number.Num(), number.Denom())}, nil
+}
+
+// Int returns the byte count as a new big.Int
+func (b BigSize) Int() *big.Int {
+	if b.bytes == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(b.bytes)
+}
+
+// Int64 returns the byte count as an int64 and whether it fits
+func (b BigSize) Int64() (int64, bool) {
+	if b.bytes == nil {
+		return 0, true
+	}
+	return b.bytes.Int64(), b.bytes.IsInt64()
+}
+
+// Cmp compares b and other, returning -1, 0 or +1
+func (b BigSize) Cmp(other BigSize) int {
+	return b.Int().Cmp(other.Int())
+}
+
+// String formats the size with binary units, see Format
+func (b BigSize) String() string {
+	return b.Format()
+}
+
+// Format renders the size with binary units up to QiB
+//
+// Formatting options behave as they do for FormatSize, except that the unit
+// ladder is always the extended binary one; use FormatSI for decimal units.
+func (b BigSize) Format(opts ...FormatOption) string {
+	return b.format(bigIECUnits, opts)
+}
+
+// FormatSI renders the size with decimal units up to QB, e.g. "1.50 RB"
+func (b BigSize) FormatSI(opts ...FormatOption) string {
+	return b.format(bigSIUnits, opts)
+}
+
+// format renders the size against a big unit ladder
+func (b BigSize) format(units []scaleUnit, opts []FormatOption) string {
+	cfg := newFormatConfig(opts)
+	n := b.Int()
+	if n.Sign() < 0 && cfg.clampNegative {
+		n.SetInt64(0)
+	}
+
+	// trim the ladder to the unit cap like regular formatting does
+	if cfg.maxUnit > 0 {
+		for len(units) > 0 && units[0].multiplier > float64(cfg.maxUnit) {
+			units = units[1:]
+		}
+	}
+
+	// small counts are written exactly, larger ones scaled via float64
+	magnitude := new(big.Int).Abs(n)
+	if magnitude.IsInt64() && (len(units) == 0 || float64(magnitude.Int64()) < units[len(units)-1].multiplier) {
+		var buf [formatBufferSize]byte
+		return string(cfg.appendParts(buf[:0], n.Sign(), magnitude.Append(nil, 10), "B", "", false))
+	}
+
+	value, _ := new(big.Float).SetInt(magnitude).Float64()
+	var buf [formatBufferSize]byte
+	return string(cfg.appendScaled(buf[:0], n.Sign(), value, units, "B", ""))
+}
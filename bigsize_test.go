@@ -0,0 +1,105 @@
+package filesize
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestParseBigSize tests the ParseBigSize function
+func TestParseBigSize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"0", "0", false},
+		{"1024", "1024", false},
+		{"4k", "4096", false},
+		{"1KB", "1000", false},
+		{"1EiB", "1152921504606846976", false},
+		{"16EiB", "18446744073709551616", false},
+		{"1ZB", "1000000000000000000000", false},
+		{"1ZiB", "1180591620717411303424", false},
+		{"1YB", "1000000000000000000000000", false},
+		{"1RB", "1000000000000000000000000000", false},
+		{"1.5QB", "1500000000000000000000000000000", false},
+		{"1QiB", "1267650600228229401496703205376", false},
+		{"2r", "2475880078570760549798248448", false},
+		{"0.5b", "0", false},
+
+		{"", "", true},
+		{"1XB", "", true},
+		{"-1QB", "", true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseBigSize(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseBigSize(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBigSize(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result.Int().String() != tc.expected {
+			t.Errorf("ParseBigSize(%q) = %s, expected %s", tc.input, result.Int(), tc.expected)
+		}
+	}
+}
+
+// TestBigSize_Format tests BigSize formatting
+func TestBigSize_Format(t *testing.T) {
+	mustParse := func(s string) BigSize {
+		b, err := ParseBigSize(s)
+		if err != nil {
+			t.Fatalf("ParseBigSize(%q) unexpected error: %v", s, err)
+		}
+		return b
+	}
+
+	testCases := []struct {
+		size     BigSize
+		si       bool
+		expected string
+	}{
+		{BigSize{}, false, "0 B"},
+		{BigSizeFromInt64(1023), false, "1023 B"},
+		{BigSizeFromInt64(1536), false, "1.50 KiB"},
+		{BigSizeFromInt64(-1536), false, "-1.50 KiB"},
+		{mustParse("1.5QiB"), false, "1.50 QiB"},
+		{mustParse("2048RiB"), false, "2.00 QiB"},
+		{mustParse("1.5RB"), true, "1.50 RB"},
+		{mustParse("1500QB"), true, "1500 QB"},
+		{mustParse("999"), true, "999 B"},
+	}
+
+	for _, tc := range testCases {
+		var result string
+		if tc.si {
+			result = tc.size.FormatSI()
+		} else {
+			result = tc.size.String()
+		}
+		if result != tc.expected {
+			t.Errorf("format(%s) = %q, expected %q", tc.size.Int(), result, tc.expected)
+		}
+	}
+}
+
+// TestBigSize_Int64 tests conversion back to int64
+func TestBigSize_Int64(t *testing.T) {
+	if n, ok := BigSizeFromInt64(42).Int64(); !ok || n != 42 {
+		t.Errorf("BigSizeFromInt64(42).Int64() = %d, %t, expected 42, true", n, ok)
+	}
+
+	huge := NewBigSize(new(big.Int).Lsh(big.NewInt(1), 80))
+	if _, ok := huge.Int64(); ok {
+		t.Errorf("2^80 bytes reported as fitting in int64")
+	}
+	if huge.Cmp(BigSizeFromInt64(1)) != 1 {
+		t.Errorf("Cmp(2^80, 1) did not report greater")
+	}
+}
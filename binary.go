@@ -0,0 +1,26 @@
+package filesize
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+//
+// The size is encoded as a zig-zag varint, so common sizes take a few bytes
+// and negative deltas stay compact. encoding/gob and net/rpc pick this up
+// automatically.
+func (s Size) MarshalBinary() ([]byte, error) {
+	return binary.AppendVarint(nil, int64(s)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *Size) UnmarshalBinary(data []byte) error {
+	bytes, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return fmt.Errorf("invalid binary size encoding")
+	}
+
+	*s = Size(bytes)
+	return nil
+}
@@ -0,0 +1,79 @@
+package filesize
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+// TestSize_MarshalBinary tests binary round trips and encoding length
+func TestSize_MarshalBinary(t *testing.T) {
+	testCases := []struct {
+		input  Size
+		length int
+	}{
+		{0, 1},
+		{-1, 1},
+		{4096, 2},
+		{Size(GiB), 5},
+		{math.MaxInt64, 10},
+		{math.MinInt64, 10},
+	}
+
+	for _, tc := range testCases {
+		data, err := tc.input.MarshalBinary()
+		if err != nil {
+			t.Errorf("Size(%d).MarshalBinary() unexpected error: %v", int64(tc.input), err)
+			continue
+		}
+		if len(data) != tc.length {
+			t.Errorf("Size(%d).MarshalBinary() length = %d, expected %d", int64(tc.input), len(data), tc.length)
+		}
+
+		var decoded Size
+		if err := decoded.UnmarshalBinary(data); err != nil || decoded != tc.input {
+			t.Errorf("UnmarshalBinary(%x) = %d, %v, expected %d", data, int64(decoded), err, int64(tc.input))
+		}
+	}
+}
+
+// TestSize_UnmarshalBinary_Invalid tests rejection of malformed input
+func TestSize_UnmarshalBinary_Invalid(t *testing.T) {
+	inputs := [][]byte{
+		nil,
+		{0x80},
+		{0x02, 0x00},
+		bytes.Repeat([]byte{0xff}, 11),
+	}
+
+	for _, input := range inputs {
+		var s Size
+		if err := s.UnmarshalBinary(input); err == nil {
+			t.Errorf("UnmarshalBinary(%x) expected error but got none", input)
+		}
+	}
+}
+
+// TestSize_Gob tests that gob encodes Size fields through the binary methods
+func TestSize_Gob(t *testing.T) {
+	type quota struct {
+		Name string
+		Used Size
+		Max  Size
+	}
+	input := quota{Name: "home", Used: Size(3 * GiB / 2), Max: Size(10 * GiB)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(input); err != nil {
+		t.Fatalf("gob Encode() unexpected error: %v", err)
+	}
+
+	var decoded quota
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("gob Decode() unexpected error: %v", err)
+	}
+	if decoded != input {
+		t.Errorf("gob round trip = %+v, expected %+v", decoded, input)
+	}
+}
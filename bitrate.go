@@ -0,0 +1,155 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bitPrefixes maps lowercase bit rate prefixes to their multipliers
+var bitPrefixes = map[string]float64{
+	"":   1,
+	"k":  1e3,
+	"m":  1e6,
+	"g":  1e9,
+	"t":  1e12,
+	"p":  1e15,
+	"e":  1e18,
+	"ki": 1 << 10,
+	"mi": 1 << 20,
+	"gi": 1 << 30,
+	"ti": 1 << 40,
+	"pi": 1 << 50,
+	"ei": 1 << 60,
+}
+
+// siBpsUnits are the units FormatBps chooses from, in descending order
+var siBpsUnits = []scaleUnit{
+	{"Ebps", 1e18},
+	{"Pbps", 1e15},
+	{"Tbps", 1e12},
+	{"Gbps", 1e9},
+	{"Mbps", 1e6},
+	{"kbps", 1e3},
+}
+
+// ParseBitRate converts a network bit rate such as "100Mbps" to a Rate
+//
+// Accepted forms are a number, an optional SI or binary prefix and either
+// "bps" or a bit unit ("b", "bit" or "bits") followed by a slash and a
+// period as in ParseRate: "100Mbps", "1.5 Gbit/s", "10 Mb/s" or "2Mibit/s".
+// A lowercase "b" always means bits; byte rates such as "10MB/s" are
+// rejected so that the two cannot be confused, use ParseRate for those.
+// Prefixes are 1000-based, or 1024-based with an "i" ("Ki", "Mi").
+//
+// The Rate holds the equivalent byte rate. Bit counts that are not a whole
+// number of bytes are kept exact by expressing the rate over eight periods.
+func ParseBitRate(rateStr string) (Rate, error) {
+	trimmed := strings.TrimSpace(rateStr)
+
+	// "bps" implies seconds, otherwise a period follows the slash
+	amountStr, periodStr, hasPeriod := strings.Cut(trimmed, "/")
+	per := time.Second
+	if hasPeriod {
+		var err error
+		if per, err = parsePeriod(periodStr); err != nil {
+			return Rate{}, err
+		}
+	}
+
+	numberStr, unitStr, err := splitSize(amountStr)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid bit rate format: %s", trimmed)
+	}
+
+	prefix, ok := trimBitUnit(unitStr, hasPeriod)
+	if !ok {
+		return Rate{}, fmt.Errorf("unknown bit rate unit: %s", unitStr)
+	}
+	multiplier, ok := bitPrefixes[strings.ToLower(prefix)]
+	if !ok {
+		return Rate{}, fmt.Errorf("unknown bit rate unit: %s", unitStr)
+	}
+
+	number, err := strconv.ParseFloat(numberStr, 64)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid number: %s", numberStr)
+	}
+
+	bits := math.Round(number * multiplier)
+	if bits >= math.MaxInt64 {
+		return Rate{}, fmt.Errorf("bit rate too large: %s", trimmed)
+	}
+	return bitsOver(int64(bits), per)
+}
+
+// trimBitUnit strips the bit unit from unitStr and returns the prefix before
+// it, accepting "bps" only when no period follows
+func trimBitUnit(unitStr string, hasPeriod bool) (string, bool) {
+	suffixes := []string{"bits", "bit", "b"}
+	if !hasPeriod {
+		suffixes = []string{"bps"}
+	}
+
+	for _, suffix := range suffixes {
+		if prefix, ok := strings.CutSuffix(unitStr, suffix); ok {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// bitsOver returns the byte rate equal to bits per period per
+func bitsOver(bits int64, per time.Duration) (Rate, error) {
+	if bits%8 == 0 {
+		return Rate{Bytes: Size(bits / 8), Per: per}, nil
+	}
+
+	// spread over eight periods so the byte count stays whole
+	if per > math.MaxInt64/8 {
+		return Rate{}, fmt.Errorf("rate period too long: %v", per)
+	}
+	return Rate{Bytes: Size(bits), Per: 8 * per}, nil
+}
+
+// RateFromBits returns the byte rate equal to bitsPerSec bits per second,
+// rounded to a whole number of bits
+func RateFromBits(bitsPerSec float64) Rate {
+	bits := math.Round(bitsPerSec)
+	switch {
+	case bits >= math.MaxInt64:
+		bits = math.MaxInt64 - 7
+	case bits < 0:
+		bits = 0
+	}
+
+	rate, _ := bitsOver(int64(bits), time.Second)
+	return rate
+}
+
+// BitsPerSecond returns the rate in bits per second
+func (r Rate) BitsPerSecond() float64 {
+	return r.BytesPerSecond() * 8
+}
+
+// FormatBits formats the rate as a bit rate like FormatBitRate, e.g.
+// "800 Mbit/s"
+func (r Rate) FormatBits(opts ...FormatOption) string {
+	return FormatBitRate(r.BytesPerSecond(), opts...)
+}
+
+// FormatBps converts a throughput in bytes per second to a bit rate in the
+// networking style, e.g. "100 Mbps" or "1.50 Gbps"
+//
+// It is FormatBitRate with "bps" units; options apply as for FormatSize.
+func FormatBps(bytesPerSec float64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	if bytesPerSec < 0 && cfg.clampNegative {
+		bytesPerSec = 0
+	}
+
+	var buf [formatBufferSize]byte
+	return string(cfg.appendScaled(buf[:0], compareZero(bytesPerSec), math.Abs(bytesPerSec)*8, siBpsUnits, "bps", ""))
+}
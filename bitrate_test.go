@@ -0,0 +1,101 @@
+package filesize
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseBitRate tests parsing network bit rates
+func TestParseBitRate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected float64
+		hasError bool
+	}{
+		{"100Mbps", 12.5e6, false},
+		{"1Gbps", 125e6, false},
+		{"1.5 Gbit/s", 187.5e6, false},
+		{"10 Mb/s", 1.25e6, false},
+		{"64kbps", 8000, false},
+		{"64Kbps", 8000, false},
+		{"2Mibit/s", 2 * 1024 * 1024 / 8, false},
+		{"100bps", 12.5, false},
+		{"1bps", 0.125, false},
+		{"8bits/s", 1, false},
+		{"36Gbit/hour", 1.25e6, false},
+		{"10MB/s", 0, true},
+		{"10MBps", 0, true},
+		{"10Mbit", 0, true},
+		{"10Mbps/s", 0, true},
+		{"10Xbps", 0, true},
+		{"fast", 0, true},
+		{"-1Mbps", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseBitRate(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseBitRate(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBitRate(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if bytesPerSec := result.BytesPerSecond(); bytesPerSec != tc.expected {
+			t.Errorf("ParseBitRate(%q) = %g B/s, expected %g", tc.input, bytesPerSec, tc.expected)
+		}
+	}
+}
+
+// TestParseBitRate_Exact tests that odd bit counts stay exact
+func TestParseBitRate_Exact(t *testing.T) {
+	result, err := ParseBitRate("100bps")
+	if err != nil {
+		t.Fatalf("ParseBitRate(100bps) unexpected error: %v", err)
+	}
+	if expected := (Rate{Bytes: 100, Per: 8 * time.Second}); result != expected {
+		t.Errorf("ParseBitRate(100bps) = %+v, expected %+v", result, expected)
+	}
+}
+
+// TestRate_Bits tests conversion between byte and bit rates
+func TestRate_Bits(t *testing.T) {
+	rate := RateFromBits(100e6)
+	if rate != PerSecond(12500000) {
+		t.Errorf("RateFromBits(100e6) = %+v, expected %+v", rate, PerSecond(12500000))
+	}
+	if bits := rate.BitsPerSecond(); bits != 100e6 {
+		t.Errorf("BitsPerSecond() = %g, expected %g", bits, 100e6)
+	}
+	if result := rate.FormatBits(); result != "100 Mbit/s" {
+		t.Errorf("FormatBits() = %q, expected %q", result, "100 Mbit/s")
+	}
+	if bits := RateFromBits(12).BitsPerSecond(); bits != 12 {
+		t.Errorf("RateFromBits(12).BitsPerSecond() = %g, expected 12", bits)
+	}
+}
+
+// TestFormatBps tests networking style bit rate formatting
+func TestFormatBps(t *testing.T) {
+	testCases := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0 bps"},
+		{12.5, "100 bps"},
+		{12.5e6, "100 Mbps"},
+		{187.5e6, "1.50 Gbps"},
+		{8000, "64.0 kbps"},
+		{-125e6, "-1.00 Gbps"},
+	}
+
+	for _, tc := range testCases {
+		if result := FormatBps(tc.input); result != tc.expected {
+			t.Errorf("FormatBps(%g) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package filesize
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Budget is a cumulative byte allowance shared by any number of writers
+//
+// Every write through a writer from Writer draws on the same budget, which
+// makes it suitable for per-tenant or per-job quotas spread over many files.
+// A write that does not fit is refused as a whole with a *BudgetError, so
+// quota enforcement never leaves half-written records behind. A Budget is
+// safe for concurrent use.
+type Budget struct {
+	mu      sync.Mutex
+	allowed int64
+	used    int64
+}
+
+// BudgetError reports a write refused because it would exceed a Budget
+//
+// It matches ErrLimitExceeded with errors.Is.
+type BudgetError struct {
+	// Written is the amount of the budget used before the write
+	Written Size
+
+	// Attempted is the size of the refused write
+	Attempted Size
+
+	// Allowed is the total budget
+	Allowed Size
+}
+
+// Error describes the refused write, e.g. "write of 4.00 MiB exceeds
+// budget: 498 MiB of 500 MiB used"
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("write of %s exceeds budget: %s of %s used", e.Attempted, e.Written, e.Allowed)
+}
+
+// Is reports whether target is ErrLimitExceeded
+func (e *BudgetError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// NewBudget returns a budget of allowed, a size string such as "500MiB"
+func NewBudget(allowed string) (*Budget, error) {
+	n, err := ParseSize(allowed)
+	if err != nil {
+		return nil, err
+	}
+	return &Budget{allowed: n}, nil
+}
+
+// Writer returns a writer to w that draws on the budget
+func (b *Budget) Writer(w io.Writer) io.Writer {
+	return &budgetWriter{w: w, b: b}
+}
+
+// Used returns how much of the budget has been written
+func (b *Budget) Used() Size {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Size(b.used)
+}
+
+// Remaining returns how much of the budget is left
+func (b *Budget) Remaining() Size {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Size(b.allowed - b.used)
+}
+
+// Allowed returns the total budget
+func (b *Budget) Allowed() Size {
+	return Size(b.allowed)
+}
+
+// reserve takes n bytes from the budget or reports why it cannot
+func (b *Budget) reserve(n int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n > b.allowed-b.used {
+		return &BudgetError{Written: Size(b.used), Attempted: Size(n), Allowed: Size(b.allowed)}
+	}
+	b.used += n
+	return nil
+}
+
+// release returns n unused bytes to the budget
+func (b *Budget) release(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used -= n
+}
+
+// budgetWriter is a writer drawing on a Budget
+type budgetWriter struct {
+	w io.Writer
+	b *Budget
+}
+
+// Write reserves len(p) bytes, writes p and refunds anything not written
+func (bw *budgetWriter) Write(p []byte) (int, error) {
+	if err := bw.b.reserve(int64(len(p))); err != nil {
+		return 0, err
+	}
+
+	n, err := bw.w.Write(p)
+	if n < len(p) {
+		bw.b.release(int64(len(p) - n))
+	}
+	return n, err
+}
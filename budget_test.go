@@ -0,0 +1,70 @@
+package filesize
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestBudget tests a budget shared by two writers
+func TestBudget(t *testing.T) {
+	b, err := NewBudget("500MiB")
+	if err != nil {
+		t.Fatalf("NewBudget(500MiB) unexpected error: %v", err)
+	}
+
+	var first, second bytes.Buffer
+	w1, w2 := b.Writer(&first), b.Writer(&second)
+
+	if _, err := w1.Write(make([]byte, 300*MiB)); err != nil {
+		t.Fatalf("Write(300 MiB) unexpected error: %v", err)
+	}
+	if _, err := w2.Write(make([]byte, 198*MiB)); err != nil {
+		t.Fatalf("Write(198 MiB) unexpected error: %v", err)
+	}
+
+	n, err := w1.Write(make([]byte, 4*MiB))
+	if n != 0 || !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Write(4 MiB) over budget = %d, %v, expected 0 and ErrLimitExceeded", n, err)
+	}
+
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Write(4 MiB) error %T, expected *BudgetError", err)
+	}
+	expected := BudgetError{Written: Size(498 * MiB), Attempted: Size(4 * MiB), Allowed: Size(500 * MiB)}
+	if *budgetErr != expected {
+		t.Errorf("BudgetError = %+v, expected %+v", *budgetErr, expected)
+	}
+	if msg := err.Error(); msg != "write of 4.00 MiB exceeds budget: 498 MiB of 500 MiB used" {
+		t.Errorf("Error() = %q, expected %q", msg, "write of 4.00 MiB exceeds budget: 498 MiB of 500 MiB used")
+	}
+
+	// the remainder can still be used exactly
+	if _, err := w2.Write(make([]byte, 2*MiB)); err != nil {
+		t.Errorf("Write(2 MiB) filling the budget unexpected error: %v", err)
+	}
+	if b.Used() != b.Allowed() || b.Remaining() != 0 {
+		t.Errorf("Used() = %d, Remaining() = %d, expected the budget used up", int64(b.Used()), int64(b.Remaining()))
+	}
+}
+
+// TestBudget_ShortWrite tests that unwritten bytes are refunded
+func TestBudget_ShortWrite(t *testing.T) {
+	b, err := NewBudget("1k")
+	if err != nil {
+		t.Fatalf("NewBudget(1k) unexpected error: %v", err)
+	}
+
+	w := b.Writer(&failingWriter{remaining: 100})
+	if n, err := w.Write(make([]byte, 600)); n != 100 || err == nil {
+		t.Errorf("Write(600) = %d, %v, expected a short write of 100", n, err)
+	}
+	if b.Used() != 100 {
+		t.Errorf("Used() = %d, expected 100 after the short write", int64(b.Used()))
+	}
+
+	if _, err := NewBudget("-1k"); err == nil {
+		t.Errorf("NewBudget(-1k) expected error but got none")
+	}
+}
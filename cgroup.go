@@ -0,0 +1,146 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// cgroupUnlimited is the smallest value cgroup v1 uses for "no limit",
+// which is math.MaxInt64 rounded down to the page size; rounding to 64 KiB
+// covers every page size in use
+const cgroupUnlimited = math.MaxInt64 &^ (64*KiB - 1)
+
+// ParseCgroupMemory parses the contents of a cgroup memory limit file,
+// memory.max on cgroup v2 or memory.limit_in_bytes on v1
+//
+// The literal "max" and v1's page-aligned maximum both mean no limit, which
+// is reported as limited == false. Surrounding whitespace, including the
+// trailing newline the kernel writes, is ignored.
+func ParseCgroupMemory(s string) (limit Size, limited bool, err error) {
+	s = strings.TrimSpace(s)
+	if s == "max" {
+		return 0, false, nil
+	}
+
+	bytes, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || bytes < 0 {
+		return 0, false, fmt.Errorf("invalid cgroup memory value: %q", s)
+	}
+	if bytes >= cgroupUnlimited {
+		return 0, false, nil
+	}
+	return Size(bytes), true, nil
+}
+
+// ReadCgroupMemory reads and parses a cgroup memory limit file such as
+// /sys/fs/cgroup/memory.max
+func ReadCgroupMemory(file string) (limit Size, limited bool, err error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, false, err
+	}
+
+	limit, limited, err = ParseCgroupMemory(string(data))
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", file, err)
+	}
+	return limit, limited, nil
+}
+
+// CgroupMemoryLimit returns the memory limit of the cgroup this process
+// runs in, so containerized services can size caches as a fraction of it
+//
+// The process's cgroup is read from /proc/self/cgroup, preferring the v1
+// memory controller when one is listed and cgroup v2 otherwise. Limits set
+// on parent cgroups apply too, so the smallest limit between the process's
+// cgroup and the root is returned; containers that mount only their own
+// cgroup at /sys/fs/cgroup are handled by the same walk. limited is false
+// when no cgroup sets a limit, and an error wrapping fs.ErrNotExist means
+// no cgroup memory files were found at all, as outside Linux.
+func CgroupMemoryLimit() (limit Size, limited bool, err error) {
+	return cgroupMemoryLimit(os.DirFS("/"))
+}
+
+// cgroupMemoryLimit implements CgroupMemoryLimit over a root file system
+func cgroupMemoryLimit(root fs.FS) (Size, bool, error) {
+	dir, file := cgroupMemoryFile(root)
+
+	found := false
+	var limit Size
+	limited := false
+	for {
+		name := path.Join(dir, file)
+		data, err := fs.ReadFile(root, name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// the root cgroup and unmapped paths have no limit file
+		case err != nil:
+			return 0, false, err
+		default:
+			found = true
+			value, ok, err := ParseCgroupMemory(string(data))
+			if err != nil {
+				return 0, false, fmt.Errorf("/%s: %w", name, err)
+			}
+			if ok && (!limited || value < limit) {
+				limit, limited = value, true
+			}
+		}
+
+		if dir == "sys/fs/cgroup" || dir == "sys/fs/cgroup/memory" {
+			break
+		}
+		dir = path.Dir(dir)
+	}
+
+	if !found {
+		return 0, false, fmt.Errorf("cgroup memory limit: %w", fs.ErrNotExist)
+	}
+	return limit, limited, nil
+}
+
+// cgroupMemoryFile returns the cgroup directory of the current process and
+// the name of its memory limit file, relative to the root file system
+func cgroupMemoryFile(root fs.FS) (dir, file string) {
+	data, err := fs.ReadFile(root, "proc/self/cgroup")
+	if err != nil {
+		// without /proc, assume cgroup v2 mounted at our own cgroup
+		return "sys/fs/cgroup", "memory.max"
+	}
+
+	// lines look like "0::/path" for v2 and "4:memory:/path" for v1
+	v2 := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			v2 = fields[2]
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			if controller == "memory" {
+				return cgroupDir("sys/fs/cgroup/memory", fields[2]), "memory.limit_in_bytes"
+			}
+		}
+	}
+	return cgroupDir("sys/fs/cgroup", v2), "memory.max"
+}
+
+// cgroupDir joins a cgroup path to its mount point, falling back to the
+// mount point for paths outside it, such as the "/../.." that nested
+// cgroup namespaces report
+func cgroupDir(mount, cgroup string) string {
+	dir := path.Join(mount, cgroup)
+	if !strings.HasPrefix(dir+"/", mount+"/") {
+		return mount
+	}
+	return dir
+}
@@ -0,0 +1,179 @@
+package filesize
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// TestParseCgroupMemory tests limit file contents from both cgroup versions
+func TestParseCgroupMemory(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+		limited  bool
+		hasError bool
+	}{
+		{"max\n", 0, false, false},
+		{"536870912\n", 512 * MiB, true, false},
+		{"0", 0, true, false},
+		{"9223372036854771712\n", 0, false, false},
+		{"9223372036854710272\n", 0, false, false},
+		{"9223372036854644736\n", 9223372036854644736, true, false},
+		{"", 0, false, true},
+		{"512M", 0, false, true},
+		{"-1", 0, false, true},
+		{"MAX", 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		limit, limited, err := ParseCgroupMemory(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseCgroupMemory(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseCgroupMemory(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if int64(limit) != tc.expected || limited != tc.limited {
+			t.Errorf("ParseCgroupMemory(%q) = (%d, %v), expected (%d, %v)",
+				tc.input, int64(limit), limited, tc.expected, tc.limited)
+		}
+	}
+}
+
+// TestReadCgroupMemory tests reading a limit file from disk
+func TestReadCgroupMemory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "memory.max")
+	if err := os.WriteFile(file, []byte("1073741824\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	limit, limited, err := ReadCgroupMemory(file)
+	if err != nil || !limited || int64(limit) != GiB {
+		t.Errorf("ReadCgroupMemory() = (%d, %v, %v), expected (%d, true, nil)", int64(limit), limited, err, GiB)
+	}
+
+	if _, _, err := ReadCgroupMemory(file + ".missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadCgroupMemory() of a missing file = %v, expected fs.ErrNotExist", err)
+	}
+}
+
+// TestCgroupMemoryLimit tests locating the process's limit in fake trees
+func TestCgroupMemoryLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fsys     fstest.MapFS
+		expected int64
+		limited  bool
+		hasError bool
+	}{
+		{
+			name: "v2 own cgroup",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte("0::/app.slice/web.service\n")},
+				"sys/fs/cgroup/app.slice/web.service/memory.max": {Data: []byte("268435456\n")},
+				"sys/fs/cgroup/app.slice/memory.max":             {Data: []byte("max\n")},
+			},
+			expected: 256 * MiB,
+			limited:  true,
+		},
+		{
+			name: "v2 parent limit is smaller",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte("0::/app.slice/web.service\n")},
+				"sys/fs/cgroup/app.slice/web.service/memory.max": {Data: []byte("max\n")},
+				"sys/fs/cgroup/app.slice/memory.max":             {Data: []byte("134217728\n")},
+			},
+			expected: 128 * MiB,
+			limited:  true,
+		},
+		{
+			name: "v2 container namespace",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("2147483648\n")},
+			},
+			expected: 2 * GiB,
+			limited:  true,
+		},
+		{
+			name: "v2 unmapped host path",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/docker/abc123\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("1073741824\n")},
+			},
+			expected: GiB,
+			limited:  true,
+		},
+		{
+			name: "v2 unlimited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                    {Data: []byte("0::/user.slice\n")},
+				"sys/fs/cgroup/user.slice/memory.max": {Data: []byte("max\n")},
+			},
+		},
+		{
+			name: "v1 memory controller",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte("12:pids:/docker/abc\n4:cpu,memory:/docker/abc\n0::/\n")},
+				"sys/fs/cgroup/memory/docker/abc/memory.limit_in_bytes": {Data: []byte("536870912\n")},
+				"sys/fs/cgroup/memory/memory.limit_in_bytes":            {Data: []byte("9223372036854771712\n")},
+			},
+			expected: 512 * MiB,
+			limited:  true,
+		},
+		{
+			name: "v2 path outside the mount",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/../..\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("1073741824\n")},
+			},
+			expected: GiB,
+			limited:  true,
+		},
+		{
+			name: "no proc",
+			fsys: fstest.MapFS{
+				"sys/fs/cgroup/memory.max": {Data: []byte("max\n")},
+			},
+		},
+		{
+			name:     "no cgroup files",
+			fsys:     fstest.MapFS{"proc/self/cgroup": {Data: []byte("0::/\n")}},
+			hasError: true,
+		},
+		{
+			name: "malformed limit",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("lots\n")},
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		limit, limited, err := cgroupMemoryLimit(tc.fsys)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("%s: CgroupMemoryLimit() expected error but got none", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: CgroupMemoryLimit() unexpected error: %v", tc.name, err)
+			continue
+		}
+		if int64(limit) != tc.expected || limited != tc.limited {
+			t.Errorf("%s: CgroupMemoryLimit() = (%d, %v), expected (%d, %v)",
+				tc.name, int64(limit), limited, tc.expected, tc.limited)
+		}
+	}
+}
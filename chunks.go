@@ -0,0 +1,96 @@
+package filesize
+
+import "fmt"
+
+// Chunk is one piece of a chunked transfer
+type Chunk struct {
+	// Index is the zero-based position of the chunk
+	Index int64
+
+	// Offset is the byte offset of the chunk's first byte
+	Offset int64
+
+	// Length is the number of bytes in the chunk
+	Length int64
+}
+
+// End returns the offset just past the chunk's last byte
+func (c Chunk) End() int64 {
+	return c.Offset + c.Length
+}
+
+// ChunkPlan describes how a total splits into fixed-size chunks
+//
+// Every chunk is ChunkSize bytes except the last, which holds the remainder.
+// Individual chunks are computed on demand, so plans for huge totals cost
+// nothing until they are walked.
+type ChunkPlan struct {
+	// Total is the number of bytes being split
+	Total int64
+
+	// ChunkSize is the size of every chunk but the last
+	ChunkSize int64
+
+	// Count is the number of chunks, zero for an empty total
+	Count int64
+
+	// Last is the size of the final chunk, zero for an empty total
+	Last int64
+}
+
+// Chunks splits total bytes into chunks of chunkSize
+//
+// For example Chunks(10*MiB, 4*MiB) yields three chunks with a 2 MiB last
+// chunk, ready to drive multipart uploads or parallel range downloads. The
+// chunk size must be positive and the total not negative.
+func Chunks(total, chunkSize int64) (ChunkPlan, error) {
+	if chunkSize <= 0 {
+		return ChunkPlan{}, fmt.Errorf("invalid chunk size: %d", chunkSize)
+	}
+	if total < 0 {
+		return ChunkPlan{}, fmt.Errorf("size cannot be negative: %d", total)
+	}
+
+	plan := ChunkPlan{Total: total, ChunkSize: chunkSize}
+	if total == 0 {
+		return plan, nil
+	}
+
+	plan.Count = (total-1)/chunkSize + 1
+	plan.Last = total - (plan.Count-1)*chunkSize
+	return plan, nil
+}
+
+// Chunk returns the chunk at index i, which must be in [0, Count)
+func (p ChunkPlan) Chunk(i int64) Chunk {
+	if i < 0 || i >= p.Count {
+		panic(fmt.Sprintf("filesize: chunk index %d out of range [0, %d)", i, p.Count))
+	}
+
+	length := p.ChunkSize
+	if i == p.Count-1 {
+		length = p.Last
+	}
+	return Chunk{Index: i, Offset: i * p.ChunkSize, Length: length}
+}
+
+// Each calls fn for every chunk in order until fn returns false
+func (p ChunkPlan) Each(fn func(Chunk) bool) {
+	for i := int64(0); i < p.Count; i++ {
+		if !fn(p.Chunk(i)) {
+			return
+		}
+	}
+}
+
+// All returns every chunk as a slice
+//
+// Prefer Each or Chunk for plans with very many chunks.
+func (p ChunkPlan) All() []Chunk {
+	chunks := make([]Chunk, 0, p.Count)
+	p.Each(func(c Chunk) bool {
+		chunks = append(chunks, c)
+		return true
+	})
+	return chunks
+}
@@ -0,0 +1,68 @@
+package filesize
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestChunks tests chunk counts and last-chunk sizes
+func TestChunks(t *testing.T) {
+	testCases := []struct {
+		total, chunkSize int64
+		count, last      int64
+		hasError         bool
+	}{
+		{10 * MiB, 4 * MiB, 3, 2 * MiB, false},
+		{8 * MiB, 4 * MiB, 2, 4 * MiB, false},
+		{1, 4 * MiB, 1, 1, false},
+		{0, 4 * MiB, 0, 0, false},
+		{math.MaxInt64, GiB, 8589934592, GiB - 1, false},
+		{MiB, 0, 0, 0, true},
+		{-1, MiB, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		plan, err := Chunks(tc.total, tc.chunkSize)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Chunks(%d, %d) expected error but got none", tc.total, tc.chunkSize)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Chunks(%d, %d) unexpected error: %v", tc.total, tc.chunkSize, err)
+			continue
+		}
+		if plan.Count != tc.count || plan.Last != tc.last {
+			t.Errorf("Chunks(%d, %d) = %d chunks, last %d, expected %d chunks, last %d",
+				tc.total, tc.chunkSize, plan.Count, plan.Last, tc.count, tc.last)
+		}
+		if plan.Count > 0 && plan.Chunk(plan.Count-1).End() != tc.total {
+			t.Errorf("Chunks(%d, %d) last chunk ends at %d, expected %d",
+				tc.total, tc.chunkSize, plan.Chunk(plan.Count-1).End(), tc.total)
+		}
+	}
+}
+
+// TestChunkPlan_All tests walking every chunk
+func TestChunkPlan_All(t *testing.T) {
+	plan, err := Chunks(10, 4)
+	if err != nil {
+		t.Fatalf("Chunks(10, 4) unexpected error: %v", err)
+	}
+
+	expected := []Chunk{{0, 0, 4}, {1, 4, 4}, {2, 8, 2}}
+	if chunks := plan.All(); !slices.Equal(chunks, expected) {
+		t.Errorf("All() = %v, expected %v", chunks, expected)
+	}
+
+	var visited []int64
+	plan.Each(func(c Chunk) bool {
+		visited = append(visited, c.Index)
+		return c.Index < 1
+	})
+	if !slices.Equal(visited, []int64{0, 1}) {
+		t.Errorf("Each() visited %v, expected to stop after chunk 1", visited)
+	}
+}
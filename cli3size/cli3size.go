@@ -0,0 +1,37 @@
+// Package cli3size provides human-readable size flags for urfave/cli v3.
+//
+// It mirrors the clisize package for v2:
+//
+//	cmd.Flags = []cli.Flag{
+//		cli3size.Flag("max-upload", 10*filesize.MiB, "largest accepted upload", "MAX_UPLOAD"),
+//	}
+//	...
+//	limit := cli3size.Size(cmd, "max-upload")
+package cli3size
+
+import (
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/urfave/cli/v3"
+)
+
+// Flag returns a size flag with the given default, read from the first set
+// environment variable in envVars when not given on the command line
+//
+// Help output shows the default in humanized form, e.g. "(default: 10MiB)".
+func Flag(name string, value int64, usage string, envVars ...string) *cli.GenericFlag {
+	return &cli.GenericFlag{
+		Name:    name,
+		Usage:   usage,
+		Sources: cli.EnvVars(envVars...),
+		Value:   filesize.NewSizeValue(filesize.Size(value), new(filesize.Size)),
+	}
+}
+
+// Size returns the value of the named size flag, or zero when the flag is
+// not defined or is not a size flag
+func Size(cmd *cli.Command, name string) filesize.Size {
+	// v3 reports flag values through flag.Getter, which SizeValue implements
+	// by returning a filesize.Size
+	size, _ := cmd.Value(name).(filesize.Size)
+	return size
+}
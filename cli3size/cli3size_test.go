@@ -0,0 +1,88 @@
+package cli3size
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/urfave/cli/v3"
+)
+
+// runCommand runs a command with a single size flag and returns the parsed value
+func runCommand(args []string) (filesize.Size, error) {
+	var result filesize.Size
+	cmd := &cli.Command{
+		Name:      "upload",
+		Flags:     []cli.Flag{Flag("max-upload", 10*filesize.MiB, "largest accepted upload", "MAX_UPLOAD")},
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			result = Size(cmd, "max-upload")
+			return nil
+		},
+	}
+
+	err := cmd.Run(context.Background(), append([]string{"upload"}, args...))
+	return result, err
+}
+
+// TestFlag tests command-line parsing and defaults
+func TestFlag(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected filesize.Size
+		hasError bool
+	}{
+		{nil, filesize.Size(10 * filesize.MiB), false},
+		{[]string{"--max-upload", "1g"}, filesize.Size(filesize.GiB), false},
+		{[]string{"--max-upload=512KB"}, filesize.Size(512 * filesize.KB), false},
+		{[]string{"--max-upload", "huge"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := runCommand(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Run(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Run(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("Run(%q) = %d, expected %d", tc.args, int64(result), int64(tc.expected))
+		}
+	}
+}
+
+// TestFlag_EnvVars tests sourcing the value from the environment
+func TestFlag_EnvVars(t *testing.T) {
+	t.Setenv("MAX_UPLOAD", "2GiB")
+
+	result, err := runCommand(nil)
+	if err != nil || result != filesize.Size(2*filesize.GiB) {
+		t.Errorf("Run() with MAX_UPLOAD=2GiB = %d, %v, expected %d", int64(result), err, 2*filesize.GiB)
+	}
+}
+
+// TestFlag_Help tests that the default is rendered humanized
+func TestFlag_Help(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cli.Command{
+		Name:   "upload",
+		Flags:  []cli.Flag{Flag("max-upload", 10*filesize.MiB, "largest accepted upload", "MAX_UPLOAD")},
+		Writer: &out,
+	}
+	if err := cmd.Run(context.Background(), []string{"upload", "--help"}); err != nil {
+		t.Fatalf("Run(--help) unexpected error: %v", err)
+	}
+
+	if help := out.String(); !strings.Contains(help, "(default: 10MiB)") || !strings.Contains(help, "$MAX_UPLOAD") {
+		t.Errorf("help output = %q, expected default 10MiB and $MAX_UPLOAD", help)
+	}
+}
@@ -0,0 +1,41 @@
+// Package clisize provides human-readable size flags for urfave/cli v2.
+//
+// Flags are ordinary cli.GenericFlag values backed by filesize.SizeValue, so
+// they take part in help output, environment variable sourcing and
+// validation like any other flag:
+//
+//	app.Flags = []cli.Flag{
+//		clisize.Flag("max-upload", 10*filesize.MiB, "largest accepted upload", "MAX_UPLOAD"),
+//	}
+//	...
+//	limit := clisize.Size(c, "max-upload")
+//
+// For urfave/cli v3 use the cli3size package.
+package clisize
+
+import (
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag returns a size flag with the given default, read from the first set
+// environment variable in envVars when not given on the command line
+//
+// Help output shows the default in humanized form, e.g. "(default: 10MiB)".
+func Flag(name string, value int64, usage string, envVars ...string) *cli.GenericFlag {
+	return &cli.GenericFlag{
+		Name:    name,
+		Usage:   usage,
+		EnvVars: envVars,
+		Value:   filesize.NewSizeValue(filesize.Size(value), new(filesize.Size)),
+	}
+}
+
+// Size returns the value of the named size flag, or zero when the flag is
+// not defined or is not a size flag
+func Size(c *cli.Context, name string) filesize.Size {
+	if v, ok := c.Generic(name).(*filesize.SizeValue); ok {
+		return filesize.Size(*v)
+	}
+	return 0
+}
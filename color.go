@@ -0,0 +1,112 @@
+package filesize
+
+import (
+	"io"
+	"os"
+)
+
+// ANSI escape sequences for the colors used by the default thresholds
+const (
+	ColorGreen  = "\x1b[32m"
+	ColorYellow = "\x1b[33m"
+	ColorRed    = "\x1b[31m"
+
+	// colorReset restores the terminal's default attributes
+	colorReset = "\x1b[0m"
+)
+
+// ColorThreshold assigns a color to sizes strictly below a byte count
+type ColorThreshold struct {
+	// Below is the exclusive upper bound of the range this color applies to
+	Below int64
+
+	// Color is the ANSI escape sequence emitted before the formatted size
+	Color string
+}
+
+// Colorizer wraps formatted sizes in ANSI colors chosen by thresholds
+//
+// Thresholds are checked in order and the first one whose Below exceeds the
+// value wins; values past every threshold use Above. A Colorizer with Enabled
+// set to false returns plain text, which is how output to files and pipes
+// stays free of escape sequences.
+type Colorizer struct {
+	// Thresholds lists the color ranges in ascending order of Below
+	Thresholds []ColorThreshold
+
+	// Above is the color for values at or above the last threshold
+	Above string
+
+	// Enabled turns colorization on, see NewColorizer for detection
+	Enabled bool
+}
+
+// NewColorizer returns a Colorizer with the default thresholds for output
+// written to w
+//
+// Sizes below 1 GiB are green, below 10 GiB yellow, and red above that.
+// Colors are only enabled when w is a terminal, the NO_COLOR environment
+// variable is unset and TERM is not "dumb".
+func NewColorizer(w io.Writer) Colorizer {
+	return Colorizer{
+		Thresholds: []ColorThreshold{
+			{Below: GiB, Color: ColorGreen},
+			{Below: 10 * GiB, Color: ColorYellow},
+		},
+		Above:   ColorRed,
+		Enabled: colorSupported(w),
+	}
+}
+
+// Color returns the escape sequence for bytes, or "" when disabled
+func (c Colorizer) Color(bytes int64) string {
+	if !c.Enabled {
+		return ""
+	}
+
+	// the first threshold above the value decides the color
+	for _, threshold := range c.Thresholds {
+		if bytes < threshold.Below {
+			return threshold.Color
+		}
+	}
+
+	return c.Above
+}
+
+// Wrap surrounds s with the color for bytes and a reset sequence
+func (c Colorizer) Wrap(bytes int64, s string) string {
+	color := c.Color(bytes)
+	if color == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// Format formats bytes with FormatSize and colors the result
+func (c Colorizer) Format(bytes int64, opts ...FormatOption) string {
+	return c.Wrap(bytes, FormatSize(bytes, opts...))
+}
+
+// colorSupported reports whether colored output should be written to w
+func colorSupported(w io.Writer) bool {
+	// honor the NO_COLOR convention and dumb terminals
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	// only character devices such as terminals get colors
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,42 @@
+package filesize
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestColorizer tests threshold selection and wrapping
+func TestColorizer(t *testing.T) {
+	c := NewColorizer(&bytes.Buffer{})
+	c.Enabled = true
+
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, ColorGreen + "0 B" + colorReset},
+		{512 * MiB, ColorGreen + "512 MiB" + colorReset},
+		{GiB, ColorYellow + "1.00 GiB" + colorReset},
+		{5 * GiB, ColorYellow + "5.00 GiB" + colorReset},
+		{10 * GiB, ColorRed + "10.0 GiB" + colorReset},
+	}
+
+	for _, tc := range testCases {
+		result := c.Format(tc.input)
+		if result != tc.expected {
+			t.Errorf("Format(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestColorizer_Disabled tests that non-terminal writers get plain output
+func TestColorizer_Disabled(t *testing.T) {
+	c := NewColorizer(&bytes.Buffer{})
+	if c.Enabled {
+		t.Fatalf("NewColorizer(buffer) enabled colors for a non-terminal writer")
+	}
+
+	if result := c.Format(20 * GiB); result != "20.0 GiB" {
+		t.Errorf("Format(20 GiB) = %q, expected %q", result, "20.0 GiB")
+	}
+}
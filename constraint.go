@@ -0,0 +1,245 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Constraint is a set of rules a size must satisfy, built by chaining, e.g.
+//
+//	filesize.Min("1k").Max("1G").MultipleOf("4k").PowerOfTwo()
+//
+// Each call returns a new Constraint, so a shared base can be extended
+// without affecting other users. The zero value accepts every size.
+//
+// Rule parameters often come from config themselves, so an invalid one is
+// recorded rather than panicking: Err reports it, and Check and Validate
+// return it for every size. Wrap constraints built from literals in
+// MustConstraint to fail at startup instead.
+type Constraint struct {
+	min, max, multipleOf          int64
+	hasMin, hasMax, hasMultipleOf bool
+	powerOfTwo                    bool
+
+	// err is the first invalid rule parameter
+	err error
+}
+
+// MustConstraint returns c, panicking if any of its rule parameters was
+// invalid; it suits package-level constraints built from literals, like
+// regexp.MustCompile
+func MustConstraint(c Constraint) Constraint {
+	if c.err != nil {
+		panic("filesize: " + c.err.Error())
+	}
+	return c
+}
+
+// Min returns a Constraint requiring sizes of at least size
+func Min(size string) Constraint {
+	return Constraint{}.Min(size)
+}
+
+// Max returns a Constraint requiring sizes of at most size
+func Max(size string) Constraint {
+	return Constraint{}.Max(size)
+}
+
+// MultipleOf returns a Constraint requiring sizes divisible by size
+func MultipleOf(size string) Constraint {
+	return Constraint{}.MultipleOf(size)
+}
+
+// Aligned returns a Constraint requiring sizes aligned to boundary
+func Aligned(boundary string) Constraint {
+	return Constraint{}.Aligned(boundary)
+}
+
+// PowerOfTwo returns a Constraint requiring sizes that are powers of two
+func PowerOfTwo() Constraint {
+	return Constraint{}.PowerOfTwo()
+}
+
+// Min adds a lower bound, replacing any earlier one
+func (c Constraint) Min(size string) Constraint {
+	if bytes, ok := c.parseRule("Min", size, false); ok {
+		c.min, c.hasMin = bytes, true
+	}
+	return c
+}
+
+// Max adds an upper bound, replacing any earlier one
+func (c Constraint) Max(size string) Constraint {
+	if bytes, ok := c.parseRule("Max", size, false); ok {
+		c.max, c.hasMax = bytes, true
+	}
+	return c
+}
+
+// MultipleOf requires sizes divisible by size, which must be positive
+func (c Constraint) MultipleOf(size string) Constraint {
+	if bytes, ok := c.parseRule("MultipleOf", size, true); ok {
+		c.multipleOf, c.hasMultipleOf = bytes, true
+	}
+	return c
+}
+
+// Aligned is MultipleOf under the name block sizes, stripe widths and
+// buffer lengths are usually described with; violations read "must be a
+// multiple of 4 KiB"
+func (c Constraint) Aligned(boundary string) Constraint {
+	if bytes, ok := c.parseRule("Aligned", boundary, true); ok {
+		c.multipleOf, c.hasMultipleOf = bytes, true
+	}
+	return c
+}
+
+// PowerOfTwo requires sizes that are powers of two, zero excluded
+func (c Constraint) PowerOfTwo() Constraint {
+	c.powerOfTwo = true
+	return c
+}
+
+// parseRule parses the parameter of a constraint rule, recording the first
+// failure in c.err and reporting whether the parameter is usable
+func (c *Constraint) parseRule(rule, size string, positive bool) (int64, bool) {
+	bytes, err := ParseSize(size)
+	if err == nil && positive && bytes == 0 {
+		err = errors.New("must be greater than zero")
+	}
+	if err != nil {
+		if c.err == nil {
+			c.err = fmt.Errorf("invalid %s parameter %q: %w", rule, size, err)
+		}
+		return 0, false
+	}
+	return bytes, true
+}
+
+// Err returns the first invalid rule parameter given while building c, or
+// nil
+func (c Constraint) Err() error {
+	return c.err
+}
+
+// Check returns a *ConstraintError for the first rule bytes violates, or
+// nil when it satisfies them all
+//
+// Rules are checked in the order minimum, maximum, multiple, power of two.
+// A constraint with an invalid rule parameter returns that error instead.
+func (c Constraint) Check(bytes int64) error {
+	switch {
+	case c.err != nil:
+		return c.err
+	case c.hasMin && bytes < c.min:
+		return &ConstraintError{Rule: "min", Value: bytes, Limit: c.min}
+	case c.hasMax && bytes > c.max:
+		return &ConstraintError{Rule: "max", Value: bytes, Limit: c.max}
+	case c.hasMultipleOf && !IsAligned(bytes, c.multipleOf):
+		return &ConstraintError{Rule: "multiple_of", Value: bytes, Limit: c.multipleOf}
+	case c.powerOfTwo && !IsPowerOfTwo(bytes):
+		return &ConstraintError{Rule: "power_of_two", Value: bytes}
+	}
+	return nil
+}
+
+// Validate parses sizeStr with ParseSize and checks the result
+//
+// A string that does not parse returns the ParseSize error unchanged, and a
+// constraint with an invalid rule parameter returns that error.
+func (c Constraint) Validate(sizeStr string) (Size, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	bytes, err := ParseSize(sizeStr)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.Check(bytes); err != nil {
+		return 0, err
+	}
+	return Size(bytes), nil
+}
+
+// String describes the rules for help text, e.g. "at least 1 KiB, at most
+// 1 GiB, a multiple of 4 KiB", or "any size" for the zero value
+func (c Constraint) String() string {
+	var rules []string
+	if c.hasMin {
+		rules = append(rules, "at least "+formatExact(c.min, " "))
+	}
+	if c.hasMax {
+		rules = append(rules, "at most "+formatExact(c.max, " "))
+	}
+	if c.hasMultipleOf {
+		rules = append(rules, "a multiple of "+formatExact(c.multipleOf, " "))
+	}
+	if c.powerOfTwo {
+		rules = append(rules, "a power of two")
+	}
+	if len(rules) == 0 {
+		return "any size"
+	}
+	return strings.Join(rules, ", ")
+}
+
+// ConstraintError describes a size that failed a Constraint
+type ConstraintError struct {
+	// Rule is the rule that failed: "min", "max", "multiple_of" or
+	// "power_of_two"
+	Rule string
+
+	// Value is the rejected size in bytes
+	Value int64
+
+	// Limit is the rule's parameter in bytes, zero for "power_of_two"
+	Limit int64
+}
+
+// Error describes the violation in human-readable form, e.g. "6 KiB must
+// be a multiple of 4 KiB"
+func (e *ConstraintError) Error() string {
+	value, limit := formatExact(e.Value, " "), formatExact(e.Limit, " ")
+	switch e.Rule {
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", value, limit)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", value, limit)
+	case "multiple_of":
+		return fmt.Sprintf("%s must be a multiple of %s", value, limit)
+	default:
+		return fmt.Sprintf("%s must be a power of two", value)
+	}
+}
+
+// ValidateSizeInRange parses s and checks that it lies between min and max
+// inclusive, for the common case of validating a CLI flag
+//
+// A size outside the range returns an error naming it, e.g. "2 GiB must be
+// between 4 KiB and 1 GiB". Invalid bounds, or a min above max, are
+// reported as errors too.
+func ValidateSizeInRange(s, min, max string) error {
+	low, err := ParseSize(min)
+	if err != nil {
+		return fmt.Errorf("invalid minimum size %q: %w", min, err)
+	}
+	high, err := ParseSize(max)
+	if err != nil {
+		return fmt.Errorf("invalid maximum size %q: %w", max, err)
+	}
+	if low > high {
+		return fmt.Errorf("invalid size range: minimum %s exceeds maximum %s",
+			formatExact(low, " "), formatExact(high, " "))
+	}
+
+	bytes, err := ParseSize(s)
+	if err != nil {
+		return err
+	}
+	if !NewSizeRange(low, high).Contains(bytes) {
+		return fmt.Errorf("%s must be between %s and %s",
+			formatExact(bytes, " "), formatExact(low, " "), formatExact(high, " "))
+	}
+	return nil
+}
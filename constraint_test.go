@@ -0,0 +1,196 @@
+package filesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestConstraint_Check tests each rule and the order they are checked in
+func TestConstraint_Check(t *testing.T) {
+	all := Min("1k").Max("1G").MultipleOf("4k").PowerOfTwo()
+
+	testCases := []struct {
+		c        Constraint
+		bytes    int64
+		expected string
+	}{
+		{all, 4 * KiB, ""},
+		{all, GiB, ""},
+		{all, 512, "512 B must be at least 1 KiB"},
+		{all, 2 * GiB, "2 GiB must be at most 1 GiB"},
+		{all, 6 * KiB, "6 KiB must be a multiple of 4 KiB"},
+		{all, 12 * KiB, "12 KiB must be a power of two"},
+		{PowerOfTwo(), 0, "0 B must be a power of two"},
+		{PowerOfTwo(), -4, "-4 B must be a power of two"},
+		{PowerOfTwo(), 1, ""},
+		{Max("1M"), -1, ""},
+		{MultipleOf("1.5k"), 3 * KiB, ""},
+		{Aligned("4k"), 64 * KiB, ""},
+		{Aligned("4k"), 0, ""},
+		{Aligned("4k"), 5000, "5000 B must be a multiple of 4 KiB"},
+		{Min("4k").Aligned("192k"), 384 * KiB, ""},
+		{Min("4k").Aligned("192k"), 256 * KiB, "256 KiB must be a multiple of 192 KiB"},
+		{Constraint{}, -KiB, ""},
+	}
+
+	for _, tc := range testCases {
+		err := tc.c.Check(tc.bytes)
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("%v.Check(%d) unexpected error: %v", tc.c, tc.bytes, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("%v.Check(%d) = %v, expected %q", tc.c, tc.bytes, err, tc.expected)
+		}
+	}
+}
+
+// TestConstraint_Validate tests parsing and checking in one call
+func TestConstraint_Validate(t *testing.T) {
+	c := Min("4k").MultipleOf("4k")
+
+	testCases := []struct {
+		input    string
+		expected Size
+		rule     string
+		hasError bool
+	}{
+		{"64k", Size(64 * KiB), "", false},
+		{"1 MiB", Size(MiB), "", false},
+		{"2k", 0, "min", true},
+		{"10KB", 0, "multiple_of", true},
+		{"lots", 0, "", true},
+	}
+
+	for _, tc := range testCases {
+		result, err := c.Validate(tc.input)
+		if tc.hasError {
+			var ce *ConstraintError
+			switch {
+			case err == nil:
+				t.Errorf("Validate(%q) expected error but got none", tc.input)
+			case errors.As(err, &ce) != (tc.rule != ""):
+				t.Errorf("Validate(%q) error = %v, expected rule %q", tc.input, err, tc.rule)
+			case ce != nil && ce.Rule != tc.rule:
+				t.Errorf("Validate(%q) rule = %q, expected %q", tc.input, ce.Rule, tc.rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Validate(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("Validate(%q) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestConstraint_Immutable tests that chaining does not modify the receiver
+func TestConstraint_Immutable(t *testing.T) {
+	base := Min("1k")
+	strict := base.Max("1M")
+
+	if err := base.Check(GiB); err != nil {
+		t.Errorf("base.Check(1GiB) unexpected error: %v", err)
+	}
+	if err := strict.Check(GiB); err == nil {
+		t.Errorf("strict.Check(1GiB) expected error but got none")
+	}
+}
+
+// TestConstraint_String tests the help text description
+func TestConstraint_String(t *testing.T) {
+	testCases := []struct {
+		c        Constraint
+		expected string
+	}{
+		{Min("1k").Max("1G").MultipleOf("4k").PowerOfTwo(), "at least 1 KiB, at most 1 GiB, a multiple of 4 KiB, a power of two"},
+		{Max("1.5M"), "at most 1.5 MiB"},
+		{Constraint{}, "any size"},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.c.String(); result != tc.expected {
+			t.Errorf("String() = %q, expected %q", result, tc.expected)
+		}
+	}
+}
+
+// TestConstraint_InvalidParameter tests that bad rule parameters are
+// reported as errors rather than panics
+func TestConstraint_InvalidParameter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		c        Constraint
+		expected string
+	}{
+		{"Min", Min("lots"), `invalid Min parameter "lots": invalid size format: lots`},
+		{"Max", Constraint{}.Max(""), `invalid Max parameter "": empty size string`},
+		{"MultipleOf", MultipleOf("4x"), `invalid MultipleOf parameter "4x": unknown unit: x`},
+		{"MultipleOf 0", MultipleOf("0"), `invalid MultipleOf parameter "0": must be greater than zero`},
+		{"Aligned", Aligned("4 pages"), `invalid Aligned parameter "4 pages": unknown unit: pages`},
+		{"Aligned 0", Max("1G").Aligned("0k"), `invalid Aligned parameter "0k": must be greater than zero`},
+		{"first error wins", Min("a1").Max("b2").PowerOfTwo(), `invalid Min parameter "a1": invalid size format: a1`},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.c.Err(); err == nil || err.Error() != tc.expected {
+			t.Errorf("%s: Err() = %v, expected %q", tc.name, err, tc.expected)
+		}
+		if err := tc.c.Check(4 * KiB); err != tc.c.Err() {
+			t.Errorf("%s: Check() = %v, expected the parameter error", tc.name, err)
+		}
+		if _, err := tc.c.Validate("4k"); err != tc.c.Err() {
+			t.Errorf("%s: Validate() = %v, expected the parameter error", tc.name, err)
+		}
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: MustConstraint() expected panic but got none", tc.name)
+				}
+			}()
+			MustConstraint(tc.c)
+		}()
+	}
+
+	valid := MustConstraint(Min("1k").Max("1G"))
+	if err := valid.Err(); err != nil {
+		t.Errorf("MustConstraint(valid).Err() = %v, expected nil", err)
+	}
+}
+
+// TestValidateSizeInRange tests one-call range validation
+func TestValidateSizeInRange(t *testing.T) {
+	testCases := []struct {
+		input, min, max string
+		expected        string
+	}{
+		{"64k", "4k", "1G", ""},
+		{"4 KiB", "4k", "1G", ""},
+		{"1GiB", "4k", "1G", ""},
+		{"2G", "4k", "1G", "2 GiB must be between 4 KiB and 1 GiB"},
+		{"1000", "4k", "1G", "1000 B must be between 4 KiB and 1 GiB"},
+		{"1.5m", "1MB", "2MB", ""},
+		{"lots", "4k", "1G", "invalid size format: lots"},
+		{"64k", "4x", "1G", `invalid minimum size "4x": unknown unit: x`},
+		{"64k", "4k", "", `invalid maximum size "": empty size string`},
+		{"64k", "1G", "4k", "invalid size range: minimum 1 GiB exceeds maximum 4 KiB"},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateSizeInRange(tc.input, tc.min, tc.max)
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("ValidateSizeInRange(%q, %q, %q) unexpected error: %v", tc.input, tc.min, tc.max, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("ValidateSizeInRange(%q, %q, %q) = %v, expected %q", tc.input, tc.min, tc.max, err, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package filesize
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// CountingReader wraps an io.Reader and counts the bytes read through it
+//
+// The count is kept atomically, so another goroutine can report progress
+// while a copy is running. Its String method prints the count in humanized
+// form, e.g. "copied " + cr.String() gives "copied 1.40 GiB".
+type CountingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+// NewCountingReader returns a CountingReader reading from r
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+// Read reads from the underlying reader and adds the bytes read to the count
+func (cr *CountingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n.Add(int64(n))
+	return n, err
+}
+
+// Count returns the number of bytes read so far
+func (cr *CountingReader) Count() Size {
+	return Size(cr.n.Load())
+}
+
+// String formats the count with the package default options
+func (cr *CountingReader) String() string {
+	return cr.Count().String()
+}
+
+// CountingWriter wraps an io.Writer and counts the bytes written through it
+//
+// Like CountingReader the count is safe to read concurrently with writes.
+type CountingWriter struct {
+	w io.Writer
+	n atomic.Int64
+}
+
+// NewCountingWriter returns a CountingWriter writing to w
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	return &CountingWriter{w: w}
+}
+
+// Write writes to the underlying writer and adds the bytes written to the
+// count
+func (cw *CountingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n.Add(int64(n))
+	return n, err
+}
+
+// Count returns the number of bytes written so far
+func (cw *CountingWriter) Count() Size {
+	return Size(cw.n.Load())
+}
+
+// String formats the count with the package default options
+func (cw *CountingWriter) String() string {
+	return cw.Count().String()
+}
@@ -0,0 +1,59 @@
+package filesize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestCountingReader tests counting bytes through io.Copy
+func TestCountingReader(t *testing.T) {
+	cr := NewCountingReader(bytes.NewReader(make([]byte, 3*MiB/2)))
+	if _, err := io.Copy(io.Discard, cr); err != nil {
+		t.Fatalf("io.Copy() unexpected error: %v", err)
+	}
+
+	if cr.Count() != Size(3*MiB/2) {
+		t.Errorf("Count() = %d, expected %d", int64(cr.Count()), 3*MiB/2)
+	}
+	if result := "copied " + cr.String(); result != "copied 1.50 MiB" {
+		t.Errorf("String() = %q, expected %q", result, "copied 1.50 MiB")
+	}
+}
+
+// failingWriter accepts a fixed number of bytes and then fails
+type failingWriter struct {
+	remaining int
+}
+
+// Write writes up to the remaining allowance
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, errors.New("disk full")
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+// TestCountingWriter tests that short writes are counted as written
+func TestCountingWriter(t *testing.T) {
+	cw := NewCountingWriter(&failingWriter{remaining: 1000})
+
+	if _, err := io.Copy(cw, strings.NewReader(strings.Repeat("x", 600))); err != nil {
+		t.Fatalf("io.Copy() unexpected error: %v", err)
+	}
+	if _, err := cw.Write(make([]byte, 600)); err == nil {
+		t.Errorf("Write() past the allowance expected error but got none")
+	}
+
+	if cw.Count() != 1000 {
+		t.Errorf("Count() = %d, expected 1000", int64(cw.Count()))
+	}
+	if cw.String() != "1000 B" {
+		t.Errorf("String() = %q, expected %q", cw.String(), "1000 B")
+	}
+}
@@ -0,0 +1,20 @@
+package filesize
+
+// MarshalCSV implements the TypeMarshaller interface of gocarina/gocsv
+//
+// Cells use the exact canonical form with a space, e.g. "1.5 GiB" or
+// "1500 B", which reads well in a spreadsheet and always parses back to the
+// same value. The package default format is deliberately ignored, since a
+// setting such as WithJEDEC or WithDecimalMark would write cells that
+// UnmarshalCSV reads differently or not at all.
+func (s Size) MarshalCSV() (string, error) {
+	return formatExact(int64(s), " "), nil
+}
+
+// UnmarshalCSV implements the TypeUnmarshaller interface of gocarina/gocsv
+//
+// Cells are parsed like UnmarshalText, so both humanized values such as
+// "1.50 GiB" and raw byte counts read back.
+func (s *Size) UnmarshalCSV(cell string) error {
+	return s.UnmarshalText([]byte(cell))
+}
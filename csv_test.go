@@ -0,0 +1,95 @@
+package filesize
+
+import (
+	"testing"
+
+	"github.com/gocarina/gocsv"
+)
+
+// usageRow is a report row with a size column
+type usageRow struct {
+	Path string `csv:"path"`
+	Used Size   `csv:"used"`
+}
+
+// TestSize_MarshalCSV tests writing exact humanized size columns
+func TestSize_MarshalCSV(t *testing.T) {
+	rows := []usageRow{
+		{"/home", Size(GiB * 3 / 2)},
+		{"/tmp", 512},
+		{"/var", 1500},
+	}
+
+	out, err := gocsv.MarshalString(&rows)
+	if err != nil {
+		t.Fatalf("gocsv.MarshalString() unexpected error: %v", err)
+	}
+
+	expected := "path,used\n/home,1.5 GiB\n/tmp,512 B\n/var,1500 B\n"
+	if out != expected {
+		t.Errorf("gocsv.MarshalString() = %q, expected %q", out, expected)
+	}
+}
+
+// TestSize_UnmarshalCSV tests reading humanized and raw size columns
+func TestSize_UnmarshalCSV(t *testing.T) {
+	input := "path,used\n/home,1.50 GiB\n/var,4096\n/srv,10MB\n"
+
+	var rows []usageRow
+	if err := gocsv.UnmarshalString(input, &rows); err != nil {
+		t.Fatalf("gocsv.UnmarshalString() unexpected error: %v", err)
+	}
+
+	expected := []Size{Size(GiB * 3 / 2), 4096, Size(10 * MB)}
+	if len(rows) != len(expected) {
+		t.Fatalf("gocsv.UnmarshalString() returned %d rows, expected %d", len(rows), len(expected))
+	}
+	for i, row := range rows {
+		if row.Used != expected[i] {
+			t.Errorf("row %d used = %d, expected %d", i, int64(row.Used), int64(expected[i]))
+		}
+	}
+
+	if err := gocsv.UnmarshalString("path,used\n/home,lots\n", &rows); err == nil {
+		t.Errorf("gocsv.UnmarshalString(lots) expected error but got none")
+	}
+}
+
+// TestSize_CSVRoundTrip tests that cells read back exactly whatever the
+// package default format
+func TestSize_CSVRoundTrip(t *testing.T) {
+	defer SetDefaultFormat()
+
+	formats := [][]FormatOption{
+		nil,
+		{WithJEDEC()},
+		{WithDecimalMark(",")},
+		{WithApproximate("~")},
+		{WithUnitSystem(SI)},
+	}
+	rows := []usageRow{
+		{"/home", Size(GiB * 3 / 2)},
+		{"/var", 1500},
+		{"/srv", 9007199254740993},
+	}
+
+	for _, opts := range formats {
+		SetDefaultFormat(opts...)
+		out, err := gocsv.MarshalString(&rows)
+		if err != nil {
+			t.Errorf("gocsv.MarshalString() unexpected error: %v", err)
+			continue
+		}
+
+		var decoded []usageRow
+		if err := gocsv.UnmarshalString(out, &decoded); err != nil {
+			t.Errorf("gocsv.UnmarshalString(%q) unexpected error: %v", out, err)
+			continue
+		}
+		for i, row := range decoded {
+			if row.Used != rows[i].Used {
+				t.Errorf("gocsv.UnmarshalString(%q) row %d used = %d, expected %d", out, i, int64(row.Used), int64(rows[i].Used))
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package filesize
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DirSizeOption configures DirSize
+type DirSizeOption func(*dirSizeConfig)
+
+// dirSizeConfig holds the settings applied by DirSizeOption values
+type dirSizeConfig struct {
+	workers        int
+	followSymlinks bool
+	skipErrors     bool
+}
+
+// WithWorkers sets how many goroutines DirSize walks the tree with, and so
+// how many directories it reads at once
+//
+// The default is twice GOMAXPROCS, which keeps local disks and network
+// filesystems busy without flooding them; values below one are ignored.
+func WithWorkers(n int) DirSizeOption {
+	return func(cfg *dirSizeConfig) {
+		if n >= 1 {
+			cfg.workers = n
+		}
+	}
+}
+
+// WithFollowSymlinks makes DirSize count the targets of symbolic links
+// instead of skipping them
+//
+// Directories reached twice, including through link cycles, are walked only
+// once.
+func WithFollowSymlinks() DirSizeOption {
+	return func(cfg *dirSizeConfig) {
+		cfg.followSymlinks = true
+	}
+}
+
+// WithSkipErrors makes DirSize ignore entries it cannot read, such as
+// directories without permission, and total everything else
+func WithSkipErrors() DirSizeOption {
+	return func(cfg *dirSizeConfig) {
+		cfg.skipErrors = true
+	}
+}
+
+// DirSize returns the total size of the regular files under path
+//
+// The tree is walked concurrently by a fixed pool of workers. Files
+// with several hard links are counted once, like du does, on platforms that
+// expose inode numbers. Symbolic links are not followed unless
+// WithFollowSymlinks is given, although path itself may be one. The first
+// error stops the walk and is returned unless WithSkipErrors is given. A
+// path naming a file returns that file's size.
+func DirSize(path string, opts ...DirSizeOption) (Size, error) {
+	cfg := dirSizeConfig{workers: 2 * runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return newDirWalker(cfg).size(path)
+}
+
+// dirWalker is the shared state of one DirSize call
+//
+// A fixed pool of cfg.workers goroutines pulls directories from a shared
+// queue, so goroutine count stays bounded however wide the tree is.
+type dirWalker struct {
+	cfg       dirSizeConfig
+	readDirFn func(string) ([]fs.DirEntry, error)
+	total     atomic.Int64
+
+	// queue holds directories waiting to be read and pending counts those
+	// queued or being read, the walk ending when it drops to zero
+	queueMu sync.Mutex
+	cond    *sync.Cond
+	queue   []string
+	pending int
+
+	// seen holds the files and directories already counted
+	mu   sync.Mutex
+	seen map[fileKey]struct{}
+
+	// err is the first error, after which the walk winds down
+	errOnce sync.Once
+	err     error
+	failed  atomic.Bool
+}
+
+// newDirWalker returns a walker with the given settings
+func newDirWalker(cfg dirSizeConfig) *dirWalker {
+	w := &dirWalker{
+		cfg:       cfg,
+		readDirFn: os.ReadDir,
+		seen:      make(map[fileKey]struct{}),
+	}
+	w.cond = sync.NewCond(&w.queueMu)
+	return w
+}
+
+// size implements DirSize for path
+func (w *dirWalker) size(path string) (Size, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		w.add(info)
+		return Size(w.total.Load()), nil
+	}
+
+	w.markSeen(info)
+	w.queue, w.pending = []string{path}, 1
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work()
+		}()
+	}
+	wg.Wait()
+
+	return Size(w.total.Load()), w.err
+}
+
+// work reads queued directories until the walk is finished
+func (w *dirWalker) work() {
+	for {
+		dir, ok := w.next()
+		if !ok {
+			return
+		}
+
+		// after a failure the queue is drained without reading
+		var subdirs []string
+		if !w.failed.Load() {
+			var err error
+			subdirs, err = w.readDir(dir)
+			if err != nil {
+				w.fail(err)
+			}
+		}
+		w.finish(subdirs)
+	}
+}
+
+// next waits for a queued directory, reporting false once none are left
+// and none are being read
+func (w *dirWalker) next() (string, bool) {
+	w.queueMu.Lock()
+	defer w.queueMu.Unlock()
+
+	for len(w.queue) == 0 && w.pending > 0 {
+		w.cond.Wait()
+	}
+	if len(w.queue) == 0 {
+		return "", false
+	}
+
+	// taking the newest directory walks depth first, keeping the queue short
+	dir := w.queue[len(w.queue)-1]
+	w.queue = w.queue[:len(w.queue)-1]
+	return dir, true
+}
+
+// finish marks a directory as read and queues its subdirectories
+func (w *dirWalker) finish(subdirs []string) {
+	w.queueMu.Lock()
+	defer w.queueMu.Unlock()
+
+	w.queue = append(w.queue, subdirs...)
+	w.pending += len(subdirs) - 1
+	if len(subdirs) > 0 || w.pending == 0 {
+		w.cond.Broadcast()
+	}
+}
+
+// readDir counts the files in dir and returns its unseen subdirectories
+func (w *dirWalker) readDir(dir string) ([]string, error) {
+	entries, err := w.readDirFn(dir)
+	var subdirs []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		var info fs.FileInfo
+		var infoErr error
+		if w.cfg.followSymlinks && entry.Type()&fs.ModeSymlink != 0 {
+			info, infoErr = os.Stat(path)
+		} else {
+			info, infoErr = entry.Info()
+		}
+		if infoErr != nil {
+			// entries removed during the walk are simply gone
+			if errors.Is(infoErr, fs.ErrNotExist) {
+				continue
+			}
+			w.fail(infoErr)
+			continue
+		}
+
+		switch {
+		case info.IsDir():
+			if w.markSeen(info) {
+				subdirs = append(subdirs, path)
+			}
+		case info.Mode().IsRegular():
+			w.add(info)
+		}
+	}
+	return subdirs, err
+}
+
+// add counts a regular file unless it is another link to a counted one
+//
+// Only files with several hard links can be reached twice, unless symbolic
+// links are followed, in which case any file may also be a link's target.
+func (w *dirWalker) add(info fs.FileInfo) {
+	if !info.Mode().IsRegular() {
+		return
+	}
+	key, links, ok := fileID(info)
+	if ok && (links > 1 || w.cfg.followSymlinks) && !w.markKey(key) {
+		return
+	}
+	w.total.Add(info.Size())
+}
+
+// markSeen records a directory and reports whether it was new
+func (w *dirWalker) markSeen(info fs.FileInfo) bool {
+	key, _, ok := fileID(info)
+	if !ok {
+		return true
+	}
+	return w.markKey(key)
+}
+
+// markKey records key and reports whether it was new
+func (w *dirWalker) markKey(key fileKey) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.seen[key]; ok {
+		return false
+	}
+	w.seen[key] = struct{}{}
+	return true
+}
+
+// fail records err as the walk's error unless errors are skipped
+func (w *dirWalker) fail(err error) {
+	if w.cfg.skipErrors {
+		return
+	}
+	w.errOnce.Do(func() {
+		w.err = err
+		w.failed.Store(true)
+	})
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package filesize
+
+import "io/fs"
+
+// fileKey identifies a file independently of the paths leading to it
+type fileKey struct{}
+
+// fileID reports that file identities are unavailable on this platform, so
+// hard links are counted once per path
+func fileID(info fs.FileInfo) (fileKey, uint64, bool) {
+	return fileKey{}, 0, false
+}
@@ -0,0 +1,143 @@
+package filesize
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeTree creates files of the given sizes below dir
+func writeTree(t *testing.T, dir string, files map[string]int) {
+	t.Helper()
+	for name, size := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll(%q) unexpected error: %v", path, err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatalf("WriteFile(%q) unexpected error: %v", path, err)
+		}
+	}
+}
+
+// TestDirSize tests totaling a nested tree
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]int{
+		"a.txt":         100,
+		"sub/b.bin":     2048,
+		"sub/deep/c.gz": 4096,
+		"other/d":       1,
+	})
+	if err := os.MkdirAll(filepath.Join(dir, "empty"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() unexpected error: %v", err)
+	}
+
+	for _, workers := range []int{1, 4} {
+		size, err := DirSize(dir, WithWorkers(workers))
+		if err != nil {
+			t.Fatalf("DirSize(%d workers) unexpected error: %v", workers, err)
+		}
+		if size != 6245 {
+			t.Errorf("DirSize(%d workers) = %d, expected 6245", workers, int64(size))
+		}
+	}
+
+	if size, err := DirSize(filepath.Join(dir, "sub", "b.bin")); size != 2048 || err != nil {
+		t.Errorf("DirSize(file) = %d, %v, expected 2048", int64(size), err)
+	}
+	if _, err := DirSize(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("DirSize(missing) = %v, expected a not-exist error", err)
+	}
+}
+
+// TestDirSize_Links tests hard link deduplication and symlink handling
+func TestDirSize_Links(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("links need extra privileges on Windows")
+	}
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]int{
+		"data/big":   10000,
+		"data/small": 10,
+		"outside/x":  500,
+	})
+	mustLink := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("creating link: %v", err)
+		}
+	}
+	mustLink(os.Link(filepath.Join(dir, "data/big"), filepath.Join(dir, "data/big-hardlink")))
+	mustLink(os.Symlink(filepath.Join(dir, "outside"), filepath.Join(dir, "data/outside-link")))
+	mustLink(os.Symlink(filepath.Join(dir, "data"), filepath.Join(dir, "data/loop")))
+
+	root := filepath.Join(dir, "data")
+	if size, err := DirSize(root); size != 10010 || err != nil {
+		t.Errorf("DirSize() = %d, %v, expected 10010 with the hard link counted once", int64(size), err)
+	}
+	if size, err := DirSize(root, WithFollowSymlinks()); size != 10510 || err != nil {
+		t.Errorf("DirSize(WithFollowSymlinks) = %d, %v, expected 10510 without looping", int64(size), err)
+	}
+	// a file with a single hard link is still counted once when a symlink
+	// also points at it
+	single := filepath.Join(dir, "single")
+	writeTree(t, single, map[string]int{"file": 1000})
+	mustLink(os.Symlink(filepath.Join(single, "file"), filepath.Join(single, "file-link")))
+	if size, err := DirSize(single); size != 1000 || err != nil {
+		t.Errorf("DirSize(single) = %d, %v, expected 1000", int64(size), err)
+	}
+	if size, err := DirSize(single, WithFollowSymlinks()); size != 1000 || err != nil {
+		t.Errorf("DirSize(single, WithFollowSymlinks) = %d, %v, expected 1000 with the target counted once", int64(size), err)
+	}
+}
+
+// TestDirSize_WorkerBound tests that a wide tree is walked by a fixed
+// number of goroutines
+func TestDirSize_WorkerBound(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{}
+	for i := 0; i < 300; i++ {
+		files[filepath.Join(fmt.Sprintf("d%03d", i), "f")] = 10
+	}
+	writeTree(t, dir, files)
+
+	const workers = 3
+	w := newDirWalker(dirSizeConfig{workers: workers})
+
+	var mu sync.Mutex
+	var active, maxActive, maxGoroutines int
+	baseline := runtime.NumGoroutine()
+	w.readDirFn = func(name string) ([]fs.DirEntry, error) {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		maxGoroutines = max(maxGoroutines, runtime.NumGoroutine()-baseline)
+		mu.Unlock()
+
+		time.Sleep(100 * time.Microsecond)
+		defer func() {
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+		return os.ReadDir(name)
+	}
+
+	size, err := w.size(dir)
+	if size != 3000 || err != nil {
+		t.Errorf("size() = %d, %v, expected 3000", int64(size), err)
+	}
+	if maxActive > workers {
+		t.Errorf("read %d directories at once, expected at most %d", maxActive, workers)
+	}
+	if maxGoroutines > workers {
+		t.Errorf("walk used %d goroutines, expected at most %d", maxGoroutines, workers)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package filesize
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// fileKey identifies a file independently of the paths leading to it
+type fileKey struct {
+	dev uint64
+	ino uint64
+}
+
+// fileID returns the device and inode of info and its hard link count
+func fileID(info fs.FileInfo) (fileKey, uint64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileKey{}, 0, false
+	}
+	return fileKey{dev: uint64(st.Dev), ino: uint64(st.Ino)}, uint64(st.Nlink), true
+}
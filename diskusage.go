@@ -0,0 +1,67 @@
+package filesize
+
+import "strconv"
+
+// DiskSpace describes the capacity of a filesystem
+type DiskSpace struct {
+	// Total is the size of the filesystem
+	Total Size
+
+	// Free is the unused space, including any reserved for the superuser
+	Free Size
+
+	// Available is the space unprivileged users can still allocate
+	Available Size
+
+	// Used is Total minus Free
+	Used Size
+}
+
+// DiskUsage returns the capacity of the filesystem containing path
+//
+// It uses statfs or statvfs on Unix systems and GetDiskFreeSpaceEx on
+// Windows, so a check such as "is there 10 GiB free before I start" needs
+// no further dependency:
+//
+//	space, err := filesize.DiskUsage(dir)
+//	if err == nil && space.Available < filesize.Size(10*filesize.GiB) { ... }
+//
+// Other platforms return an error.
+func DiskUsage(path string) (DiskSpace, error) {
+	total, free, avail, err := diskSpace(path)
+	if err != nil {
+		return DiskSpace{}, err
+	}
+
+	return DiskSpace{
+		Total:     Size(clampUint64(total)),
+		Free:      Size(clampUint64(free)),
+		Available: Size(clampUint64(avail)),
+		Used:      Size(clampUint64(total - min(free, total))),
+	}, nil
+}
+
+// UsedPercent returns the used share of the space ordinary users can
+// reach, Used / (Used + Available), the figure df prints as "Use%"
+func (d DiskSpace) UsedPercent() float64 {
+	usable := d.Used + d.Available
+	if usable <= 0 {
+		return 0
+	}
+	return float64(d.Used) / float64(usable) * 100
+}
+
+// String summarizes the space, e.g.
+// "12.3 GiB available of 100 GiB (87% used)"
+func (d DiskSpace) String() string {
+	percent := strconv.FormatFloat(d.UsedPercent(), 'f', 0, 64)
+	return d.Available.String() + " available of " + d.Total.String() + " (" + percent + "% used)"
+}
+
+// clampUint64 converts n to an int64, saturating at the largest int64
+func clampUint64(n uint64) int64 {
+	if n > 1<<63-1 {
+		return 1<<63 - 1
+	}
+	return int64(n)
+}
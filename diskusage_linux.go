@@ -0,0 +1,39 @@
+package filesize
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskSpace returns the total, free and available bytes of the filesystem
+// containing path
+func diskSpace(path string) (total, free, avail uint64, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, 0, 0, &os.PathError{Op: "statfs", Path: path, Err: err}
+	}
+
+	// block counts are in fragment units, which older kernels leave unset
+	unit := uint64(st.Frsize)
+	if unit == 0 {
+		unit = uint64(st.Bsize)
+	}
+	return st.Blocks * unit, st.Bfree * unit, st.Bavail * unit, nil
+}
+
+// blockSize returns the allocation unit of the filesystem containing path
+//
+// That is the fragment size, as diskSpace uses; Bsize is only the preferred
+// I/O size, which network filesystems such as NFS report as the transfer
+// size, often 1 MiB.
+func blockSize(path string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, &os.PathError{Op: "statfs", Path: path, Err: err}
+	}
+	if st.Frsize == 0 {
+		return int64(st.Bsize), nil
+	}
+	return int64(st.Frsize), nil
+}
@@ -0,0 +1,29 @@
+package filesize
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskSpace returns the total, free and available bytes of the filesystem
+// containing path
+func diskSpace(path string) (total, free, avail uint64, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, 0, 0, &os.PathError{Op: "statfs", Path: path, Err: err}
+	}
+
+	// available blocks go negative when the reserve is in use
+	unit := uint64(st.F_bsize)
+	return st.F_blocks * unit, st.F_bfree * unit, uint64(max(st.F_bavail, 0)) * unit, nil
+}
+
+// blockSize returns the allocation unit of the filesystem containing path
+func blockSize(path string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, &os.PathError{Op: "statfs", Path: path, Err: err}
+	}
+	return int64(st.F_bsize), nil
+}
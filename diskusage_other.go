@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !openbsd && !netbsd && !solaris && !illumos && !windows
+
+package filesize
+
+import (
+	"errors"
+	"os"
+	"runtime"
+)
+
+// diskSpace reports that disk usage is unavailable on this platform
+func diskSpace(path string) (total, free, avail uint64, err error) {
+	return 0, 0, 0, &os.PathError{Op: "statfs", Path: path, Err: errors.New("disk usage not supported on " + runtime.GOOS)}
+}
+
+// blockSize reports that block sizes are unavailable on this platform
+func blockSize(path string) (int64, error) {
+	return 0, &os.PathError{Op: "statfs", Path: path, Err: errors.New("block size not supported on " + runtime.GOOS)}
+}
@@ -0,0 +1,31 @@
+//go:build netbsd || solaris || illumos
+
+package filesize
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskSpace returns the total, free and available bytes of the filesystem
+// containing path
+func diskSpace(path string) (total, free, avail uint64, err error) {
+	var st unix.Statvfs_t
+	if err := unix.Statvfs(path, &st); err != nil {
+		return 0, 0, 0, &os.PathError{Op: "statvfs", Path: path, Err: err}
+	}
+
+	unit := uint64(st.Frsize)
+	return st.Blocks * unit, st.Bfree * unit, st.Bavail * unit, nil
+}
+
+// blockSize returns the allocation unit of the filesystem containing path,
+// which statvfs calls the fragment size
+func blockSize(path string) (int64, error) {
+	var st unix.Statvfs_t
+	if err := unix.Statvfs(path, &st); err != nil {
+		return 0, &os.PathError{Op: "statvfs", Path: path, Err: err}
+	}
+	return int64(st.Frsize), nil
+}
@@ -0,0 +1,53 @@
+package filesize
+
+import (
+	"runtime"
+	"testing"
+)
+
+// TestDiskUsage tests that the temporary directory's filesystem reports
+// consistent figures
+func TestDiskUsage(t *testing.T) {
+	space, err := DiskUsage(t.TempDir())
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" || runtime.GOOS == "plan9" || runtime.GOOS == "aix" {
+		if err == nil {
+			t.Errorf("DiskUsage() on %s expected error but got none", runtime.GOOS)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("DiskUsage() unexpected error: %v", err)
+	}
+
+	if space.Total <= 0 || space.Free > space.Total || space.Available > space.Free {
+		t.Errorf("DiskUsage() = %+v, expected 0 < Available <= Free <= Total", space)
+	}
+	if space.Used != space.Total-space.Free {
+		t.Errorf("DiskUsage().Used = %d, expected Total-Free = %d", int64(space.Used), int64(space.Total-space.Free))
+	}
+	if percent := space.UsedPercent(); percent < 0 || percent > 100 {
+		t.Errorf("UsedPercent() = %g, expected a percentage", percent)
+	}
+
+	if _, err := DiskUsage("/definitely/not/a/real/path"); err == nil {
+		t.Errorf("DiskUsage(missing) expected error but got none")
+	}
+}
+
+// TestDiskSpace_String tests the humanized summary
+func TestDiskSpace_String(t *testing.T) {
+	space := DiskSpace{
+		Total:     Size(100 * GiB),
+		Free:      Size(15 * GiB),
+		Available: Size(10 * GiB),
+		Used:      Size(85 * GiB),
+	}
+
+	expected := "10.0 GiB available of 100 GiB (89% used)"
+	if result := space.String(); result != expected {
+		t.Errorf("String() = %q, expected %q", result, expected)
+	}
+	if (DiskSpace{}).UsedPercent() != 0 {
+		t.Errorf("UsedPercent() of an empty filesystem = %g, expected 0", (DiskSpace{}).UsedPercent())
+	}
+}
@@ -0,0 +1,27 @@
+package filesize
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// diskSpace returns the total, free and available bytes of the volume
+// containing path
+func diskSpace(path string) (total, free, avail uint64, err error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, 0, &os.PathError{Op: "GetDiskFreeSpaceEx", Path: path, Err: err}
+	}
+
+	// the available figure honors per-user quotas
+	if err := windows.GetDiskFreeSpaceEx(name, &avail, &total, &free); err != nil {
+		return 0, 0, 0, &os.PathError{Op: "GetDiskFreeSpaceEx", Path: path, Err: err}
+	}
+	return total, free, avail, nil
+}
+
+// blockSize returns the cluster size of the volume containing path
+func blockSize(path string) (int64, error) {
+	return clusterSize(path)
+}
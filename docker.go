@@ -0,0 +1,97 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DockerMinMemory is the smallest --memory limit the Docker daemon accepts
+const DockerMinMemory = 6 * MiB
+
+// ParseDockerMemory parses a size the way the docker CLI parses --memory,
+// --memory-swap and --shm-size
+//
+// This mirrors RAMInBytes from github.com/docker/go-units: a number,
+// an optional space and an optional case-insensitive suffix of "b" or one
+// of k, m, g, t and p followed by an optional "b" or "ib", all 1024-based,
+// so "512m", "512MB" and "512MiB" are equal. Fractions are accepted and
+// truncated to whole bytes as Docker does. Values too large for an int64,
+// which Docker would wrap silently, are reported as errors.
+func ParseDockerMemory(s string) (Size, error) {
+	// the number runs to the last digit, dot or space
+	sep := strings.LastIndexAny(s, "0123456789. ")
+	if sep == -1 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+	num, suffix := s[:sep+1], s[sep+1:]
+	if s[sep] == ' ' {
+		num = s[:sep]
+	}
+
+	size, err := strconv.ParseFloat(num, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	multiplier, ok := dockerSuffix(strings.ToLower(suffix))
+	if !ok {
+		return 0, fmt.Errorf("invalid suffix %q in size %q", suffix, s)
+	}
+
+	size *= float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size too large: %q", s)
+	}
+	return Size(size), nil
+}
+
+// dockerSuffix returns the multiplier for a lowercase docker size suffix
+func dockerSuffix(suffix string) (int64, bool) {
+	if suffix == "" || suffix == "b" {
+		return 1, true
+	}
+	if len(suffix) > 3 || (len(suffix) == 2 && suffix[1] != 'b') || (len(suffix) == 3 && suffix[1:] != "ib") {
+		return 0, false
+	}
+
+	switch suffix[0] {
+	case 'k':
+		return KiB, true
+	case 'm':
+		return MiB, true
+	case 'g':
+		return GiB, true
+	case 't':
+		return TiB, true
+	case 'p':
+		return PiB, true
+	}
+	return 0, false
+}
+
+// FormatDockerMemory formats bytes as an argument for docker run --memory
+// and related flags
+//
+// The result is an integer with the largest of the documented g, m and k
+// suffixes that represents bytes exactly, or a plain byte count, e.g. "2g",
+// "1536m" or "1000". Negative values such as the -1 that --memory-swap uses
+// for unlimited swap are formatted as plain numbers.
+func FormatDockerMemory(bytes int64) string {
+	if bytes > 0 {
+		for _, unit := range []struct {
+			suffix     string
+			multiplier int64
+		}{
+			{"g", GiB},
+			{"m", MiB},
+			{"k", KiB},
+		} {
+			if bytes%unit.multiplier == 0 {
+				return strconv.FormatInt(bytes/unit.multiplier, 10) + unit.suffix
+			}
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
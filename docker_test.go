@@ -0,0 +1,90 @@
+package filesize
+
+import "testing"
+
+// TestParseDockerMemory tests the docker CLI's size syntax
+func TestParseDockerMemory(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{"0", 0, false},
+		{"1024", 1024, false},
+		{"100b", 100, false},
+		{"100B", 100, false},
+		{"32k", 32 * KiB, false},
+		{"512m", 512 * MiB, false},
+		{"512M", 512 * MiB, false},
+		{"512mb", 512 * MiB, false},
+		{"512MiB", 512 * MiB, false},
+		{"512 m", 512 * MiB, false},
+		{"2g", 2 * GiB, false},
+		{"1t", TiB, false},
+		{"1p", PiB, false},
+		{"1.5g", GiB + GiB/2, false},
+		{"0.5k", 512, false},
+		{"1.3k", 1331, false},
+
+		// docker rejects these
+		{"", 0, true},
+		{"m", 0, true},
+		{"-1m", 0, true},
+		{"1x", 0, true},
+		{"1mm", 0, true},
+		{"1mib2", 0, true},
+		{"1bb", 0, true},
+		{"1kbb", 0, true},
+		{"1e", 0, true},
+		{"1..5m", 0, true},
+		{"9000000p", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseDockerMemory(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseDockerMemory(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDockerMemory(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("ParseDockerMemory(%q) = %d, expected %d", tc.input, int64(result), tc.expected)
+		}
+	}
+}
+
+// TestFormatDockerMemory tests that formatted values parse back exactly
+func TestFormatDockerMemory(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0"},
+		{1000, "1000"},
+		{4 * KiB, "4k"},
+		{DockerMinMemory, "6m"},
+		{1536 * MiB, "1536m"},
+		{2 * GiB, "2g"},
+		{4 * TiB, "4096g"},
+		{-1, "-1"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatDockerMemory(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatDockerMemory(%d) = %q, expected %q", tc.input, result, tc.expected)
+			continue
+		}
+		if tc.input < 0 {
+			continue
+		}
+		if parsed, err := ParseDockerMemory(result); err != nil || int64(parsed) != tc.input {
+			t.Errorf("ParseDockerMemory(%q) = %d, %v, expected %d", result, int64(parsed), err, tc.input)
+		}
+	}
+}
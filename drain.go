@@ -0,0 +1,70 @@
+package filesize
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// drainBufferSize is the size of the buffers SizeOf reads into
+const drainBufferSize = 32 * 1024
+
+// drainBuffers pools the read buffers used by SizeOf
+var drainBuffers = sync.Pool{
+	New: func() any {
+		buf := make([]byte, drainBufferSize)
+		return &buf
+	},
+}
+
+// SizeOf reads r to the end and returns the number of bytes it held
+//
+// Reads go into pooled buffers that are discarded, so measuring a stream
+// costs no allocation per call. Bytes read before an error are included in
+// the returned size.
+func SizeOf(r io.Reader) (Size, error) {
+	n, err := drain(r, -1)
+	return Size(n), err
+}
+
+// SizeOfLimit is like SizeOf but stops once r turns out to hold more than
+// limit bytes, returning limit and an error matching ErrLimitExceeded
+//
+// It keeps an untrusted stream from being read indefinitely just to be
+// measured.
+func SizeOfLimit(r io.Reader, limit int64) (Size, error) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	n, err := drain(r, limit+1)
+	if n > limit {
+		return Size(limit), limitExceeded(limit)
+	}
+	return Size(n), err
+}
+
+// drain reads r until EOF, an error or, when max is not negative, max bytes
+func drain(r io.Reader, max int64) (int64, error) {
+	bufp := drainBuffers.Get().(*[]byte)
+	defer drainBuffers.Put(bufp)
+	buf := *bufp
+
+	var total int64
+	for max < 0 || total < max {
+		p := buf
+		if max >= 0 && int64(len(p)) > max-total {
+			p = p[:max-total]
+		}
+
+		n, err := r.Read(p)
+		total += int64(n)
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,79 @@
+package filesize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestSizeOf tests measuring readers by draining them
+func TestSizeOf(t *testing.T) {
+	testCases := []struct {
+		input    io.Reader
+		expected Size
+	}{
+		{strings.NewReader(""), 0},
+		{strings.NewReader("hello"), 5},
+		{bytes.NewReader(make([]byte, 5*MiB/2)), Size(5 * MiB / 2)},
+		{iotest.OneByteReader(strings.NewReader("slow reader")), 11},
+	}
+
+	for _, tc := range testCases {
+		result, err := SizeOf(tc.input)
+		if err != nil {
+			t.Errorf("SizeOf() unexpected error: %v", err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("SizeOf() = %d, expected %d", int64(result), int64(tc.expected))
+		}
+	}
+
+	// bytes read before a failure are still counted
+	failing := io.MultiReader(strings.NewReader("12345"), iotest.ErrReader(errors.New("network down")))
+	if result, err := SizeOf(failing); result != 5 || err == nil {
+		t.Errorf("SizeOf(failing) = %d, %v, expected 5 and an error", int64(result), err)
+	}
+}
+
+// TestSizeOfLimit tests the capped variant
+func TestSizeOfLimit(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		limit    int64
+		expected Size
+		hasError bool
+	}{
+		{100, 100, 100, false},
+		{99, 100, 99, false},
+		{101, 100, 100, true},
+		{10 * MiB, MiB, Size(MiB), true},
+		{0, 0, 0, false},
+		{1, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := SizeOfLimit(bytes.NewReader(make([]byte, tc.size)), tc.limit)
+		if tc.hasError != errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("SizeOfLimit(%d bytes, %d) error = %v, expected limit error %v", tc.size, tc.limit, err, tc.hasError)
+		}
+		if result != tc.expected {
+			t.Errorf("SizeOfLimit(%d bytes, %d) = %d, expected %d", tc.size, tc.limit, int64(result), int64(tc.expected))
+		}
+	}
+}
+
+// TestSizeOf_Allocations tests that the pooled buffer avoids allocation
+func TestSizeOf_Allocations(t *testing.T) {
+	r := bytes.NewReader(make([]byte, MiB))
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Seek(0, io.SeekStart)
+		_, _ = SizeOf(r)
+	})
+	if allocs != 0 {
+		t.Errorf("SizeOf() allocated %.0f times per call, expected 0", allocs)
+	}
+}
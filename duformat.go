@@ -0,0 +1,206 @@
+package filesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DuFormatter formats sizes exactly as GNU du prints them
+//
+// The predefined formatters match du's common flags and ParseDuBlockSize
+// covers --block-size. Like du, every mode rounds up, so a single byte is
+// "1K" under -k and "1" under -h, and 1537 bytes is "1.6K" under -h.
+type DuFormatter struct {
+	// autoscale picks the unit per value, as -h and --si do
+	autoscale bool
+
+	// base is 1024 or 1000 for autoscaling and suffix letters
+	base uint64
+
+	// blockSize is the unit values are counted in without autoscaling
+	blockSize uint64
+
+	// suffix is printed after fixed block counts, e.g. "M" or "MB"
+	suffix string
+}
+
+// formatters for du's size flags
+var (
+	// DuHuman is du -h: powers of 1024 with suffixes like "4.0K" and "12M"
+	DuHuman = DuFormatter{autoscale: true, base: 1024}
+
+	// DuSI is du --si: powers of 1000 with suffixes like "4.1k" and "13M"
+	DuSI = DuFormatter{autoscale: true, base: 1000}
+
+	// DuKilobytes is du -k, du's default: 1024-byte blocks without suffix
+	DuKilobytes = DuFormatter{base: 1024, blockSize: 1024}
+
+	// DuMegabytes is du -m: 1048576-byte blocks without suffix
+	DuMegabytes = DuFormatter{base: 1024, blockSize: 1024 * 1024}
+
+	// DuBytes is du -b: plain byte counts
+	DuBytes = DuFormatter{base: 1024, blockSize: 1}
+)
+
+// duLetters are du's unit letters, indexed by exponent
+const duLetters = " KMGTPEZY"
+
+// ParseDuBlockSize returns the formatter for du --block-size=SPEC
+//
+// SPEC follows GNU conventions: "human-readable" and "si" select DuHuman
+// and DuSI, a number such as "512" or "1M" counts blocks of that size and
+// prints bare numbers, and a unit alone such as "M", "MB", "MiB" or "kB"
+// counts blocks of that unit and prints it after each number ("5M",
+// "6MB"). Letters alone and with "iB" are powers of 1024, with "B" powers
+// of 1000.
+func ParseDuBlockSize(spec string) (DuFormatter, error) {
+	switch spec {
+	case "human-readable":
+		return DuHuman, nil
+	case "si":
+		return DuSI, nil
+	}
+
+	// split an optional count from an optional unit
+	i := scanDigits(spec, 0)
+	count := uint64(1)
+	if i > 0 {
+		n, err := strconv.ParseUint(spec[:i], 10, 64)
+		if err != nil || n == 0 {
+			return DuFormatter{}, fmt.Errorf("invalid block size: %q", spec)
+		}
+		count = n
+	}
+	unit := spec[i:]
+	if unit == "" {
+		if i == 0 {
+			return DuFormatter{}, fmt.Errorf("invalid block size: %q", spec)
+		}
+		return DuFormatter{base: 1024, blockSize: count}, nil
+	}
+
+	// a power letter, optionally followed by "B" or "iB"
+	exponent := strings.IndexByte(duLetters, unit[0]&^0x20)
+	if exponent <= 0 || (unit[0] >= 'a' && (unit[0] == 'y' || unit[0] == 'z')) {
+		return DuFormatter{}, fmt.Errorf("invalid block size: %q", spec)
+	}
+	base := uint64(1024)
+	switch unit[1:] {
+	case "":
+	case "iB":
+	case "B":
+		base = 1000
+	default:
+		return DuFormatter{}, fmt.Errorf("invalid block size: %q", spec)
+	}
+
+	multiplier := uint64(1)
+	for e := 0; e < exponent; e++ {
+		if multiplier > (1<<64-1)/base {
+			return DuFormatter{}, fmt.Errorf("block size too large: %q", spec)
+		}
+		multiplier *= base
+	}
+	if count > (1<<64-1)/multiplier {
+		return DuFormatter{}, fmt.Errorf("block size too large: %q", spec)
+	}
+
+	f := DuFormatter{base: base, blockSize: count * multiplier}
+
+	// a unit without a count is printed after every value
+	if i == 0 {
+		letter := duLetters[exponent]
+		if base == 1000 && exponent == 1 {
+			letter = 'k'
+		}
+		f.suffix = string(letter) + unit[1:]
+	}
+	return f, nil
+}
+
+// Format formats bytes as du would, treating negative values as zero
+func (f DuFormatter) Format(bytes int64) string {
+	n := uint64(max(bytes, 0))
+	if f.autoscale {
+		return duHuman(n, f.base)
+	}
+
+	blockSize := max(f.blockSize, 1)
+	blocks := n / blockSize
+	if n%blockSize != 0 {
+		blocks++
+	}
+	return strconv.FormatUint(blocks, 10) + f.suffix
+}
+
+// Line formats one line of du output, the size and path separated by a tab
+func (f DuFormatter) Line(bytes int64, path string) string {
+	return f.Format(bytes) + "\t" + path
+}
+
+// duHuman autoscales n like gnulib's human_readable with the ceiling
+// rounding du uses, tracking the discarded remainder in tenths and a
+// rounding indicator (0: exact, 1: below half, 2: half, 3: above half)
+func duHuman(n, base uint64) string {
+	amt, tenths, rounding, exponent := n, uint64(0), uint64(0), 0
+	fraction := ""
+
+	if base <= amt {
+		for {
+			r10 := (amt%base)*10 + tenths
+			r2 := (r10%base)*2 + rounding>>1
+			amt /= base
+			tenths = r10 / base
+			switch {
+			case r2 < base && r2+rounding != 0:
+				rounding = 1
+			case r2 < base:
+				rounding = 0
+			case base < r2+rounding:
+				rounding = 3
+			default:
+				rounding = 2
+			}
+			exponent++
+			if amt < base || exponent == len(duLetters)-1 {
+				break
+			}
+		}
+
+		// small values keep one decimal, rounded up
+		if amt < 10 {
+			if rounding > 0 {
+				tenths++
+				rounding = 0
+				if tenths == 10 {
+					amt++
+					tenths = 0
+				}
+			}
+			if amt < 10 {
+				fraction = "." + strconv.FormatUint(tenths, 10)
+				tenths = 0
+			}
+		}
+	}
+
+	// round the integer up, carrying into the next unit when it fills
+	if tenths+rounding > 0 {
+		amt++
+		if amt == base && exponent < len(duLetters)-1 {
+			exponent++
+			fraction = ".0"
+			amt = 1
+		}
+	}
+
+	result := strconv.FormatUint(amt, 10) + fraction
+	if exponent == 0 {
+		return result
+	}
+	if base == 1000 && exponent == 1 {
+		return result + "k"
+	}
+	return result + string(duLetters[exponent])
+}
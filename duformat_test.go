@@ -0,0 +1,112 @@
+package filesize
+
+import "testing"
+
+// TestDuFormatter_Format tests output against what GNU du prints
+func TestDuFormatter_Format(t *testing.T) {
+	testCases := []struct {
+		name      string
+		formatter DuFormatter
+		input     int64
+		expected  string
+	}{
+		// du -h: one rounded-up decimal below ten, integers above
+		{"human zero", DuHuman, 0, "0"},
+		{"human bytes", DuHuman, 1023, "1023"},
+		{"human exact", DuHuman, 4096, "4.0K"},
+		{"human half", DuHuman, 1536, "1.5K"},
+		{"human rounds up", DuHuman, 1537, "1.6K"},
+		{"human tenths carry", DuHuman, 10*1024 - 1, "10K"},
+		{"human integer", DuHuman, 10 * 1024, "10K"},
+		{"human integer rounds up", DuHuman, 10*1024 + 1, "11K"},
+		{"human next unit", DuHuman, 1024*1024 - 1, "1.0M"},
+		{"human mebibytes", DuHuman, 12 * MiB, "12M"},
+		{"human gibibytes", DuHuman, 3*GiB + GiB/2, "3.5G"},
+		{"human exbibytes", DuHuman, 1<<63 - 1, "8.0E"},
+		{"human negative", DuHuman, -5, "0"},
+
+		// du --si
+		{"si bytes", DuSI, 999, "999"},
+		{"si kilobytes", DuSI, 4096, "4.1k"},
+		{"si megabytes", DuSI, 12 * MiB, "13M"},
+
+		// fixed block sizes round up to whole blocks
+		{"kilobytes zero", DuKilobytes, 0, "0"},
+		{"kilobytes one byte", DuKilobytes, 1, "1"},
+		{"kilobytes exact", DuKilobytes, 8192, "8"},
+		{"kilobytes partial", DuKilobytes, 8193, "9"},
+		{"megabytes", DuMegabytes, 5*MiB + 1, "6"},
+		{"bytes", DuBytes, 12345, "12345"},
+	}
+
+	for _, tc := range testCases {
+		result := tc.formatter.Format(tc.input)
+		if result != tc.expected {
+			t.Errorf("%s: Format(%d) = %q, expected %q", tc.name, tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestParseDuBlockSize tests --block-size specs and their output
+func TestParseDuBlockSize(t *testing.T) {
+	testCases := []struct {
+		spec     string
+		input    int64
+		expected string
+		hasError bool
+	}{
+		{"human-readable", 1537, "1.6K", false},
+		{"si", 4096, "4.1k", false},
+		{"1", 12345, "12345", false},
+		{"512", 1025, "3", false},
+		{"1K", 4096, "4", false},
+		{"1M", 5 * MiB, "5", false},
+		{"K", 4097, "5K", false},
+		{"k", 4096, "4K", false},
+		{"M", 5*MiB + 1, "6M", false},
+		{"MB", 5 * MiB, "6MB", false},
+		{"MiB", 5 * MiB, "5MiB", false},
+		{"KB", 4096, "5kB", false},
+		{"kB", 4096, "5kB", false},
+		{"KiB", 4096, "4KiB", false},
+		{"G", 1, "1G", false},
+		{"2K", 4097, "3", false},
+
+		// invalid specs
+		{"", 0, "", true},
+		{"0", 0, "", true},
+		{"X", 0, "", true},
+		{"MX", 0, "", true},
+		{"1.5M", 0, "", true},
+		{"z", 0, "", true},
+		{"-1K", 0, "", true},
+		{"99999999999E", 0, "", true},
+	}
+
+	for _, tc := range testCases {
+		formatter, err := ParseDuBlockSize(tc.spec)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseDuBlockSize(%q) expected error but got none", tc.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDuBlockSize(%q) unexpected error: %v", tc.spec, err)
+			continue
+		}
+
+		result := formatter.Format(tc.input)
+		if result != tc.expected {
+			t.Errorf("ParseDuBlockSize(%q).Format(%d) = %q, expected %q", tc.spec, tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestDuFormatter_Line tests du's tab-separated output lines
+func TestDuFormatter_Line(t *testing.T) {
+	result := DuHuman.Line(4096, "./src")
+	if result != "4.0K\t./src" {
+		t.Errorf("Line(4096, %q) = %q, expected %q", "./src", result, "4.0K\t./src")
+	}
+}
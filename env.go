@@ -0,0 +1,32 @@
+package filesize
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SizeFromEnv reads a size from the environment variable key
+//
+// An unset or blank variable yields def, so services get a sane default in
+// one call: SizeFromEnv("MAX_HEAP", 512*MiB). A value that does not parse
+// also yields def, together with an error naming the variable, letting
+// callers decide whether to fail or carry on with the default.
+func SizeFromEnv(key string, def int64) (Size, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return Size(def), nil
+	}
+
+	bytes, err := ParseSize(value)
+	if err != nil {
+		return Size(def), fmt.Errorf("invalid size in %s: %w", key, err)
+	}
+	return Size(bytes), nil
+}
+
+// Decode implements the Decoder interface of kelseyhightower/envconfig,
+// parsing the variable like UnmarshalText
+func (s *Size) Decode(value string) error {
+	return s.UnmarshalText([]byte(value))
+}
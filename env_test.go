@@ -0,0 +1,64 @@
+package filesize
+
+import (
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// TestSizeFromEnv tests reading sizes from the environment
+func TestSizeFromEnv(t *testing.T) {
+	testCases := []struct {
+		value    string
+		set      bool
+		expected Size
+		hasError bool
+	}{
+		{"", false, Size(512 * MiB), false},
+		{"  ", true, Size(512 * MiB), false},
+		{"2GiB", true, Size(2 * GiB), false},
+		{"4096", true, 4096, false},
+		{"lots", true, Size(512 * MiB), true},
+		{"-1k", true, Size(512 * MiB), true},
+	}
+
+	for _, tc := range testCases {
+		if tc.set {
+			t.Setenv("FILESIZE_TEST_MAX_HEAP", tc.value)
+		}
+
+		result, err := SizeFromEnv("FILESIZE_TEST_MAX_HEAP", 512*MiB)
+		if tc.hasError && err == nil {
+			t.Errorf("SizeFromEnv(%q) expected error but got none", tc.value)
+		}
+		if !tc.hasError && err != nil {
+			t.Errorf("SizeFromEnv(%q) unexpected error: %v", tc.value, err)
+		}
+		if result != tc.expected {
+			t.Errorf("SizeFromEnv(%q) = %d, expected %d", tc.value, int64(result), int64(tc.expected))
+		}
+	}
+}
+
+// TestSize_Decode tests decoding through kelseyhightower/envconfig
+func TestSize_Decode(t *testing.T) {
+	type spec struct {
+		MaxHeap   Size `envconfig:"MAX_HEAP" default:"256MiB"`
+		CacheSize Size `envconfig:"CACHE_SIZE" default:"64m"`
+	}
+
+	t.Setenv("APP_MAX_HEAP", "1.5GiB")
+
+	var s spec
+	if err := envconfig.Process("app", &s); err != nil {
+		t.Fatalf("envconfig.Process() unexpected error: %v", err)
+	}
+	if s.MaxHeap != Size(GiB*3/2) || s.CacheSize != Size(64*MiB) {
+		t.Errorf("envconfig.Process() = %+v, expected MaxHeap %d and CacheSize %d", s, GiB*3/2, 64*MiB)
+	}
+
+	t.Setenv("APP_CACHE_SIZE", "64 parsecs")
+	if err := envconfig.Process("app", &s); err == nil {
+		t.Errorf("envconfig.Process() with APP_CACHE_SIZE=%q expected error but got none", "64 parsecs")
+	}
+}
@@ -0,0 +1,51 @@
+package filesize
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// FileSize returns the size of the file at path, following symbolic links
+//
+// Directories are rejected, since their reported size says nothing about
+// their contents; use DirSize for those.
+func FileSize(path string) (Size, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return fileInfoSize(path, info)
+}
+
+// MustFileSize is like FileSize but panics if the file cannot be sized
+//
+// It is meant for scripts and tests where a missing file is a bug, e.g.
+// fmt.Println(filesize.MustFileSize("dump.sql")) prints "2.30 GiB".
+func MustFileSize(path string) Size {
+	size, err := FileSize(path)
+	if err != nil {
+		panic(err)
+	}
+	return size
+}
+
+// FSFileSize returns the size of the named file in fsys
+//
+// It works with any fs.FS, such as embedded assets, zip archives or
+// os.DirFS, and like FileSize rejects directories.
+func FSFileSize(fsys fs.FS, name string) (Size, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return 0, err
+	}
+	return fileInfoSize(name, info)
+}
+
+// fileInfoSize returns the size in info, failing for directories
+func fileInfoSize(name string, info fs.FileInfo) (Size, error) {
+	if info.IsDir() {
+		return 0, &fs.PathError{Op: "size", Path: name, Err: errors.New("is a directory")}
+	}
+	return Size(info.Size()), nil
+}
@@ -0,0 +1,59 @@
+package filesize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+// TestFileSize tests sizing files on disk
+func TestFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dump.sql")
+	if err := os.WriteFile(path, make([]byte, 3*KiB/2), 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	size, err := FileSize(path)
+	if err != nil {
+		t.Fatalf("FileSize(%q) unexpected error: %v", path, err)
+	}
+	if size != Size(3*KiB/2) || size.String() != "1.50 KiB" {
+		t.Errorf("FileSize(%q) = %d (%s), expected 1536 (1.50 KiB)", path, int64(size), size)
+	}
+	if MustFileSize(path) != size {
+		t.Errorf("MustFileSize(%q) = %d, expected %d", path, int64(MustFileSize(path)), int64(size))
+	}
+
+	if _, err := FileSize(dir); err == nil {
+		t.Errorf("FileSize(dir) expected error but got none")
+	}
+	if _, err := FileSize(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("FileSize(missing) = %v, expected a not-exist error", err)
+	}
+}
+
+// TestMustFileSize_Panics tests that missing files panic
+func TestMustFileSize_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustFileSize(missing) did not panic")
+		}
+	}()
+	MustFileSize(filepath.Join(t.TempDir(), "missing"))
+}
+
+// TestFSFileSize tests sizing files in an fs.FS
+func TestFSFileSize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"assets/app.js": {Data: make([]byte, 2048)},
+	}
+
+	if size, err := FSFileSize(fsys, "assets/app.js"); size != 2048 || err != nil {
+		t.Errorf("FSFileSize(app.js) = %d, %v, expected 2048", int64(size), err)
+	}
+	if _, err := FSFileSize(fsys, "assets"); err == nil {
+		t.Errorf("FSFileSize(assets) expected error for a directory but got none")
+	}
+}
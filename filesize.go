@@ -5,7 +5,9 @@ package filesize
 
 import (
 	"fmt"
-	"regexp"
+	"math"
+	"math/big"
+	"math/bits"
 	"strconv"
 	"strings"
 )
@@ -21,6 +23,7 @@ const (
 	GiB = MiB * 1024
 	TiB = GiB * 1024
 	PiB = TiB * 1024
+	EiB = PiB * 1024
 
 	// decimal units (1000-based)
 	KB = Byte * 1000
@@ -28,41 +31,43 @@ const (
 	GB = MB * 1000
 	TB = GB * 1000
 	PB = TB * 1000
+	EB = PB * 1000
 )
 
-// unitMap maps unit strings to their byte multipliers
-// this supports various formats and case variations
-var unitMap = map[string]int64{
+// unitTable lists every unit ParseSize understands
+// this is the single source of truth for parsing and for unit introspection
+var unitTable = []UnitInfo{
 	// bytes
-	"b":     Byte,
-	"byte":  Byte,
-	"bytes": Byte,
+	byteUnit,
 
 	// binary units (1024-based) - standard format
-	"kib": KiB,
-	"mib": MiB,
-	"gib": GiB,
-	"tib": TiB,
-	"pib": PiB,
+	{Name: "KiB", Multiplier: KiB, Convention: ConventionIEC},
+	{Name: "MiB", Multiplier: MiB, Convention: ConventionIEC},
+	{Name: "GiB", Multiplier: GiB, Convention: ConventionIEC},
+	{Name: "TiB", Multiplier: TiB, Convention: ConventionIEC},
+	{Name: "PiB", Multiplier: PiB, Convention: ConventionIEC},
+	{Name: "EiB", Multiplier: EiB, Convention: ConventionIEC},
 
 	// binary units (1024-based) - short format
-	"k": KiB,
-	"m": MiB,
-	"g": GiB,
-	"t": TiB,
-	"p": PiB,
+	{Name: "k", Multiplier: KiB, Convention: ConventionShort},
+	{Name: "m", Multiplier: MiB, Convention: ConventionShort},
+	{Name: "g", Multiplier: GiB, Convention: ConventionShort},
+	{Name: "t", Multiplier: TiB, Convention: ConventionShort},
+	{Name: "p", Multiplier: PiB, Convention: ConventionShort},
+	{Name: "e", Multiplier: EiB, Convention: ConventionShort},
 
 	// decimal units (1000-based) - standard format
-	"kb": KB,
-	"mb": MB,
-	"gb": GB,
-	"tb": TB,
-	"pb": PB,
+	{Name: "KB", Multiplier: KB, Convention: ConventionSI},
+	{Name: "MB", Multiplier: MB, Convention: ConventionSI},
+	{Name: "GB", Multiplier: GB, Convention: ConventionSI},
+	{Name: "TB", Multiplier: TB, Convention: ConventionSI},
+	{Name: "PB", Multiplier: PB, Convention: ConventionSI},
+	{Name: "EB", Multiplier: EB, Convention: ConventionSI},
 }
 
-// parseRegex matches a number followed by an optional unit
-// this regex captures floating point numbers and various unit formats
-var parseRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]*)$`)
+// unitMap maps lowercase unit names and aliases to their definitions
+// this supports various formats and case variations
+var unitMap = DefaultUnits.lookup
 
 // ParseSize converts a human-readable size string to bytes
 //
@@ -75,104 +80,239 @@ var parseRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]*)$`)
 // Binary units use 1024-based calculations (k/K = 1024 bytes)
 // Decimal units use 1000-based calculations (KB = 1000 bytes)
 func ParseSize(sizeStr string) (int64, error) {
-	// trim whitespace from input string
-	sizeStr = strings.TrimSpace(sizeStr)
+	return parseWithUnits(sizeStr, unitMap)
+}
 
-	// handle empty string
-	if sizeStr == "" {
-		return 0, fmt.Errorf("empty size string")
+// parseWithUnits implements ParseSize against the given unit lookup map
+func parseWithUnits(sizeStr string, units map[string]UnitInfo) (int64, error) {
+	bytes, err := parseMagnitude(sizeStr, units, math.MaxInt64)
+	return int64(bytes), err
+}
+
+// parseMagnitude parses an unsigned size string, rejecting results above
+// limit
+//
+// The limit is math.MaxInt64 for ParseSize and one more for the magnitude of
+// a negative size, so that math.MinInt64 can be written and read back.
+func parseMagnitude(sizeStr string, units map[string]UnitInfo, limit uint64) (uint64, error) {
+	// plain integers need no unit lookup
+	if bytes, ok, err := parsePlainInt(sizeStr, limit); ok {
+		return bytes, err
+	}
+
+	// split the input into its number and unit
+	numberStr, unitStr, err := splitSize(sizeStr)
+	if err != nil {
+		return 0, err
 	}
 
-	// match the input against our parsing regex
-	matches := parseRegex.FindStringSubmatch(sizeStr)
-	if matches == nil {
-		return 0, fmt.Errorf("invalid size format: %s", sizeStr)
+	// look up the unit multiplier in our map, assuming bytes without one
+	multiplier := Byte
+	if unitStr != "" {
+		unit, exists := lookupUnit(units, unitStr)
+		if !exists {
+			return 0, fmt.Errorf("unknown unit: %s", strings.ToLower(unitStr))
+		}
+		multiplier = unit.Multiplier
 	}
 
-	// extract number and unit from regex matches
-	numberStr := matches[1]
-	unitStr := strings.ToLower(matches[2])
+	bytes, ok := scaleDecimal(numberStr, uint64(multiplier))
+	if !ok || bytes > limit {
+		return 0, fmt.Errorf("size too large: %s", strings.TrimSpace(sizeStr))
+	}
+	return bytes, nil
+}
 
-	// parse the numeric portion as a float to handle decimals
-	number, err := strconv.ParseFloat(numberStr, 64)
+// scaleDecimal multiplies a decimal number of the form digits[.digits] by
+// multiplier exactly, truncating any fraction of a byte, and reports false
+// when the result does not fit in a uint64
+//
+// The arithmetic is done in integers rather than float64 so that sizes above
+// 2^53 are not rounded, which keeps formatExact output parsing back exactly.
+func scaleDecimal(number string, multiplier uint64) (uint64, bool) {
+	intPart, fracPart, _ := strings.Cut(number, ".")
+
+	whole, err := strconv.ParseUint(intPart, 10, 64)
 	if err != nil {
-		return 0, fmt.Errorf("invalid number: %s", numberStr)
+		return 0, false
+	}
+	hi, bytes := bits.Mul64(whole, multiplier)
+	if hi != 0 {
+		return 0, false
+	}
+
+	bytes, carry := bits.Add64(bytes, scaleFraction(fracPart, multiplier), 0)
+	return bytes, carry == 0
+}
+
+// scaleFraction returns the whole part of 0.digits * multiplier, which is
+// always below multiplier
+func scaleFraction(digits string, multiplier uint64) uint64 {
+	digits = strings.TrimRight(digits, "0")
+	if digits == "" {
+		return 0
+	}
+
+	// up to 19 digits fit in a uint64 along with their power of ten, and the
+	// 128-bit product divides without allocating
+	if len(digits) <= 19 {
+		numerator, _ := strconv.ParseUint(digits, 10, 64)
+		denominator := uint64(1)
+		for range digits {
+			denominator *= 10
+		}
+		hi, lo := bits.Mul64(numerator, multiplier)
+		quotient, _ := bits.Div64(hi, lo, denominator)
+		return quotient
+	}
+
+	// longer fractions are rare enough to go through math/big
+	numerator, _ := new(big.Int).SetString(digits, 10)
+	numerator.Mul(numerator, new(big.Int).SetUint64(multiplier))
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(digits))), nil)
+	return numerator.Quo(numerator, denominator).Uint64()
+}
+
+// parsePlainInt parses sizeStr directly with strconv.ParseUint when it is a
+// bare run of digits, reporting false for anything else
+func parsePlainInt(sizeStr string, limit uint64) (uint64, bool, error) {
+	sizeStr = strings.TrimSpace(sizeStr)
+	if sizeStr == "" || scanDigits(sizeStr, 0) != len(sizeStr) {
+		return 0, false, nil
+	}
+
+	bytes, err := strconv.ParseUint(sizeStr, 10, 64)
+	if err != nil || bytes > limit {
+		return 0, true, fmt.Errorf("size too large: %s", sizeStr)
 	}
+	return bytes, true, nil
+}
 
-	// check for negative numbers
-	if number < 0 {
-		return 0, fmt.Errorf("size cannot be negative: %f", number)
+// ParseSizeWithUnit parses a size string like ParseSize but also returns how
+// it was written
+//
+// The value is the number as written, unit is the unit exactly as the user
+// typed it (empty for plain numbers) and multiplier is the number of bytes
+// per unit. Tools can use these to write values back out in the user's
+// original style, e.g. "1.5 GiB" yields (1.5, "GiB", GiB).
+func ParseSizeWithUnit(sizeStr string) (value float64, unit string, multiplier int64, err error) {
+	return parseWithUnitDetails(sizeStr, unitMap)
+}
+
+// parseWithUnitDetails implements ParseSizeWithUnit against a unit lookup map
+func parseWithUnitDetails(sizeStr string, units map[string]UnitInfo) (float64, string, int64, error) {
+	// validate the whole string first so errors match ParseSize
+	if _, err := parseWithUnits(sizeStr, units); err != nil {
+		return 0, "", 0, err
+	}
+
+	numberStr, unitStr, err := splitSize(sizeStr)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	value, err := strconv.ParseFloat(numberStr, 64)
+	if err != nil {
+		return 0, "", 0, fmt.Errorf("invalid number: %s", numberStr)
 	}
 
-	// handle case where no unit is specified (assume bytes)
+	// plain numbers count bytes
 	if unitStr == "" {
-		return int64(number), nil
+		return value, "", Byte, nil
 	}
 
-	// look up the unit multiplier in our map
-	multiplier, exists := unitMap[unitStr]
-	if !exists {
-		return 0, fmt.Errorf("unknown unit: %s", unitStr)
+	unit, _ := lookupUnit(units, unitStr)
+	return value, unitStr, unit.Multiplier, nil
+}
+
+// splitSize trims a size string and separates its number from its unit
+//
+// The accepted syntax is digits with an optional fraction, optional
+// whitespace and an optional unit made of ASCII letters. The unit is returned
+// with its original case and is empty for plain numbers. The scanner slices
+// the input rather than copying it, so it never allocates on success.
+func splitSize(sizeStr string) (string, string, error) {
+	// trim whitespace from input string
+	sizeStr = strings.TrimSpace(sizeStr)
+
+	// handle empty string
+	if sizeStr == "" {
+		return "", "", fmt.Errorf("empty size string")
 	}
 
-	// calculate final byte count
-	result := number * float64(multiplier)
+	// scan the integer part, which is mandatory
+	i := scanDigits(sizeStr, 0)
+	if i == 0 {
+		return "", "", fmt.Errorf("invalid size format: %s", sizeStr)
+	}
+
+	// scan an optional fraction, which needs at least one digit
+	if i < len(sizeStr) && sizeStr[i] == '.' {
+		end := scanDigits(sizeStr, i+1)
+		if end == i+1 {
+			return "", "", fmt.Errorf("invalid size format: %s", sizeStr)
+		}
+		i = end
+	}
+	numberEnd := i
+
+	// skip whitespace between the number and the unit
+	for i < len(sizeStr) && isSpace(sizeStr[i]) {
+		i++
+	}
+
+	// the rest must be the unit
+	unitStart := i
+	for i < len(sizeStr) && isLetter(sizeStr[i]) {
+		i++
+	}
+	if i != len(sizeStr) {
+		return "", "", fmt.Errorf("invalid size format: %s", sizeStr)
+	}
+
+	return sizeStr[:numberEnd], sizeStr[unitStart:], nil
+}
 
-	// check for overflow by comparing against max int64
-	if result > float64(int64(^uint64(0)>>1)) {
-		return 0, fmt.Errorf("size too large: %s", sizeStr)
+// scanDigits returns the index of the first non-digit in s at or after i
+func scanDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
 	}
+	return i
+}
 
-	return int64(result), nil
+// isSpace reports whether c is ASCII whitespace
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
 }
 
-// FormatSize converts a byte count to a human-readable string using binary units
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+	c |= 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// lookupUnit finds a unit in a lookup map keyed by lowercase names
 //
-// This function automatically selects the most appropriate unit (KiB, MiB, etc.)
-// and formats the result to a reasonable number of decimal places.
-func FormatSize(bytes int64) string {
-	// handle special cases
-	if bytes < 0 {
-		return "0 B"
-	}
-	if bytes < KiB {
-		return fmt.Sprintf("%d B", bytes)
-	}
-
-	// define units in descending order for formatting
-	units := []struct {
-		name       string
-		multiplier int64
-	}{
-		{"PiB", PiB},
-		{"TiB", TiB},
-		{"GiB", GiB},
-		{"MiB", MiB},
-		{"KiB", KiB},
-	}
-
-	// find the largest unit that the byte count can be expressed in
-	for _, unit := range units {
-		if bytes >= unit.multiplier {
-			// calculate the value in this unit
-			value := float64(bytes) / float64(unit.multiplier)
-
-			// format with appropriate precision
-			if value >= 100 {
-				// for large values, show no decimal places
-				return fmt.Sprintf("%.0f %s", value, unit.name)
-			} else if value >= 10 {
-				// for medium values, show one decimal place
-				return fmt.Sprintf("%.1f %s", value, unit.name)
-			} else {
-				// for small values, show two decimal places
-				return fmt.Sprintf("%.2f %s", value, unit.name)
-			}
+// Short names are lowercased in a stack buffer so that the lookup does not
+// allocate, which keeps ParseSize allocation-free.
+func lookupUnit(units map[string]UnitInfo, name string) (UnitInfo, bool) {
+	var buf [16]byte
+	if len(name) > len(buf) {
+		unit, ok := units[strings.ToLower(name)]
+		return unit, ok
+	}
+
+	lower := buf[:len(name)]
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
 		}
+		lower[i] = c
 	}
 
-	// fallback to bytes (should never reach here due to earlier check)
-	return fmt.Sprintf("%d B", bytes)
+	unit, ok := units[string(lower)]
+	return unit, ok
 }
 
 // ValidateSize checks if a size string is valid without parsing it
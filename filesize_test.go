@@ -18,6 +18,20 @@ func TestParseSize(t *testing.T) {
 		{"1024", 1024, false},
 		{"2048", 2048, false},
 
+		// plain integers are exact beyond float64 precision
+		{"9007199254740993", 9007199254740993, false},
+		{"9223372036854775807", 9223372036854775807, false},
+		{"9223372036854775808", 0, true},
+		{"9223372036854775807B", 9223372036854775807, false},
+		{"9223372036854775807.5", 9223372036854775807, false},
+		{"9223372036854775808B", 0, true},
+		{"8796093022208PiB", 0, true},
+		{"8EiB", 0, true},
+		{"8e", 0, true},
+		{"8192PiB", 0, true},
+		{"7.5EiB", 15 << 59, false},
+		{"000123", 123, false},
+
 		// binary units - short format (1024-based)
 		{"1k", 1024, false},
 		{"1K", 1024, false},
@@ -38,6 +52,17 @@ func TestParseSize(t *testing.T) {
 		{"1MB", 1000 * 1000, false},
 		{"1GB", 1000 * 1000 * 1000, false},
 		{"1TB", 1000 * 1000 * 1000 * 1000, false},
+		{"1EB", 1000 * 1000 * 1000 * 1000 * 1000 * 1000, false},
+		{"1EiB", 1024 * 1024 * 1024 * 1024 * 1024 * 1024, false},
+		{"2e", 2 * 1024 * 1024 * 1024 * 1024 * 1024 * 1024, false},
+
+		// fractions are scaled exactly, beyond float64 precision
+		{"9007199254740993B", 9007199254740993, false},
+		{"4503599627370496.5KiB", 1<<62 + 512, false},
+		{"7.999999999999999999EiB", 9223372036854775806, false},
+		{"0.50000000000000000000001e", 1 << 59, false},
+		{"0.00000000000000000000001EiB", 0, false},
+		{"0.1k", 102, false},
 
 		// floating point values
 		{"1.5k", int64(1.5 * 1024), false},
@@ -67,6 +92,10 @@ func TestParseSize(t *testing.T) {
 		{"k1", 0, true},
 		{"1xy", 0, true},
 		{"1.2.3k", 0, true},
+		{"1.k", 0, true},
+		{".5k", 0, true},
+		{"1k b", 0, true},
+		{"1\tk", 1024, false},
 
 		// error cases - negative numbers
 		{"-1", 0, true},
@@ -129,43 +158,39 @@ func TestParseSize_SpecificExamples(t *testing.T) {
 	}
 }
 
-// TestFormatSize tests the FormatSize function
-func TestFormatSize(t *testing.T) {
+// TestParseSizeWithUnit tests that the unit is reported as written
+func TestParseSizeWithUnit(t *testing.T) {
 	testCases := []struct {
-		input    int64
-		expected string
+		input      string
+		value      float64
+		unit       string
+		multiplier int64
+		hasError   bool
 	}{
-		// basic byte values
-		{0, "0 B"},
-		{1, "1 B"},
-		{512, "512 B"},
-		{1023, "1023 B"},
-
-		// kibibytes
-		{1024, "1.00 KiB"},
-		{2048, "2.00 KiB"},
-		{1536, "1.50 KiB"},
-		{10240, "10.0 KiB"},
-		{102400, "100 KiB"},
-
-		// mebibytes
-		{1024 * 1024, "1.00 MiB"},
-		{1024 * 1024 * 2, "2.00 MiB"},
-		{1024 * 1024 * 10, "10.0 MiB"},
-		{1024 * 1024 * 100, "100 MiB"},
-
-		// gibibytes
-		{1024 * 1024 * 1024, "1.00 GiB"},
-		{1024 * 1024 * 1024 * 2, "2.00 GiB"},
-
-		// negative values (edge case)
-		{-1, "0 B"},
+		{"1024", 1024, "", 1, false},
+		{"1.5 GiB", 1.5, "GiB", GiB, false},
+		{"4k", 4, "k", KiB, false},
+		{" 2.5MB ", 2.5, "MB", MB, false},
+		{"100bytes", 100, "bytes", 1, false},
+		{"1xy", 0, "", 0, true},
+		{"", 0, "", 0, true},
 	}
 
 	for _, tc := range testCases {
-		result := FormatSize(tc.input)
-		if result != tc.expected {
-			t.Errorf("FormatSize(%d) = %q, expected %q", tc.input, result, tc.expected)
+		value, unit, multiplier, err := ParseSizeWithUnit(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseSizeWithUnit(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSizeWithUnit(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if value != tc.value || unit != tc.unit || multiplier != tc.multiplier {
+			t.Errorf("ParseSizeWithUnit(%q) = (%g, %q, %d), expected (%g, %q, %d)",
+				tc.input, value, unit, multiplier, tc.value, tc.unit, tc.multiplier)
 		}
 	}
 }
@@ -204,6 +229,20 @@ func TestValidateSize(t *testing.T) {
 	}
 }
 
+// TestParseSize_Allocations tests that successful parses do not allocate
+func TestParseSize_Allocations(t *testing.T) {
+	inputs := []string{"1024", "1.5 MiB", " 4K ", "10GB", "100bytes"}
+
+	for _, input := range inputs {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = ParseSize(input)
+		})
+		if allocs != 0 {
+			t.Errorf("ParseSize(%q) allocated %.0f times per call, expected 0", input, allocs)
+		}
+	}
+}
+
 // BenchmarkParseSize benchmarks the ParseSize function
 func BenchmarkParseSize(b *testing.B) {
 	testCases := []string{
@@ -214,6 +253,7 @@ func BenchmarkParseSize(b *testing.B) {
 		"1GiB",
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		for _, tc := range testCases {
@@ -221,21 +261,3 @@ func BenchmarkParseSize(b *testing.B) {
 		}
 	}
 }
-
-// BenchmarkFormatSize benchmarks the FormatSize function
-func BenchmarkFormatSize(b *testing.B) {
-	testCases := []int64{
-		1024,
-		1024 * 1024,
-		1024 * 1024 * 1024,
-		1000 * 1000,
-		1000 * 1000 * 1000,
-	}
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		for _, tc := range testCases {
-			_ = FormatSize(tc)
-		}
-	}
-}
@@ -0,0 +1,86 @@
+package filesize
+
+// SizeValue is a flag.Value holding a Size, so command-line flags accept
+// human-readable sizes such as "-buffer-size 4k"
+//
+// It also implements flag.Getter and the Type method that spf13/pflag
+// expects, so the same value works with the standard library and pflag.
+// The sizeflag subpackage wraps it in one-line flag declarations.
+type SizeValue Size
+
+// NewSizeValue sets *p to value and returns a SizeValue that stores into p
+func NewSizeValue(value Size, p *Size) *SizeValue {
+	*p = value
+	return (*SizeValue)(p)
+}
+
+// Set parses a size string like ParseSize, rejecting negative sizes
+func (v *SizeValue) Set(s string) error {
+	bytes, err := ParseSize(s)
+	if err != nil {
+		return err
+	}
+
+	*v = SizeValue(bytes)
+	return nil
+}
+
+// String returns the canonical text form, e.g. "4KiB", which is how flag
+// help output shows defaults
+func (v *SizeValue) String() string {
+	if v == nil {
+		return formatExact(0, "")
+	}
+	return formatExact(int64(*v), "")
+}
+
+// Get implements flag.Getter, returning the current value as a Size
+func (v *SizeValue) Get() any {
+	return Size(*v)
+}
+
+// Type returns "size", the placeholder pflag shows in help output
+func (v *SizeValue) Type() string {
+	return "size"
+}
+
+// RateValue is a flag.Value holding a Rate, so command-line flags accept
+// rates such as "-bandwidth 10MB/s" or "-quota 1GiB/day"
+//
+// Like SizeValue it implements flag.Getter and pflag's Type method.
+type RateValue Rate
+
+// NewRateValue sets *p to value and returns a RateValue that stores into p
+func NewRateValue(value Rate, p *Rate) *RateValue {
+	*p = value
+	return (*RateValue)(p)
+}
+
+// Set parses a rate string like ParseRate
+func (v *RateValue) Set(s string) error {
+	rate, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+
+	*v = RateValue(rate)
+	return nil
+}
+
+// String returns the canonical text form, e.g. "10MiB/s"
+func (v *RateValue) String() string {
+	if v == nil {
+		return formatRateExact(Rate{})
+	}
+	return formatRateExact(Rate(*v))
+}
+
+// Get implements flag.Getter, returning the current value as a Rate
+func (v *RateValue) Get() any {
+	return Rate(*v)
+}
+
+// Type returns "rate", the placeholder pflag shows in help output
+func (v *RateValue) Type() string {
+	return "rate"
+}
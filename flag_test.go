@@ -0,0 +1,153 @@
+package filesize
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// compile-time checks that the flag values satisfy flag.Getter
+var (
+	_ flag.Getter = (*SizeValue)(nil)
+	_ flag.Getter = (*RateValue)(nil)
+)
+
+// TestSizeValue tests parsing flags into a SizeValue
+func TestSizeValue(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected Size
+		hasError bool
+	}{
+		{nil, Size(4 * KiB), false},
+		{[]string{"-buffer-size", "1m"}, Size(MiB), false},
+		{[]string{"-buffer-size=1.5GB"}, Size(1500 * MB), false},
+		{[]string{"-buffer-size", "-1k"}, 0, true},
+		{[]string{"-buffer-size", "lots"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+
+		var size Size
+		fs.Var(NewSizeValue(Size(4*KiB), &size), "buffer-size", "read buffer size")
+
+		err := fs.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if size != tc.expected {
+			t.Errorf("Parse(%q) = %d, expected %d", tc.args, int64(size), int64(tc.expected))
+		}
+		if got := fs.Lookup("buffer-size").Value.(flag.Getter).Get(); got != tc.expected {
+			t.Errorf("Get() = %v, expected %v", got, tc.expected)
+		}
+	}
+}
+
+// TestSizeValue_Defaults tests that help output shows humanized defaults
+func TestSizeValue_Defaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	var size, zero Size
+	fs.Var(NewSizeValue(Size(4*KiB), &size), "buffer-size", "read buffer `size`")
+	fs.Var(NewSizeValue(0, &zero), "limit", "optional limit")
+	fs.PrintDefaults()
+
+	help := out.String()
+	if !strings.Contains(help, "-buffer-size size") || !strings.Contains(help, "(default 4KiB)") {
+		t.Errorf("PrintDefaults() = %q, expected the humanized default 4KiB", help)
+	}
+	if strings.Contains(help, "(default 0B)") {
+		t.Errorf("PrintDefaults() = %q, expected no default for a zero size", help)
+	}
+}
+
+// TestRateValue tests parsing flags into a RateValue
+func TestRateValue(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected Rate
+		hasError bool
+	}{
+		{nil, PerSecond(10 * MiB), false},
+		{[]string{"-bandwidth", "10MB/s"}, Rate{Bytes: Size(10 * MB), Per: time.Second}, false},
+		{[]string{"-bandwidth=1GiB/day"}, Rate{Bytes: Size(GiB), Per: 24 * time.Hour}, false},
+		{[]string{"-bandwidth", "10MB"}, Rate{}, true},
+		{[]string{"-bandwidth", "-1k/s"}, Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+
+		var rate Rate
+		fs.Var(NewRateValue(PerSecond(10*MiB), &rate), "bandwidth", "bandwidth limit")
+
+		err := fs.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if rate != tc.expected {
+			t.Errorf("Parse(%q) = %v, expected %v", tc.args, rate, tc.expected)
+		}
+		if got := fs.Lookup("bandwidth").Value.(flag.Getter).Get(); got != tc.expected {
+			t.Errorf("Get() = %v, expected %v", got, tc.expected)
+		}
+	}
+}
+
+// TestRateValue_String tests the canonical text shown for defaults
+func TestRateValue_String(t *testing.T) {
+	testCases := []struct {
+		input    Rate
+		expected string
+	}{
+		{Rate{}, "0B/s"},
+		{PerSecond(10 * MiB), "10MiB/s"},
+		{Rate{Bytes: Size(GiB), Per: 24 * time.Hour}, "1GiB/day"},
+		{Rate{Bytes: Size(2 * GiB), Per: 10 * time.Minute}, "2GiB/10min"},
+		{Rate{Bytes: 1500, Per: 90 * time.Second}, "1500B/90s"},
+		{Rate{Bytes: 1, Per: time.Millisecond}, "1000B/s"},
+	}
+
+	for _, tc := range testCases {
+		v := RateValue(tc.input)
+		if result := v.String(); result != tc.expected {
+			t.Errorf("RateValue(%v).String() = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestSizeValue_StringRoundTrip tests that a default shown in help text can
+// be passed back on the command line unchanged
+func TestSizeValue_StringRoundTrip(t *testing.T) {
+	sizes := []Size{0, Size(4 * KiB), 9007199254740993, 1<<62 + 512, math.MaxInt64}
+	for _, size := range sizes {
+		var parsed Size
+		text := NewSizeValue(size, new(Size)).String()
+		if err := NewSizeValue(0, &parsed).Set(text); err != nil || parsed != size {
+			t.Errorf("Set(%q) = %d, %v, expected %d", text, int64(parsed), err, int64(size))
+		}
+	}
+}
@@ -0,0 +1,775 @@
+package filesize
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// FormatOption configures how the formatting functions render a byte count
+type FormatOption func(*formatConfig)
+
+// formatConfig holds the settings assembled from a list of FormatOption values
+type formatConfig struct {
+	// clampNegative renders negative values as "0 B" instead of signed output
+	clampNegative bool
+
+	// explicitSign prefixes positive values with "+" and zero with "±"
+	explicitSign bool
+
+	// valueWidth and unitWidth are the minimum widths of the number and unit
+	// columns, zero meaning no padding
+	valueWidth int
+	unitWidth  int
+
+	// units is the descending ladder of byte units, nil meaning binary units
+	units []scaleUnit
+
+	// customUnits are merged into the byte unit ladder
+	customUnits []UnitInfo
+
+	// maxUnit caps the multiplier of the largest unit used, zero meaning no cap
+	maxUnit int64
+
+	// precision selects how scaled values are rounded, with digits as its
+	// parameter
+	precision precisionMode
+	digits    int
+
+	// approxPrefix marks values rounded by approximate formatting
+	approxPrefix string
+
+	// scientific renders byte counts in exponent notation instead of units
+	scientific bool
+
+	// separator is placed between the number and the unit
+	separator string
+
+	// decimalMark replaces the "." in formatted numbers, empty meaning "."
+	decimalMark string
+
+	// jsonString marshals Size values to JSON as strings instead of numbers
+	jsonString bool
+}
+
+// formatBufferSize is the size of the stack buffer the string-returning
+// functions format into, large enough for any output without padding
+const formatBufferSize = 64
+
+// baseFormatConfig is the configuration used when no default has been set
+var baseFormatConfig = formatConfig{separator: " "}
+
+// defaultFormat holds the configuration installed by SetDefaultFormat
+var defaultFormat atomic.Pointer[formatConfig]
+
+// precisionMode selects how the number part of a formatted size is rounded
+type precisionMode int
+
+const (
+	// precisionAdaptive shows two, one or zero decimals depending on magnitude
+	precisionAdaptive precisionMode = iota
+
+	// precisionFixed always shows a fixed number of decimals
+	precisionFixed
+
+	// precisionSignificant rounds to significant digits and trims zeros
+	precisionSignificant
+
+	// precisionApproximate rounds to whole units and marks rounded values
+	precisionApproximate
+)
+
+// SetDefaultFormat sets the options every formatting call starts from
+//
+// Applications can configure their preferred style once at startup, e.g.
+// SetDefaultFormat(WithUnitSystem(SI), WithFixedDecimals(1), WithSeparator(""))
+// and have FormatSize, Size.String and the other formatting functions honor
+// it everywhere. Options passed to an individual call are applied on top of
+// the default. Calling SetDefaultFormat without options restores the built-in
+// style. It is safe to call concurrently with formatting, but is intended to
+// be called during initialization.
+func SetDefaultFormat(opts ...FormatOption) {
+	cfg := baseFormatConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	defaultFormat.Store(&cfg)
+}
+
+// defaultFormatConfig returns the configuration installed by SetDefaultFormat
+func defaultFormatConfig() formatConfig {
+	if cfg := defaultFormat.Load(); cfg != nil {
+		return *cfg
+	}
+	return baseFormatConfig
+}
+
+// newFormatConfig applies the given options on top of the default settings
+func newFormatConfig(opts []FormatOption) formatConfig {
+	// options receive a pointer, so skip them entirely when there are none to
+	// keep the config on the stack
+	if len(opts) == 0 {
+		return defaultFormatConfig()
+	}
+
+	cfg := defaultFormatConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithClampNegative renders negative byte counts as "0 B"
+//
+// This restores the historical FormatSize behavior for callers that treat
+// negative values as invalid and never want a sign in the output.
+func WithClampNegative() FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.clampNegative = true
+	}
+}
+
+// WithSeparator sets the text placed between the number and the unit
+//
+// The default is a single space; WithSeparator("") produces compact output
+// such as "1.50MiB".
+func WithSeparator(separator string) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.separator = separator
+	}
+}
+
+// WithDecimalMark sets the decimal separator used in formatted numbers
+//
+// Locales that write decimals with a comma can use WithDecimalMark(",") to get
+// "1,50 MiB". Grouping of thousands is not performed.
+func WithDecimalMark(mark string) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.decimalMark = mark
+	}
+}
+
+// WithPadding pads output to fixed column widths for tabular display
+//
+// The number (including any sign) is right-aligned within valueWidth runes and
+// the unit is left-aligned within unitWidth runes, so a column of formatted
+// sizes lines up without further processing. WithPadding(7, 3) fits every
+// value FormatSize produces by default. Widths smaller than the content are
+// ignored rather than truncating.
+func WithPadding(valueWidth, unitWidth int) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.valueWidth = valueWidth
+		cfg.unitWidth = unitWidth
+	}
+}
+
+// WithJEDEC formats with JEDEC unit names, i.e. "KB", "MB" and "GB" computed
+// with 1024-based math
+//
+// This matches the convention used by Windows Explorer and many memory
+// datasheets, so 1536 renders as "1.50 KB" rather than "1.50 KiB". Note that
+// ParseSize reads "KB" as 1000 bytes; parse JEDEC output with JEDEC.Parse or
+// a Parser built from JEDEC. It is shorthand for WithUnitSystem(JEDEC).
+func WithJEDEC() FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.units = JEDEC.ladder
+	}
+}
+
+// WithCustomUnits lets the formatter choose from additional units
+//
+// The units are merged into the byte unit ladder by multiplier, so with a
+// custom "seg" unit of 8 MiB a 16 MiB value renders as "2.00 seg". This is
+// typically fed from Parser.CustomUnits so that domain units round-trip
+// through parsing and formatting. Where a custom unit has the same multiplier
+// as a built-in one, the built-in unit is preferred.
+func WithCustomUnits(units ...UnitInfo) FormatOption {
+	return func(cfg *formatConfig) {
+		// clip first so a shared default config is never appended into
+		cfg.customUnits = append(slices.Clip(cfg.customUnits), units...)
+	}
+}
+
+// WithMaxUnit caps the largest unit the formatter will select
+//
+// The cap is given as a unit multiplier such as GiB, so that billing and quota
+// displays can express every value in the same unit: with WithMaxUnit(GiB) a
+// 5 TiB value renders as "5120 GiB". Values below the cap still scale down to
+// smaller units as usual. The cap compares multipliers, so it also applies to
+// the JEDEC and other byte unit ladders.
+func WithMaxUnit(multiplier int64) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.maxUnit = multiplier
+	}
+}
+
+// WithSignificantDigits rounds values to the given number of significant
+// digits and drops trailing zeros
+//
+// With three significant digits 1.5 GiB renders as "1.5 GiB" instead of
+// "1.50 GiB", and 1 GiB as "1 GiB". Digits below one are treated as one.
+func WithSignificantDigits(digits int) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.precision = precisionSignificant
+		cfg.digits = max(digits, 1)
+	}
+}
+
+// WithFixedDecimals always renders the given number of decimal places
+//
+// This keeps trailing zeros, so with two decimals both 1 GiB and 100 GiB
+// render with ".00", which suits surfaces that want uniform columns. Counts
+// below the smallest unit are whole bytes and are never given decimals.
+func WithFixedDecimals(decimals int) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.precision = precisionFixed
+		cfg.digits = max(decimals, 0)
+	}
+}
+
+// WithApproximate rounds values aggressively to whole units and marks any
+// value that was rounded with the given prefix
+//
+// This suits progress messages and estimates where precision is noise: with
+// the prefix "~" 1.5 GiB renders as "~2 GiB" and 1023.9 MiB as "~1 GiB",
+// while values that are already whole, such as exactly 2 GiB, render without
+// the prefix. Use a prefix such as "about " for prose.
+func WithApproximate(prefix string) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.precision = precisionApproximate
+		cfg.approxPrefix = prefix
+	}
+}
+
+// WithScientific renders byte counts in exponent notation, e.g. "1.07e9 B"
+//
+// This suits data exports where humanized units get in the way but raw digits
+// are too long. The mantissa is rounded to three significant digits with
+// trailing zeros dropped, unless WithSignificantDigits or WithFixedDecimals
+// says otherwise. Counts below 1000 are written as plain bytes.
+func WithScientific() FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.scientific = true
+	}
+}
+
+// WithJSONString makes Size marshal to JSON as a human-readable string such
+// as "1.5GiB" instead of a raw byte count
+//
+// The string is the canonical MarshalText form, so it always decodes back to
+// the same value. It is meant for SetDefaultFormat and for Formatter.AppendJSON;
+// it does not change how FormatSize renders values.
+func WithJSONString() FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.jsonString = true
+	}
+}
+
+// FormatSize converts a byte count to a human-readable string using binary units
+//
+// This function automatically selects the most appropriate unit (KiB, MiB, etc.)
+// and formats the result to a reasonable number of decimal places. Negative
+// values keep their sign ("-1.50 MiB") unless WithClampNegative is given.
+func FormatSize(bytes int64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	return cfg.format(bytes)
+}
+
+// FormatSizeUint64 converts an unsigned byte count to a human-readable string
+//
+// It behaves like FormatSize but accepts the full uint64 range, so counters
+// read from /proc, eBPF maps and similar sources can be formatted without a
+// lossy conversion to int64: math.MaxUint64 renders as "16.0 EiB".
+func FormatSizeUint64(bytes uint64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	var buf [formatBufferSize]byte
+	return string(cfg.appendMagnitude(buf[:0], compareZero(bytes), bytes))
+}
+
+// FormatDelta converts a signed byte difference to a human-readable string
+//
+// Unlike FormatSize the sign is always shown, which suits before/after
+// comparisons: growth renders as "+1.50 MiB", shrinkage as "-320 KiB" and no
+// change as "±0 B". WithClampNegative has no effect on deltas.
+func FormatDelta(bytes int64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	cfg.clampNegative = false
+	cfg.explicitSign = true
+	return cfg.format(bytes)
+}
+
+// shortestUnits lists the unit suffixes FormatShortest may emit, in order of
+// preference when two candidates are equally short
+var shortestUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"e", EiB},
+	{"p", PiB},
+	{"t", TiB},
+	{"g", GiB},
+	{"m", MiB},
+	{"k", KiB},
+	{"EB", EB},
+	{"PB", PB},
+	{"TB", TB},
+	{"GB", GB},
+	{"MB", MB},
+	{"KB", KB},
+}
+
+// FormatShortest returns the shortest string that ParseSize maps back to
+// exactly the same byte count
+//
+// This is intended for writing normalized values back into configuration
+// files: 4096 becomes "4k", 1572864 becomes "1.5m" and 1000000 becomes "1MB",
+// while counts with no shorter exact form stay as plain digits. Negative
+// values are rendered as "-" followed by the shortest form of their magnitude,
+// which ParseSize itself does not accept.
+func FormatShortest(bytes int64) string {
+	if bytes < 0 {
+		return "-" + formatShortestMagnitude(absInt64(bytes))
+	}
+	return formatShortestMagnitude(uint64(bytes))
+}
+
+// formatShortestMagnitude picks the shortest exact representation of bytes
+func formatShortestMagnitude(bytes uint64) string {
+	best := strconv.FormatUint(bytes, 10)
+	if bytes == 0 || bytes > uint64(1<<63-1) {
+		return best
+	}
+
+	// try every unit and keep the shortest candidate that round-trips
+	for _, unit := range shortestUnits {
+		if bytes < uint64(unit.multiplier) {
+			continue
+		}
+
+		value := float64(bytes) / float64(unit.multiplier)
+		candidate := strconv.FormatFloat(value, 'f', -1, 64) + unit.suffix
+		if len(candidate) >= len(best) {
+			continue
+		}
+
+		if parsed, err := ParseSize(candidate); err == nil && uint64(parsed) == bytes {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+// FormatBits converts a byte count to a human-readable number of bits
+//
+// The count is multiplied by eight and expressed with SI prefixes, which is
+// how network capacity is conventionally quoted: 100000000 bytes renders as
+// "800 Mbit". Formatting options behave as they do for FormatSize.
+func FormatBits(bytes int64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	if bytes < 0 && cfg.clampNegative {
+		bytes = 0
+	}
+
+	var buf [formatBufferSize]byte
+	return string(cfg.appendScaled(buf[:0], compareZero(bytes), float64(absInt64(bytes))*8, siBitUnits, "bit", ""))
+}
+
+// FormatBitRate converts a throughput in bytes per second to a bit rate
+//
+// The result uses SI prefixes and a "/s" suffix, e.g. "800 Mbit/s" for a rate
+// of 100000000 bytes per second.
+func FormatBitRate(bytesPerSec float64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	if bytesPerSec < 0 && cfg.clampNegative {
+		bytesPerSec = 0
+	}
+
+	var buf [formatBufferSize]byte
+	return string(cfg.appendScaled(buf[:0], compareZero(bytesPerSec), math.Abs(bytesPerSec)*8, siBitUnits, "bit", "/s"))
+}
+
+// FormatRate converts a throughput in bytes per second to a human-readable
+// string such as "1.50 MiB/s"
+//
+// Units, precision and the other options work as they do for FormatSize, so
+// FormatRate(980000, WithUnitSystem(SI)) returns "980 kB/s". Rates below the
+// smallest unit are written in "B/s".
+func FormatRate(bytesPerSec float64, opts ...FormatOption) string {
+	cfg := newFormatConfig(opts)
+	var buf [formatBufferSize]byte
+	return string(cfg.appendRate(buf[:0], bytesPerSec))
+}
+
+// appendRate appends a throughput in bytes per second with a "/s" suffix
+func (cfg formatConfig) appendRate(dst []byte, bytesPerSec float64) []byte {
+	if bytesPerSec < 0 && cfg.clampNegative {
+		bytesPerSec = 0
+	}
+	return cfg.appendScaled(dst, compareZero(bytesPerSec), math.Abs(bytesPerSec), cfg.byteUnits(), "B", "/s")
+}
+
+// AppendSize appends the formatted form of bytes to dst and returns the
+// extended buffer
+//
+// It produces the same text as FormatSize but, like strconv.AppendInt, lets
+// hot paths such as loggers reuse a buffer. Without options it performs no
+// heap allocations beyond growing dst.
+func AppendSize(dst []byte, bytes int64, opts ...FormatOption) []byte {
+	cfg := newFormatConfig(opts)
+	return cfg.appendSize(dst, bytes)
+}
+
+// FormatParts returns the number and unit FormatSize would display, without
+// rounding or joining them
+//
+// This lets GUIs and web frontends style the value and unit separately, e.g.
+// FormatParts(1536) returns (1.5, "KiB"). The value carries the sign of bytes
+// and counts below the smallest unit are returned in "B". Options selecting
+// the unit ladder, such as WithJEDEC and WithMaxUnit, are honored; options
+// affecting only the text, such as precision and padding, have no effect.
+func FormatParts(bytes int64, opts ...FormatOption) (float64, string) {
+	cfg := newFormatConfig(opts)
+	if bytes < 0 && cfg.clampNegative {
+		bytes = 0
+	}
+
+	// pick the largest unit the magnitude reaches
+	magnitude := float64(absInt64(bytes))
+	sign := float64(compareZero(bytes))
+	for _, unit := range cfg.byteUnits() {
+		if magnitude >= unit.multiplier {
+			return sign * magnitude / unit.multiplier, unit.name
+		}
+	}
+
+	return float64(bytes), "B"
+}
+
+// format renders a signed byte count according to the config
+func (cfg formatConfig) format(bytes int64) string {
+	var buf [formatBufferSize]byte
+	return string(cfg.appendSize(buf[:0], bytes))
+}
+
+// appendSize appends a signed byte count rendered according to the config
+func (cfg formatConfig) appendSize(dst []byte, bytes int64) []byte {
+	// handle negative values, either clamped or rendered with a sign
+	if bytes < 0 && cfg.clampNegative {
+		bytes = 0
+	}
+
+	return cfg.appendMagnitude(dst, compareZero(bytes), absInt64(bytes))
+}
+
+// appendMagnitude appends an unsigned byte count preceded by the sign given
+// as signum
+func (cfg formatConfig) appendMagnitude(dst []byte, signum int, magnitude uint64) []byte {
+	if cfg.scientific && magnitude >= 1000 {
+		var scratch [32]byte
+		number := cfg.appendScientific(scratch[:0], float64(magnitude))
+		return cfg.appendParts(dst, signum, number, "B", "", false)
+	}
+
+	// counts below the smallest unit are written as whole bytes
+	units := cfg.byteUnits()
+	if len(units) == 0 || float64(magnitude) < units[len(units)-1].multiplier {
+		var scratch [24]byte
+		number := strconv.AppendUint(scratch[:0], magnitude, 10)
+		return cfg.appendParts(dst, signum, number, "B", "", false)
+	}
+
+	return cfg.appendScaled(dst, signum, float64(magnitude), units, "B", "")
+}
+
+// byteUnits returns the configured ladder of byte units, trimmed so that no
+// unit exceeds the maximum unit cap
+func (cfg formatConfig) byteUnits() []scaleUnit {
+	units := cfg.units
+	if units == nil {
+		units = binaryByteUnits
+	}
+	if len(cfg.customUnits) > 0 {
+		units = mergeUnits(units, cfg.customUnits)
+	}
+
+	// the ladder is descending, so drop units from the front until under the cap
+	if cfg.maxUnit > 0 {
+		for len(units) > 0 && units[0].multiplier > float64(cfg.maxUnit) {
+			units = units[1:]
+		}
+	}
+
+	return units
+}
+
+// mergeUnits returns a new descending ladder containing the built-in units
+// and any custom units whose multiplier is not already present
+func mergeUnits(builtin []scaleUnit, custom []UnitInfo) []scaleUnit {
+	merged := append([]scaleUnit(nil), builtin...)
+	for _, unit := range custom {
+		multiplier := float64(unit.Multiplier)
+		if unit.Multiplier <= 0 || slices.ContainsFunc(merged, func(u scaleUnit) bool {
+			return u.multiplier == multiplier
+		}) {
+			continue
+		}
+		merged = append(merged, scaleUnit{unit.Name, multiplier})
+	}
+
+	// keep the ladder in descending order of multiplier
+	slices.SortStableFunc(merged, func(a, b scaleUnit) int {
+		return cmp.Compare(b.multiplier, a.multiplier)
+	})
+
+	return merged
+}
+
+// compareZero returns -1, 0 or +1 depending on the sign of n
+func compareZero[T int64 | uint64 | float64](n T) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sign returns the prefix that precedes a magnitude, given the sign of the
+// value as -1, 0 or +1
+func (cfg formatConfig) sign(signum int) string {
+	switch {
+	case signum < 0:
+		return "-"
+	case !cfg.explicitSign:
+		return ""
+	case signum > 0:
+		return "+"
+	default:
+		return "±"
+	}
+}
+
+// absInt64 returns the magnitude of n as a uint64
+//
+// Converting through uint64 keeps math.MinInt64 representable.
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
+
+// scaleUnit is one step of a unit ladder used when formatting
+type scaleUnit struct {
+	name       string
+	multiplier float64
+}
+
+// binaryByteUnits are the units FormatSize chooses from, in descending order
+var binaryByteUnits = DefaultUnits.ladder
+
+// siBitUnits are the units FormatBits chooses from, in descending order
+var siBitUnits = []scaleUnit{
+	{"Ebit", 1e18},
+	{"Pbit", 1e15},
+	{"Tbit", 1e12},
+	{"Gbit", 1e9},
+	{"Mbit", 1e6},
+	{"kbit", 1e3},
+}
+
+// formatConfig helpers below build output by appending into byte slices so
+// that the public functions share one allocation-free code path
+
+// appendScaled appends a non-negative value expressed in the largest unit of
+// the descending ladder that it reaches, preceded by the sign given as signum
+// and followed by an optional unit suffix such as "/s"
+func (cfg formatConfig) appendScaled(dst []byte, signum int, value float64, units []scaleUnit, base, suffix string) []byte {
+	var scratch [32]byte
+	number, unit, rounded := cfg.scale(scratch[:0], value, units, base)
+	return cfg.appendParts(dst, signum, number, unit, suffix, rounded)
+}
+
+// scale appends the number part of value to dst and returns it together with
+// the chosen unit and whether the number was rounded
+func (cfg formatConfig) scale(dst []byte, value float64, units []scaleUnit, base string) ([]byte, string, bool) {
+	// find the largest unit that the value can be expressed in
+	for i, unit := range units {
+		if value < unit.multiplier {
+			continue
+		}
+
+		scaled := value / unit.multiplier
+		rounded := scaled != math.Trunc(scaled)
+
+		// approximate values that round up to the next unit move into it
+		if cfg.precision == precisionApproximate && i > 0 &&
+			math.Round(scaled)*unit.multiplier >= units[i-1].multiplier {
+			return cfg.appendNumber(dst, 1), units[i-1].name, true
+		}
+
+		return cfg.appendNumber(dst, scaled), unit.name, rounded
+	}
+
+	// whole values in the base unit never need decimal places
+	if value == math.Trunc(value) {
+		return strconv.AppendFloat(dst, value, 'f', 0, 64), base, false
+	}
+	return cfg.appendNumber(dst, value), base, true
+}
+
+// appendParts writes the approximation marker, sign, number and unit,
+// applying any column padding
+func (cfg formatConfig) appendParts(dst []byte, signum int, number []byte, unit, suffix string, rounded bool) []byte {
+	prefix := ""
+	if rounded && cfg.precision == precisionApproximate {
+		prefix = cfg.approxPrefix
+	}
+	sign := cfg.sign(signum)
+
+	// swap in the configured decimal mark
+	if cfg.decimalMark != "" && cfg.decimalMark != "." {
+		var scratch [64]byte
+		localized := scratch[:0]
+		for _, c := range number {
+			if c == '.' {
+				localized = append(localized, cfg.decimalMark...)
+			} else {
+				localized = append(localized, c)
+			}
+		}
+		number = localized
+	}
+
+	// right-align the number within its column
+	width := utf8.RuneCountInString(prefix) + utf8.RuneCountInString(sign) + utf8.RuneCount(number)
+	dst = appendSpaces(dst, cfg.valueWidth-width)
+	dst = append(dst, prefix...)
+	dst = append(dst, sign...)
+	dst = append(dst, number...)
+
+	// left-align the unit within its column
+	dst = append(dst, cfg.separator...)
+	dst = append(dst, unit...)
+	dst = append(dst, suffix...)
+	return appendSpaces(dst, cfg.unitWidth-utf8.RuneCountInString(unit)-utf8.RuneCountInString(suffix))
+}
+
+// appendSpaces appends n spaces to dst, doing nothing when n is not positive
+func appendSpaces(dst []byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// appendNumber appends a scaled value using the configured precision mode
+func (cfg formatConfig) appendNumber(dst []byte, value float64) []byte {
+	switch cfg.precision {
+	case precisionFixed:
+		return strconv.AppendFloat(dst, value, 'f', cfg.digits, 64)
+	case precisionSignificant:
+		return appendSignificant(dst, value, cfg.digits)
+	case precisionApproximate:
+		return strconv.AppendFloat(dst, math.Round(value), 'f', 0, 64)
+	}
+
+	if value >= 100 {
+		// for large values, show no decimal places
+		return strconv.AppendFloat(dst, value, 'f', 0, 64)
+	} else if value >= 10 {
+		// for medium values, show one decimal place
+		return strconv.AppendFloat(dst, value, 'f', 1, 64)
+	}
+
+	// for small values, show two decimal places
+	return strconv.AppendFloat(dst, value, 'f', 2, 64)
+}
+
+// appendScientific appends value in exponent notation with a compact
+// exponent, i.e. "1.07e9" rather than strconv's "1.07e+09"
+func (cfg formatConfig) appendScientific(dst []byte, value float64) []byte {
+	decimals, trim := 2, true
+	switch cfg.precision {
+	case precisionFixed:
+		decimals, trim = cfg.digits, false
+	case precisionSignificant:
+		decimals = cfg.digits - 1
+	}
+
+	// format into scratch space so the exponent can be rewritten
+	var scratch [32]byte
+	formatted := strconv.AppendFloat(scratch[:0], value, 'e', decimals, 64)
+	e := 0
+	for formatted[e] != 'e' {
+		e++
+	}
+	mantissa, exponent := formatted[:e], formatted[e+1:]
+
+	// drop trailing zeros from the mantissa when not using fixed decimals
+	if trim && decimals > 0 {
+		for mantissa[len(mantissa)-1] == '0' {
+			mantissa = mantissa[:len(mantissa)-1]
+		}
+		mantissa = bytesTrimSuffixDot(mantissa)
+	}
+
+	// drop the plus sign and leading zeros from the exponent
+	if exponent[0] == '+' {
+		exponent = exponent[1:]
+	}
+	for len(exponent) > 1 && exponent[0] == '0' {
+		exponent = exponent[1:]
+	}
+
+	dst = append(dst, mantissa...)
+	dst = append(dst, 'e')
+	return append(dst, exponent...)
+}
+
+// bytesTrimSuffixDot removes a trailing decimal point from b
+func bytesTrimSuffixDot(b []byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == '.' {
+		return b[:len(b)-1]
+	}
+	return b
+}
+
+// appendSignificant appends value with at most the given number of
+// significant digits and no trailing zeros
+//
+// Integer digits are never rounded away, so 1023.6 with three significant
+// digits renders as "1024" rather than "1.02e+03".
+func appendSignificant(dst []byte, value float64, digits int) []byte {
+	decimals := digits
+	if value != 0 {
+		decimals = digits - int(math.Floor(math.Log10(value))) - 1
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	// drop trailing zeros and a dangling decimal point
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, value, 'f', decimals, 64)
+	if decimals > 0 {
+		for dst[len(dst)-1] == '0' {
+			dst = dst[:len(dst)-1]
+		}
+		if dst[len(dst)-1] == '.' && len(dst)-1 > start {
+			dst = dst[:len(dst)-1]
+		}
+	}
+
+	return dst
+}
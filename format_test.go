@@ -0,0 +1,524 @@
+package filesize
+
+import (
+	"testing"
+)
+
+// TestFormatSize tests the FormatSize function
+func TestFormatSize(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		// basic byte values
+		{0, "0 B"},
+		{1, "1 B"},
+		{512, "512 B"},
+		{1023, "1023 B"},
+
+		// kibibytes
+		{1024, "1.00 KiB"},
+		{2048, "2.00 KiB"},
+		{1536, "1.50 KiB"},
+		{10240, "10.0 KiB"},
+		{102400, "100 KiB"},
+
+		// mebibytes
+		{1024 * 1024, "1.00 MiB"},
+		{1024 * 1024 * 2, "2.00 MiB"},
+		{1024 * 1024 * 10, "10.0 MiB"},
+		{1024 * 1024 * 100, "100 MiB"},
+
+		// gibibytes
+		{1024 * 1024 * 1024, "1.00 GiB"},
+		{1024 * 1024 * 1024 * 2, "2.00 GiB"},
+
+		// negative values keep their sign
+		{-1, "-1 B"},
+		{-1536, "-1.50 KiB"},
+		{-1024 * 1024 * 3 / 2, "-1.50 MiB"},
+		{-1 << 63, "-8.00 EiB"},
+
+		// exbibytes
+		{1024 * 1024 * 1024 * 1024 * 1024 * 1024, "1.00 EiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSizeUint64 tests the FormatSizeUint64 function
+func TestFormatSizeUint64(t *testing.T) {
+	testCases := []struct {
+		input    uint64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.50 KiB"},
+		{1 << 63, "8.00 EiB"},
+		{1<<64 - 1, "16.0 EiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSizeUint64(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatSizeUint64(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+
+	// options apply just as they do for FormatSize
+	if result := FormatSizeUint64(1<<64-1, WithJEDEC()); result != "16.0 EB" {
+		t.Errorf("FormatSizeUint64(MaxUint64, WithJEDEC()) = %q, expected %q", result, "16.0 EB")
+	}
+}
+
+// TestFormatSize_ClampNegative tests the WithClampNegative option
+func TestFormatSize_ClampNegative(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{-1, "0 B"},
+		{-1024 * 1024, "0 B"},
+		{0, "0 B"},
+		{1536, "1.50 KiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithClampNegative())
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithClampNegative()) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatDelta tests the FormatDelta function
+func TestFormatDelta(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "±0 B"},
+		{1, "+1 B"},
+		{-1, "-1 B"},
+		{1024 * 1024 * 3 / 2, "+1.50 MiB"},
+		{-320 * 1024, "-320 KiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatDelta(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatDelta(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+
+	// clamping never applies to deltas
+	if result := FormatDelta(-1024, WithClampNegative()); result != "-1.00 KiB" {
+		t.Errorf("FormatDelta(-1024, WithClampNegative()) = %q, expected %q", result, "-1.00 KiB")
+	}
+}
+
+// TestFormatSize_Padding tests that WithPadding aligns numbers and units
+func TestFormatSize_Padding(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "      0 B  "},
+		{1023, "   1023 B  "},
+		{1536, "   1.50 KiB"},
+		{-102400, "   -100 KiB"},
+		{1024 * 1024 * 10, "   10.0 MiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithPadding(7, 3))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithPadding(7, 3)) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+
+	// signs count towards the value width
+	if result := FormatDelta(0, WithPadding(5, 0)); result != "   ±0 B" {
+		t.Errorf("FormatDelta(0, WithPadding(5, 0)) = %q, expected %q", result, "   ±0 B")
+	}
+
+	// widths narrower than the content never truncate
+	if result := FormatSize(1536, WithPadding(1, 1)); result != "1.50 KiB" {
+		t.Errorf("FormatSize(1536, WithPadding(1, 1)) = %q, expected %q", result, "1.50 KiB")
+	}
+}
+
+// TestFormatShortest tests that FormatShortest is minimal and round-trips
+func TestFormatShortest(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{1000, "1KB"},
+		{1023, "1023"},
+		{1024, "1k"},
+		{1500, "1500"},
+		{1536, "1536"},
+		{1024 * 1024 * 3 / 2, "1.5m"},
+		{4096, "4k"},
+		{1024 * 1024, "1m"},
+		{1000 * 1000, "1MB"},
+		{3 * 1024 * 1024 * 1024, "3g"},
+		{1024*1024 + 1, "1048577"},
+		{-4096, "-4k"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatShortest(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatShortest(%d) = %q, expected %q", tc.input, result, tc.expected)
+			continue
+		}
+
+		// every non-negative result must parse back to the same value
+		if tc.input < 0 {
+			continue
+		}
+		parsed, err := ParseSize(result)
+		if err != nil || parsed != tc.input {
+			t.Errorf("ParseSize(%q) = %d, %v, expected %d", result, parsed, err, tc.input)
+		}
+	}
+}
+
+// TestFormatBits tests the FormatBits function
+func TestFormatBits(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0 bit"},
+		{1, "8 bit"},
+		{125, "1.00 kbit"},
+		{1000 * 1000 * 100, "800 Mbit"},
+		{1000 * 1000 * 1000 * 125, "1.00 Tbit"},
+		{-125 * 1000, "-1.00 Mbit"},
+		{1<<63 - 1, "73.8 Ebit"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatBits(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatBits(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatBitRate tests the FormatBitRate function
+func TestFormatBitRate(t *testing.T) {
+	testCases := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0 bit/s"},
+		{0.5, "4 bit/s"},
+		{0.01, "0.08 bit/s"},
+		{125000, "1.00 Mbit/s"},
+		{1000 * 1000 * 100, "800 Mbit/s"},
+		{1.25e9, "10.0 Gbit/s"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatBitRate(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatBitRate(%g) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSize_JEDEC tests the WithJEDEC option
+func TestFormatSize_JEDEC(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{512, "512 B"},
+		{1024, "1.00 KB"},
+		{1536, "1.50 KB"},
+		{1024 * 1024 * 700, "700 MB"},
+		{1024 * 1024 * 1024 * 5 / 2, "2.50 GB"},
+		{-1024 * 1024 * 1024 * 1024, "-1.00 TB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithJEDEC())
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithJEDEC()) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSize_MaxUnit tests the WithMaxUnit option
+func TestFormatSize_MaxUnit(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		maxUnit  int64
+		expected string
+	}{
+		{5 * 1024 * 1024 * 1024 * 1024, GiB, "5120 GiB"},
+		{1024 * 1024 * 1024 * 1024, GiB, "1024 GiB"},
+		{1536, GiB, "1.50 KiB"},
+		{1024 * 1024 * 1024, MiB, "1024 MiB"},
+		{1024 * 1024, Byte, "1048576 B"},
+		{1024 * 1024 * 1024 * 1024, 0, "1.00 TiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithMaxUnit(tc.maxUnit))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithMaxUnit(%d)) = %q, expected %q", tc.input, tc.maxUnit, result, tc.expected)
+		}
+	}
+
+	// the cap also applies to JEDEC units
+	if result := FormatSize(2*TiB, WithJEDEC(), WithMaxUnit(GiB)); result != "2048 GB" {
+		t.Errorf("FormatSize(2 TiB, WithJEDEC(), WithMaxUnit(GiB)) = %q, expected %q", result, "2048 GB")
+	}
+}
+
+// TestFormatSize_Precision tests the significant digit and fixed decimal options
+func TestFormatSize_Precision(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		opt      FormatOption
+		expected string
+	}{
+		// significant digits trim trailing zeros
+		{1024 * 1024 * 1024 * 3 / 2, WithSignificantDigits(3), "1.5 GiB"},
+		{1024 * 1024 * 1024, WithSignificantDigits(3), "1 GiB"},
+		{1024 * 1024 * 1024 * 100, WithSignificantDigits(3), "100 GiB"},
+		{1260, WithSignificantDigits(3), "1.23 KiB"},
+		{1260, WithSignificantDigits(2), "1.2 KiB"},
+		{1048000, WithSignificantDigits(3), "1023 KiB"},
+		{512, WithSignificantDigits(3), "512 B"},
+
+		// fixed decimals keep trailing zeros at every magnitude
+		{1024 * 1024 * 1024, WithFixedDecimals(2), "1.00 GiB"},
+		{1024 * 1024 * 1024 * 100, WithFixedDecimals(2), "100.00 GiB"},
+		{1536, WithFixedDecimals(0), "2 KiB"},
+		{1536, WithFixedDecimals(3), "1.500 KiB"},
+		{512, WithFixedDecimals(2), "512 B"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, tc.opt)
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, ...) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSize_Approximate tests the WithApproximate option
+func TestFormatSize_Approximate(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		prefix   string
+		expected string
+	}{
+		{1024 * 1024 * 1024 * 3 / 2, "~", "~2 GiB"},
+		{1024 * 1024 * 1024 * 2, "~", "2 GiB"},
+		{1024*1024*1024 - 1024*1024/10, "~", "~1 GiB"},
+		{1200 * 1024, "about ", "about 1 MiB"},
+		{-1536, "~", "~-2 KiB"},
+		{512, "~", "512 B"},
+		{0, "~", "0 B"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithApproximate(tc.prefix))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithApproximate(%q)) = %q, expected %q", tc.input, tc.prefix, result, tc.expected)
+		}
+	}
+}
+
+// TestAppendSize tests that AppendSize matches FormatSize and reuses buffers
+func TestAppendSize(t *testing.T) {
+	inputs := []int64{0, 1, 1023, 1536, -1536, 1024 * 1024 * 10, 1 << 62}
+
+	for _, input := range inputs {
+		result := string(AppendSize([]byte("size="), input))
+		expected := "size=" + FormatSize(input)
+		if result != expected {
+			t.Errorf("AppendSize(%d) = %q, expected %q", input, result, expected)
+		}
+	}
+
+	// options are honored the same way as FormatSize
+	if result := string(AppendSize(nil, 1536, WithPadding(7, 3))); result != "   1.50 KiB" {
+		t.Errorf("AppendSize(1536, WithPadding(7, 3)) = %q, expected %q", result, "   1.50 KiB")
+	}
+
+	// appending into a buffer with spare capacity must not allocate
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendSize(buf[:0], 1024*1024*3/2)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendSize allocated %.0f times per call, expected 0", allocs)
+	}
+}
+
+// TestFormatParts tests the FormatParts function
+func TestFormatParts(t *testing.T) {
+	testCases := []struct {
+		input         int64
+		opts          []FormatOption
+		expectedValue float64
+		expectedUnit  string
+	}{
+		{0, nil, 0, "B"},
+		{1023, nil, 1023, "B"},
+		{1536, nil, 1.5, "KiB"},
+		{-1536, nil, -1.5, "KiB"},
+		{-1536, []FormatOption{WithClampNegative()}, 0, "B"},
+		{1024 * 1024 * 1024 * 5 / 4, nil, 1.25, "GiB"},
+		{1024 * 1024 * 1024 * 5 / 4, []FormatOption{WithJEDEC()}, 1.25, "GB"},
+		{2 * 1024 * 1024 * 1024 * 1024, []FormatOption{WithMaxUnit(GiB)}, 2048, "GiB"},
+	}
+
+	for _, tc := range testCases {
+		value, unit := FormatParts(tc.input, tc.opts...)
+		if value != tc.expectedValue || unit != tc.expectedUnit {
+			t.Errorf("FormatParts(%d) = (%g, %q), expected (%g, %q)", tc.input, value, unit, tc.expectedValue, tc.expectedUnit)
+		}
+	}
+}
+
+// TestFormatSize_Scientific tests the WithScientific option
+func TestFormatSize_Scientific(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		opts     []FormatOption
+		expected string
+	}{
+		{512, nil, "512 B"},
+		{1000, nil, "1e3 B"},
+		{1024 * 1024 * 1024, nil, "1.07e9 B"},
+		{1500000, nil, "1.5e6 B"},
+		{-1024 * 1024 * 1024, nil, "-1.07e9 B"},
+		{1<<63 - 1, nil, "9.22e18 B"},
+		{1024 * 1024 * 1024, []FormatOption{WithSignificantDigits(5)}, "1.0737e9 B"},
+		{1000000, []FormatOption{WithFixedDecimals(2)}, "1.00e6 B"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, append(tc.opts, WithScientific())...)
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithScientific()) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSize_Separator tests the WithSeparator option
+func TestFormatSize_Separator(t *testing.T) {
+	testCases := []struct {
+		input     int64
+		separator string
+		expected  string
+	}{
+		{1536, "", "1.50KiB"},
+		{512, "", "512B"},
+		{1536, "\u00a0", "1.50\u00a0KiB"},
+		{-1536, "_", "-1.50_KiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithSeparator(tc.separator))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithSeparator(%q)) = %q, expected %q", tc.input, tc.separator, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatSize_Allocations tests that FormatSize only allocates its result
+func TestFormatSize_Allocations(t *testing.T) {
+	inputs := []int64{0, 1023, 1536, -1536, 1024 * 1024 * 10, 1 << 62}
+
+	for _, input := range inputs {
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = FormatSize(input)
+		})
+		if allocs > 1 {
+			t.Errorf("FormatSize(%d) allocated %.0f times per call, expected at most 1", input, allocs)
+		}
+	}
+}
+
+// BenchmarkFormatSize benchmarks the FormatSize function
+func BenchmarkFormatSize(b *testing.B) {
+	testCases := []int64{
+		1024,
+		1024 * 1024,
+		1024 * 1024 * 1024,
+		1000 * 1000,
+		1000 * 1000 * 1000,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tc := range testCases {
+			_ = FormatSize(tc)
+		}
+	}
+}
+
+// BenchmarkAppendSize benchmarks the AppendSize function
+func BenchmarkAppendSize(b *testing.B) {
+	testCases := []int64{
+		1024,
+		1024 * 1024,
+		1024 * 1024 * 1024,
+		1000 * 1000,
+		1000 * 1000 * 1000,
+	}
+
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tc := range testCases {
+			buf = AppendSize(buf[:0], tc)
+		}
+	}
+}
+
+// TestFormatRate tests throughput formatting
+func TestFormatRate(t *testing.T) {
+	testCases := []struct {
+		input    float64
+		opts     []FormatOption
+		expected string
+	}{
+		{0, nil, "0 B/s"},
+		{512, nil, "512 B/s"},
+		{0.5, nil, "0.50 B/s"},
+		{1.5 * 1024 * 1024, nil, "1.50 MiB/s"},
+		{980000, []FormatOption{WithUnitSystem(SI)}, "980 kB/s"},
+		{1.5 * 1024 * 1024, []FormatOption{WithSignificantDigits(2)}, "1.5 MiB/s"},
+		{1024, []FormatOption{WithSeparator("")}, "1.00KiB/s"},
+		{-2048, nil, "-2.00 KiB/s"},
+		{-2048, []FormatOption{WithClampNegative()}, "0 B/s"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatRate(tc.input, tc.opts...)
+		if result != tc.expected {
+			t.Errorf("FormatRate(%g) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
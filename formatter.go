@@ -0,0 +1,82 @@
+package filesize
+
+// Formatter formats byte counts with a fixed set of options
+//
+// A Formatter is built once with NewFormatter and then reused: it is
+// immutable, cheap to copy and safe to share between goroutines, which makes
+// it the output-side counterpart of Parser. Unlike the package-level
+// functions it does not follow SetDefaultFormat, so a Formatter renders the
+// same way regardless of global state. The zero value formats with the
+// built-in style.
+type Formatter struct {
+	cfg *formatConfig
+}
+
+// NewFormatter returns a Formatter applying the given options on top of the
+// built-in style
+//
+// For example NewFormatter(WithUnitSystem(SI), WithSignificantDigits(3),
+// WithSeparator(""), WithDecimalMark(",")) renders 1500000 as "1,5MB".
+func NewFormatter(opts ...FormatOption) Formatter {
+	cfg := baseFormatConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return Formatter{cfg: &cfg}
+}
+
+// DefaultFormatter returns a Formatter with the options currently installed
+// by SetDefaultFormat
+func DefaultFormatter() Formatter {
+	cfg := defaultFormatConfig()
+	return Formatter{cfg: &cfg}
+}
+
+// config returns the formatter's configuration, handling the zero value
+func (f Formatter) config() formatConfig {
+	if f.cfg == nil {
+		return baseFormatConfig
+	}
+	return *f.cfg
+}
+
+// Format converts a byte count to a human-readable string
+func (f Formatter) Format(bytes int64) string {
+	cfg := f.config()
+	var buf [formatBufferSize]byte
+	return string(cfg.appendSize(buf[:0], bytes))
+}
+
+// AppendFormat appends the formatted form of bytes to dst and returns the
+// extended buffer, without allocating when dst has room
+func (f Formatter) AppendFormat(dst []byte, bytes int64) []byte {
+	cfg := f.config()
+	return cfg.appendSize(dst, bytes)
+}
+
+// FormatUint64 converts an unsigned byte count to a human-readable string
+func (f Formatter) FormatUint64(bytes uint64) string {
+	cfg := f.config()
+	var buf [formatBufferSize]byte
+	return string(cfg.appendMagnitude(buf[:0], compareZero(bytes), bytes))
+}
+
+// FormatDelta converts a signed byte difference to a string that always
+// carries a sign, see the FormatDelta function
+func (f Formatter) FormatDelta(bytes int64) string {
+	cfg := f.config()
+	cfg.clampNegative = false
+	cfg.explicitSign = true
+	var buf [formatBufferSize]byte
+	return string(cfg.appendSize(buf[:0], bytes))
+}
+
+// FormatRate converts a throughput in bytes per second to a string such as
+// "1.50 MiB/s", see the FormatRate function
+func (f Formatter) FormatRate(bytesPerSec float64) string {
+	cfg := f.config()
+	var buf [formatBufferSize]byte
+	return string(cfg.appendRate(buf[:0], bytesPerSec))
+}
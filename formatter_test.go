@@ -0,0 +1,95 @@
+package filesize
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestFormatter tests formatting through a Formatter value
+func TestFormatter(t *testing.T) {
+	f := NewFormatter(WithUnitSystem(SI), WithSignificantDigits(3), WithSeparator(""), WithDecimalMark(","))
+
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0B"},
+		{999, "999B"},
+		{1500000, "1,5MB"},
+		{-2500, "-2,5kB"},
+		{1234567890, "1,23GB"},
+	}
+
+	for _, tc := range testCases {
+		if result := f.Format(tc.input); result != tc.expected {
+			t.Errorf("Format(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+		if result := string(f.AppendFormat([]byte("x="), tc.input)); result != "x="+tc.expected {
+			t.Errorf("AppendFormat(%d) = %q, expected %q", tc.input, result, "x="+tc.expected)
+		}
+	}
+
+	if result := f.FormatDelta(0); result != "±0B" {
+		t.Errorf("FormatDelta(0) = %q, expected %q", result, "±0B")
+	}
+	if result := f.FormatUint64(1<<64 - 1); result != "18,4EB" {
+		t.Errorf("FormatUint64(MaxUint64) = %q, expected %q", result, "18,4EB")
+	}
+	if result := f.FormatRate(980000); result != "980kB/s" {
+		t.Errorf("FormatRate(980000) = %q, expected %q", result, "980kB/s")
+	}
+}
+
+// TestFormatter_IgnoresDefault tests that formatters are independent of
+// SetDefaultFormat while DefaultFormatter captures it
+func TestFormatter_IgnoresDefault(t *testing.T) {
+	var zero Formatter
+	f := NewFormatter()
+
+	SetDefaultFormat(WithSeparator(""))
+	defer SetDefaultFormat()
+
+	if result := zero.Format(1536); result != "1.50 KiB" {
+		t.Errorf("zero Formatter.Format(1536) = %q, expected %q", result, "1.50 KiB")
+	}
+	if result := f.Format(1536); result != "1.50 KiB" {
+		t.Errorf("NewFormatter().Format(1536) = %q, expected %q", result, "1.50 KiB")
+	}
+	if result := DefaultFormatter().Format(1536); result != "1.50KiB" {
+		t.Errorf("DefaultFormatter().Format(1536) = %q, expected %q", result, "1.50KiB")
+	}
+}
+
+// TestFormatter_Concurrent tests sharing a Formatter between goroutines
+func TestFormatter_Concurrent(t *testing.T) {
+	f := NewFormatter(WithPadding(7, 3))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 0, 32)
+			for j := 0; j < 1000; j++ {
+				buf = f.AppendFormat(buf[:0], 1536)
+				if string(buf) != "   1.50 KiB" {
+					t.Errorf("AppendFormat(1536) = %q, expected %q", buf, "   1.50 KiB")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkFormatter_AppendFormat benchmarks a Formatter with options
+func BenchmarkFormatter_AppendFormat(b *testing.B) {
+	f := NewFormatter(WithUnitSystem(SI), WithSignificantDigits(3))
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = f.AppendFormat(buf[:0], 1234567890)
+	}
+}
@@ -0,0 +1,91 @@
+// Package fsnotifysize drives filesize.GrowthWatcher from filesystem
+// notifications using github.com/fsnotify/fsnotify.
+//
+// Instead of measuring on a fixed interval, Watch checks the watched path
+// whenever the operating system reports a change to it, so growth is seen
+// as soon as it happens without polling idle files:
+//
+//	w, err := filesize.NewGrowthWatcher("/var/log/app.log", func(g filesize.Growth) {
+//		log.Println(g)
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	return fsnotifysize.Watch(ctx, w, time.Minute)
+//
+// When notifications are unavailable, for example on network filesystems
+// or after the system's watch limit is reached, Watch falls back to
+// polling.
+package fsnotifysize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// Watch checks w whenever its path changes until ctx is done
+//
+// A file is watched through its parent directory, so it is picked up again
+// after log rotation replaces it. A directory is watched itself, which
+// covers files added or written directly in it; changes deeper in the tree
+// are caught by the poll every fallback interval, which also backs up
+// missed notifications. A fallback of zero or less disables that poll
+// unless notifications cannot be set up at all, in which case one minute
+// is used. Watch returns ctx's error.
+func Watch(ctx context.Context, w *filesize.GrowthWatcher, fallback time.Duration) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return w.Run(ctx, pollInterval(fallback))
+	}
+	defer fw.Close()
+
+	// watch a file through its directory and filter by name
+	target := filepath.Clean(w.Path())
+	watched, isDir := target, true
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		watched, isDir = filepath.Dir(target), false
+	}
+	if err := fw.Add(watched); err != nil {
+		return w.Run(ctx, pollInterval(fallback))
+	}
+
+	var tick <-chan time.Time
+	if fallback > 0 {
+		ticker := time.NewTicker(fallback)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-fw.Events:
+			if !ok {
+				return w.Run(ctx, pollInterval(fallback))
+			}
+			if isDir || filepath.Clean(event.Name) == target {
+				_ = w.Check()
+			}
+		case _, ok := <-fw.Errors:
+			if !ok {
+				return w.Run(ctx, pollInterval(fallback))
+			}
+		case <-tick:
+			_ = w.Check()
+		}
+	}
+}
+
+// pollInterval returns the polling interval to fall back to
+func pollInterval(fallback time.Duration) time.Duration {
+	if fallback <= 0 {
+		return time.Minute
+	}
+	return fallback
+}
@@ -0,0 +1,56 @@
+package fsnotifysize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// TestWatch tests that writes to a watched file are reported promptly
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "download.part")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	grew := make(chan filesize.Growth, 10)
+	w, err := filesize.NewGrowthWatcher(path, func(g filesize.Growth) { grew <- g })
+	if err != nil {
+		t.Fatalf("NewGrowthWatcher() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Watch(ctx, w, 0) }()
+
+	// keep writing until a report arrives, since the watch starts
+	// asynchronously
+	deadline := time.After(5 * time.Second)
+	size := 0
+	for reported := false; !reported; {
+		size += 1024
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatalf("WriteFile() unexpected error: %v", err)
+		}
+
+		select {
+		case g := <-grew:
+			if g.Delta <= 0 || g.Size <= 0 {
+				t.Errorf("Watch() reported %+v, expected growth", g)
+			}
+			reported = true
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("Watch() reported nothing while the file grew")
+		}
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Watch() = %v, expected %v", err, context.Canceled)
+	}
+}
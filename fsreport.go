@@ -0,0 +1,162 @@
+package filesize
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// FSReport summarizes the space used by a file tree
+//
+// Groups are sorted by size, largest first, with ties broken by name.
+type FSReport struct {
+	// Total is the combined size of all regular files
+	Total Size
+
+	// Files and Dirs count the regular files and directories walked, the
+	// root included
+	Files, Dirs int
+
+	// Largest holds the biggest files, largest first
+	Largest []FileEntry
+
+	// ByExtension groups files by lowercased extension, "" for none
+	ByExtension []UsageGroup
+
+	// ByTopDir groups files by the first path element below the root, "."
+	// for files directly in it
+	ByTopDir []UsageGroup
+}
+
+// FileEntry is a file and its size
+type FileEntry struct {
+	Path string
+	Size Size
+}
+
+// UsageGroup is the space used by a group of files
+type UsageGroup struct {
+	Name  string
+	Size  Size
+	Files int
+}
+
+// SummarizeFS walks root in fsys and reports its space usage, listing up to
+// topN largest files
+//
+// Any fs.FS works: os.DirFS for real directories, embed.FS for embedded
+// assets and zip.Reader for archives. Only regular files are counted, and
+// symbolic links are not followed.
+func SummarizeFS(fsys fs.FS, root string, topN int) (*FSReport, error) {
+	report := &FSReport{}
+	byExt := map[string]*UsageGroup{}
+	byDir := map[string]*UsageGroup{}
+	largest := NewTopN(topN)
+
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			report.Dirs++
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size := Size(info.Size())
+		report.Total += size
+		report.Files++
+
+		addToGroup(byExt, strings.ToLower(path.Ext(name)), size)
+		addToGroup(byDir, topDir(root, name), size)
+		largest.Add(name, int64(size))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if largest.Len() > 0 {
+		report.Largest = largest.Entries()
+	}
+	report.ByExtension = sortedGroups(byExt)
+	report.ByTopDir = sortedGroups(byDir)
+	return report, nil
+}
+
+// addToGroup adds a file of size bytes to the named group
+func addToGroup(groups map[string]*UsageGroup, name string, size Size) {
+	g, ok := groups[name]
+	if !ok {
+		g = &UsageGroup{Name: name}
+		groups[name] = g
+	}
+	g.Size += size
+	g.Files++
+}
+
+// topDir returns the first element of name below root, or "." when name
+// sits directly in root
+func topDir(root, name string) string {
+	rel := name
+	if root != "." {
+		rel = strings.TrimPrefix(strings.TrimPrefix(name, root), "/")
+	}
+
+	dir, _, ok := strings.Cut(rel, "/")
+	if !ok {
+		return "."
+	}
+	return dir
+}
+
+// compareEntries orders files largest first, then by path
+func compareEntries(a, b FileEntry) int {
+	if c := cmp.Compare(b.Size, a.Size); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Path, b.Path)
+}
+
+// sortedGroups returns the groups largest first, then by name
+func sortedGroups(groups map[string]*UsageGroup) []UsageGroup {
+	sorted := make([]UsageGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sortUsageGroups(sorted)
+	return sorted
+}
+
+// String renders the report as aligned, humanized text
+func (r *FSReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "total %s in %d files, %d directories\n", r.Total, r.Files, r.Dirs)
+
+	if len(r.Largest) > 0 {
+		sb.WriteString("\nlargest files:\n")
+		for _, e := range r.Largest {
+			fmt.Fprintf(&sb, "  %s  %s\n", e.Size.Format(WithPadding(7, 3)), e.Path)
+		}
+	}
+
+	writeGroups := func(title string, groups []UsageGroup, empty string) {
+		if len(groups) == 0 {
+			return
+		}
+		sb.WriteString("\n" + title + ":\n")
+		writeUsageGroups(&sb, groups, empty)
+	}
+	writeGroups("by extension", r.ByExtension, "(none)")
+	writeGroups("by directory", r.ByTopDir, ".")
+
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+package filesize
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// testTree is a small asset tree for report tests
+var testTree = fstest.MapFS{
+	"README":            {Data: make([]byte, 100)},
+	"css/site.css":      {Data: make([]byte, 2048)},
+	"img/logo.PNG":      {Data: make([]byte, 10240)},
+	"img/hero.png":      {Data: make([]byte, 204800)},
+	"img/icons/a.svg":   {Data: make([]byte, 512)},
+	"js/app.js":         {Data: make([]byte, 51200)},
+	"js/vendor/lib.js":  {Data: make([]byte, 102400)},
+	"js/vendor/lib.map": {Data: make([]byte, 0)},
+}
+
+// TestSummarizeFS tests totals, top files and breakdowns
+func TestSummarizeFS(t *testing.T) {
+	report, err := SummarizeFS(testTree, ".", 3)
+	if err != nil {
+		t.Fatalf("SummarizeFS() unexpected error: %v", err)
+	}
+
+	if report.Total != 371300 || report.Files != 8 || report.Dirs != 6 {
+		t.Errorf("SummarizeFS() = %d bytes, %d files, %d dirs, expected 371300, 8, 6",
+			int64(report.Total), report.Files, report.Dirs)
+	}
+
+	expectedLargest := []FileEntry{
+		{"img/hero.png", 204800},
+		{"js/vendor/lib.js", 102400},
+		{"js/app.js", 51200},
+	}
+	if !slices.Equal(report.Largest, expectedLargest) {
+		t.Errorf("Largest = %v, expected %v", report.Largest, expectedLargest)
+	}
+
+	expectedExt := []UsageGroup{
+		{".png", 215040, 2},
+		{".js", 153600, 2},
+		{".css", 2048, 1},
+		{".svg", 512, 1},
+		{"", 100, 1},
+		{".map", 0, 1},
+	}
+	if !slices.Equal(report.ByExtension, expectedExt) {
+		t.Errorf("ByExtension = %v, expected %v", report.ByExtension, expectedExt)
+	}
+
+	expectedDirs := []UsageGroup{
+		{"img", 215552, 3},
+		{"js", 153600, 3},
+		{"css", 2048, 1},
+		{".", 100, 1},
+	}
+	if !slices.Equal(report.ByTopDir, expectedDirs) {
+		t.Errorf("ByTopDir = %v, expected %v", report.ByTopDir, expectedDirs)
+	}
+}
+
+// TestSummarizeFS_Subtree tests grouping relative to a non-root start
+func TestSummarizeFS_Subtree(t *testing.T) {
+	report, err := SummarizeFS(testTree, "js", 0)
+	if err != nil {
+		t.Fatalf("SummarizeFS(js) unexpected error: %v", err)
+	}
+
+	expectedDirs := []UsageGroup{{"vendor", 102400, 2}, {".", 51200, 1}}
+	if !slices.Equal(report.ByTopDir, expectedDirs) || report.Largest != nil {
+		t.Errorf("SummarizeFS(js) = %+v, expected groups %v and no largest files", report, expectedDirs)
+	}
+
+	if _, err := SummarizeFS(testTree, "missing", 3); err == nil {
+		t.Errorf("SummarizeFS(missing) expected error but got none")
+	}
+}
+
+// TestFSReport_String tests the rendered report
+func TestFSReport_String(t *testing.T) {
+	report, err := SummarizeFS(testTree, "js", 1)
+	if err != nil {
+		t.Fatalf("SummarizeFS(js) unexpected error: %v", err)
+	}
+
+	expected := "total 150 KiB in 3 files, 2 directories\n" +
+		"\nlargest files:\n" +
+		"      100 KiB  js/vendor/lib.js\n" +
+		"\nby extension:\n" +
+		"      150 KiB       2  .js\n" +
+		"        0 B         1  .map\n" +
+		"\nby directory:\n" +
+		"      100 KiB       2  vendor\n" +
+		"     50.0 KiB       1  .\n"
+	if result := report.String(); result != expected {
+		t.Errorf("String() = %q, expected %q", result, expected)
+	}
+}
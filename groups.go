@@ -0,0 +1,94 @@
+package filesize
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GroupSum totals the sizes of items by key, such as usage by extension,
+// owner or bucket prefix
+//
+//	byOwner := filesize.GroupSum(files, func(f File) string { return f.Owner },
+//		func(f File) int64 { return f.Size })
+//
+// Totals saturate at the largest int64 rather than wrapping around.
+func GroupSum[T any, K comparable](items []T, key func(T) K, size func(T) int64) map[K]Size {
+	totals := make(map[K]Size)
+	for _, item := range items {
+		k := key(item)
+		totals[k] = saturatingAdd(totals[k], size(item))
+	}
+	return totals
+}
+
+// GroupUsage groups items like GroupSum and returns the groups sorted
+// largest first, ties broken by name, with keys formatted by fmt.Sprint
+// and the number of items in each group
+//
+// FormatGroups renders the result as an aligned report.
+func GroupUsage[T any, K comparable](items []T, key func(T) K, size func(T) int64) []UsageGroup {
+	groups := make(map[K]*UsageGroup)
+	for _, item := range items {
+		k := key(item)
+		g, ok := groups[k]
+		if !ok {
+			g = &UsageGroup{Name: fmt.Sprint(k)}
+			groups[k] = g
+		}
+		g.Size = saturatingAdd(g.Size, size(item))
+		g.Files++
+	}
+
+	sorted := make([]UsageGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sortUsageGroups(sorted)
+	return sorted
+}
+
+// FormatGroups renders groups as aligned lines of size, item count and
+// name, each indented by two spaces as in the layout of FSReport
+//
+// Groups with an empty name are shown as "(none)".
+func FormatGroups(groups []UsageGroup) string {
+	var sb strings.Builder
+	writeUsageGroups(&sb, groups, "(none)")
+	return sb.String()
+}
+
+// writeUsageGroups writes one aligned line per group, naming unnamed
+// groups empty
+func writeUsageGroups(sb *strings.Builder, groups []UsageGroup, empty string) {
+	for _, g := range groups {
+		name := g.Name
+		if name == "" {
+			name = empty
+		}
+		fmt.Fprintf(sb, "  %s  %6d  %s\n", g.Size.Format(WithPadding(7, 3)), g.Files, name)
+	}
+}
+
+// sortUsageGroups orders groups largest first, then by name
+func sortUsageGroups(groups []UsageGroup) {
+	slices.SortFunc(groups, func(a, b UsageGroup) int {
+		if c := cmp.Compare(b.Size, a.Size); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// saturatingAdd returns total + n, clamped to the int64 range
+func saturatingAdd(total Size, n int64) Size {
+	sum, ok := addInt64(int64(total), n)
+	if ok {
+		return Size(sum)
+	}
+	if n > 0 {
+		return Size(1<<63 - 1)
+	}
+	return Size(-1 << 63)
+}
@@ -0,0 +1,95 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"testing"
+)
+
+// testFile is an item for grouping tests
+type testFile struct {
+	name  string
+	owner string
+	size  int64
+}
+
+var testFiles = []testFile{
+	{"a.log", "alice", 300 * MiB},
+	{"b.log", "bob", 900 * MiB},
+	{"c.json", "alice", 10 * MiB},
+	{"d.json", "alice", 20 * MiB},
+	{"README", "bob", 4 * KiB},
+}
+
+// TestGroupSum tests totaling by key
+func TestGroupSum(t *testing.T) {
+	byOwner := GroupSum(testFiles, func(f testFile) string { return f.owner },
+		func(f testFile) int64 { return f.size })
+
+	expected := map[string]Size{
+		"alice": Size(330 * MiB),
+		"bob":   Size(900*MiB + 4*KiB),
+	}
+	if len(byOwner) != len(expected) {
+		t.Fatalf("GroupSum() = %v, expected %v", byOwner, expected)
+	}
+	for k, v := range expected {
+		if byOwner[k] != v {
+			t.Errorf("GroupSum()[%q] = %d, expected %d", k, int64(byOwner[k]), int64(v))
+		}
+	}
+
+	// totals saturate instead of wrapping
+	huge := []int64{math.MaxInt64, math.MaxInt64}
+	sums := GroupSum(huge, func(int64) bool { return true }, func(n int64) int64 { return n })
+	if sums[true] != math.MaxInt64 {
+		t.Errorf("GroupSum() overflow = %d, expected MaxInt64", int64(sums[true]))
+	}
+}
+
+// TestGroupUsage tests the sorted report and its rendering
+func TestGroupUsage(t *testing.T) {
+	byExt := GroupUsage(testFiles, func(f testFile) string { return path.Ext(f.name) },
+		func(f testFile) int64 { return f.size })
+
+	expected := []UsageGroup{
+		{Name: ".log", Size: Size(1200 * MiB), Files: 2},
+		{Name: ".json", Size: Size(30 * MiB), Files: 2},
+		{Name: "", Size: Size(4 * KiB), Files: 1},
+	}
+	if !slices.Equal(byExt, expected) {
+		t.Fatalf("GroupUsage() = %+v, expected %+v", byExt, expected)
+	}
+
+	report := FormatGroups(byExt)
+	expectedReport := "" +
+		"     1.17 GiB       2  .log\n" +
+		"     30.0 MiB       2  .json\n" +
+		"     4.00 KiB       1  (none)\n"
+	if report != expectedReport {
+		t.Errorf("FormatGroups() = %q, expected %q", report, expectedReport)
+	}
+
+	// non-string keys are named with fmt.Sprint and ties sort by name
+	bySize := GroupUsage([]int64{5, 5, 7}, func(n int64) int64 { return n % 2 },
+		func(int64) int64 { return 1 })
+	if len(bySize) != 1 || bySize[0].Name != "1" || bySize[0].Files != 3 {
+		t.Errorf("GroupUsage() with int keys = %+v", bySize)
+	}
+}
+
+// ExampleFormatGroups shows the report layout, which keeps the two-space
+// indent of FSReport
+func ExampleFormatGroups() {
+	fmt.Print(FormatGroups([]UsageGroup{
+		{Name: ".log", Size: Size(GiB * 6 / 5), Files: 42},
+		{Name: ".json", Size: Size(310 * MiB), Files: 1024},
+		{Name: "", Size: Size(12 * KiB), Files: 3},
+	}))
+	// Output:
+	//      1.20 GiB      42  .log
+	//       310 MiB    1024  .json
+	//      12.0 KiB       3  (none)
+}
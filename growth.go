@@ -0,0 +1,146 @@
+package filesize
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Growth is a change in the size of a watched file or directory
+type Growth struct {
+	// Path is the watched path
+	Path string
+
+	// Size is the current size
+	Size Size
+
+	// Delta is the change since the previous report, negative when the
+	// path shrank
+	Delta Size
+
+	// Rate is Delta over the time since the previous report
+	Rate Rate
+
+	// Time is when the size was measured
+	Time time.Time
+}
+
+// String formats the change, e.g. "app.log +12.0 MiB (1.20 MiB/s), now 1.40 GiB"
+func (g Growth) String() string {
+	return filepath.Base(g.Path) + " " + FormatDelta(int64(g.Delta)) + " (" + g.Rate.String() + "), now " + g.Size.String()
+}
+
+// GrowthWatcher reports how a file or directory grows over time
+//
+// Each Check measures the path, a file by its length and a directory with
+// DirSize, and reports to the callback when the size changed since the
+// last report. Run checks on a fixed interval; the fsnotifysize subpackage
+// checks whenever the filesystem signals a change instead. A GrowthWatcher
+// is safe for concurrent use.
+type GrowthWatcher struct {
+	mu   sync.Mutex
+	path string
+	fn   func(Growth)
+	size int64
+	last time.Time
+
+	// now is the clock, replaceable in tests
+	now func() time.Time
+}
+
+// NewGrowthWatcher measures path and returns a watcher reporting later
+// changes to fn
+func NewGrowthWatcher(path string, fn func(Growth)) (*GrowthWatcher, error) {
+	return newGrowthWatcher(path, fn, time.Now)
+}
+
+// newGrowthWatcher returns a watcher reading time from now
+func newGrowthWatcher(path string, fn func(Growth), now func() time.Time) (*GrowthWatcher, error) {
+	size, err := measurePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &GrowthWatcher{path: path, fn: fn, size: size, last: now(), now: now}, nil
+}
+
+// Path returns the watched path
+func (w *GrowthWatcher) Path() string {
+	return w.path
+}
+
+// Size returns the size measured by the latest check
+func (w *GrowthWatcher) Size() Size {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return Size(w.size)
+}
+
+// Check measures the path and reports to the callback if its size changed
+//
+// A path that disappeared reports an error and keeps the last size, so a
+// log rotated away and recreated is picked up again by the next check.
+func (w *GrowthWatcher) Check() error {
+	size, err := measurePath(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	now := w.now()
+	if size == w.size {
+		w.mu.Unlock()
+		return nil
+	}
+
+	g := Growth{Path: w.path, Size: Size(size), Delta: Size(size - w.size), Time: now}
+	if elapsed := now.Sub(w.last); elapsed > 0 {
+		g.Rate = Rate{Bytes: g.Delta, Per: elapsed}
+	}
+	w.size, w.last = size, now
+	w.mu.Unlock()
+
+	if w.fn != nil {
+		w.fn(g)
+	}
+	return nil
+}
+
+// Run checks the path every interval until ctx is done
+//
+// Errors from individual checks, such as the file being briefly absent
+// during rotation, do not stop the watcher. Run returns ctx's error, or an
+// error straight away when interval is not positive.
+func (w *GrowthWatcher) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid growth check interval: %v", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_ = w.Check()
+		}
+	}
+}
+
+// measurePath returns the size of a file, or the total size of a directory
+func measurePath(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	size, err := DirSize(path, WithSkipErrors())
+	return int64(size), err
+}
@@ -0,0 +1,122 @@
+package filesize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// appendBytes appends n zero bytes to the file at path
+func appendBytes(t *testing.T, path string, n int) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile() unexpected error: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(make([]byte, n)); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+}
+
+// TestGrowthWatcher tests growth reports for a file
+func TestGrowthWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendBytes(t, path, 1024)
+
+	clock := newFakeClock()
+	var reports []Growth
+	w, err := newGrowthWatcher(path, func(g Growth) { reports = append(reports, g) }, clock.Now)
+	if err != nil {
+		t.Fatalf("NewGrowthWatcher() unexpected error: %v", err)
+	}
+
+	// unchanged files are not reported
+	clock.Advance(time.Second)
+	if err := w.Check(); err != nil || len(reports) != 0 {
+		t.Fatalf("Check() without growth = %v with %d reports, expected none", err, len(reports))
+	}
+
+	clock.Advance(time.Second)
+	appendBytes(t, path, 4*1024*1024)
+	if err := w.Check(); err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Check() made %d reports, expected 1", len(reports))
+	}
+
+	g := reports[0]
+	if g.Delta != Size(4*MiB) || g.Size != Size(4*MiB+1024) || g.Rate.BytesPerSecond() != float64(2*MiB) {
+		t.Errorf("Growth = %+v, expected +4 MiB over two seconds", g)
+	}
+	if g.String() != "app.log +4.00 MiB (2.00 MiB/s), now 4.00 MiB" {
+		t.Errorf("Growth.String() = %q, expected %q", g.String(), "app.log +4.00 MiB (2.00 MiB/s), now 4.00 MiB")
+	}
+
+	// truncation reports a negative delta
+	clock.Advance(time.Second)
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatalf("Truncate() unexpected error: %v", err)
+	}
+	if err := w.Check(); err != nil || len(reports) != 2 || reports[1].Delta != -Size(4*MiB+1024) {
+		t.Errorf("Check() after truncation = %v, reports %+v, expected a shrink report", err, reports)
+	} else if !strings.HasPrefix(reports[1].String(), "app.log -4.00 MiB (") {
+		t.Errorf("Growth.String() = %q, expected a delta of %q", reports[1].String(), "-4.00 MiB")
+	}
+
+	// a missing file is an error that keeps the last size
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Remove() unexpected error: %v", err)
+	}
+	if err := w.Check(); err == nil || w.Size() != 0 {
+		t.Errorf("Check() of a removed file = %v with size %d, expected an error and size 0", err, int64(w.Size()))
+	}
+}
+
+// TestGrowthWatcher_Run tests polling a directory until cancelled
+func TestGrowthWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	grew := make(chan Growth, 10)
+	w, err := NewGrowthWatcher(dir, func(g Growth) { grew <- g })
+	if err != nil {
+		t.Fatalf("NewGrowthWatcher() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx, 5*time.Millisecond) }()
+
+	appendBytes(t, filepath.Join(dir, "part-0001"), 2048)
+	select {
+	case g := <-grew:
+		if g.Size != 2048 {
+			t.Errorf("Run() reported size %d, expected 2048", int64(g.Size))
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Run() reported nothing after the directory grew")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run() = %v, expected %v", err, context.Canceled)
+	}
+}
+
+// TestGrowthWatcher_RunInvalidInterval tests that a bad interval is an
+// error rather than a panic
+func TestGrowthWatcher_RunInvalidInterval(t *testing.T) {
+	w, err := NewGrowthWatcher(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewGrowthWatcher() unexpected error: %v", err)
+	}
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := w.Run(context.Background(), interval); err == nil {
+			t.Errorf("Run(%v) expected error but got none", interval)
+		}
+	}
+}
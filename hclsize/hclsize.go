@@ -0,0 +1,114 @@
+// Package hclsize accepts human-readable sizes in HCL v2 configurations.
+//
+// gohcl decodes a filesize.Size field as a plain number, so an attribute such
+// as disk_size = "100GiB" needs one extra step. Declare the attribute as an
+// hcl.Expression and decode it with DecodeExpression, which reports parse
+// failures as diagnostics pointing at the offending expression:
+//
+//	type Disk struct {
+//		Size hcl.Expression `hcl:"disk_size"`
+//	}
+//	...
+//	size, diags := hclsize.DecodeExpression(disk.Size, ctx)
+//
+// Both strings ("100GiB") and numbers (byte counts) are accepted. Function
+// additionally exposes the parser to HCL expressions, so configurations can
+// compute with sizes, e.g. filesize("1GiB") * 4.
+package hclsize
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/hcl/v2"
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FromCtyValue converts a cty string or number to a size
+//
+// Strings are parsed like Size.UnmarshalText and numbers must be whole byte
+// counts that fit in an int64. Null and unknown values are errors.
+func FromCtyValue(val cty.Value) (filesize.Size, error) {
+	if val.IsNull() {
+		return 0, fmt.Errorf("size must not be null")
+	}
+	if !val.IsKnown() {
+		return 0, fmt.Errorf("size must be known")
+	}
+
+	switch val.Type() {
+	case cty.String:
+		var size filesize.Size
+		if err := size.UnmarshalText([]byte(val.AsString())); err != nil {
+			return 0, err
+		}
+		return size, nil
+	case cty.Number:
+		bf := val.AsBigFloat()
+		if !bf.IsInt() {
+			return 0, fmt.Errorf("size must be a whole number of bytes, got %s", bf.Text('g', -1))
+		}
+		bytes, accuracy := bf.Int64()
+		if accuracy != big.Exact {
+			return 0, fmt.Errorf("size too large: %s", bf.Text('f', 0))
+		}
+		return filesize.Size(bytes), nil
+	default:
+		return 0, fmt.Errorf("size must be a string or a number, got %s", val.Type().FriendlyName())
+	}
+}
+
+// ToCtyValue converts a size to a cty string in canonical text form, e.g.
+// "100GiB", which FromCtyValue reads back exactly
+func ToCtyValue(size filesize.Size) cty.Value {
+	text, _ := size.MarshalText()
+	return cty.StringVal(string(text))
+}
+
+// DecodeExpression evaluates expr and converts the result to a size
+//
+// Errors are returned as diagnostics whose subject is the expression, so
+// HCL's diagnostic writer can show the user exactly which value is wrong.
+func DecodeExpression(expr hcl.Expression, ctx *hcl.EvalContext) (filesize.Size, hcl.Diagnostics) {
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return 0, diags
+	}
+
+	size, err := FromCtyValue(val)
+	if err != nil {
+		return 0, append(diags, &hcl.Diagnostic{
+			Severity:    hcl.DiagError,
+			Summary:     "Invalid size",
+			Detail:      fmt.Sprintf("A size such as \"512MiB\", \"100GB\" or a number of bytes is required: %s.", err),
+			Subject:     expr.Range().Ptr(),
+			Expression:  expr,
+			EvalContext: ctx,
+		})
+	}
+	return size, diags
+}
+
+// Function is an HCL function converting a size string to a number of bytes
+//
+// Register it in an hcl.EvalContext, usually as "filesize":
+//
+//	ctx := &hcl.EvalContext{Functions: map[string]function.Function{
+//		"filesize": hclsize.Function,
+//	}}
+var Function = function.New(&function.Spec{
+	Description: "Converts a human-readable size such as \"1.5GiB\" to a number of bytes.",
+	Params: []function.Parameter{
+		{Name: "size", Type: cty.String, Description: "The size to convert."},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		size, err := FromCtyValue(args[0])
+		if err != nil {
+			return cty.NilVal, function.NewArgError(0, err)
+		}
+		return cty.NumberIntVal(int64(size)), nil
+	},
+})
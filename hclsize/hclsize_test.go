@@ -0,0 +1,115 @@
+package hclsize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// diskConfig is an HCL block with a size attribute
+type diskConfig struct {
+	Name string         `hcl:"name"`
+	Size hcl.Expression `hcl:"disk_size"`
+}
+
+// evalContext offers the filesize function to expressions
+var evalContext = &hcl.EvalContext{
+	Functions: map[string]function.Function{"filesize": Function},
+}
+
+// decodeDisk parses src and decodes its disk size
+func decodeDisk(t *testing.T, src string) (filesize.Size, hcl.Diagnostics) {
+	t.Helper()
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), "disk.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("ParseConfig(%q) unexpected error: %v", src, diags)
+	}
+
+	var cfg diskConfig
+	if diags := gohcl.DecodeBody(file.Body, evalContext, &cfg); diags.HasErrors() {
+		t.Fatalf("DecodeBody(%q) unexpected error: %v", src, diags)
+	}
+	return DecodeExpression(cfg.Size, evalContext)
+}
+
+// TestDecodeExpression tests decoding size attributes
+func TestDecodeExpression(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected filesize.Size
+		hasError bool
+	}{
+		{`"100GiB"`, filesize.Size(100 * filesize.GiB), false},
+		{`"1.5 TB"`, filesize.Size(1500 * filesize.GB), false},
+		{`4096`, 4096, false},
+		{`filesize("1GiB") * 4`, filesize.Size(4 * filesize.GiB), false},
+		{`"-1k"`, -1024, false},
+		{`"100 gigs"`, 0, true},
+		{`1.5`, 0, true},
+		{`1e30`, 0, true},
+		{`true`, 0, true},
+		{`null`, 0, true},
+		{`filesize("lots")`, 0, true},
+	}
+
+	for _, tc := range testCases {
+		src := "name = \"root\"\ndisk_size = " + tc.input + "\n"
+		size, diags := decodeDisk(t, src)
+		if tc.hasError {
+			if !diags.HasErrors() {
+				t.Errorf("DecodeExpression(%s) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if diags.HasErrors() {
+			t.Errorf("DecodeExpression(%s) unexpected error: %v", tc.input, diags)
+			continue
+		}
+		if size != tc.expected {
+			t.Errorf("DecodeExpression(%s) = %d, expected %d", tc.input, int64(size), int64(tc.expected))
+		}
+	}
+}
+
+// TestDecodeExpression_Diagnostic tests that errors point at the attribute
+func TestDecodeExpression_Diagnostic(t *testing.T) {
+	_, diags := decodeDisk(t, "name = \"root\"\ndisk_size = \"100 gigs\"\n")
+	if len(diags) != 1 {
+		t.Fatalf("DecodeExpression() = %v, expected one diagnostic", diags)
+	}
+
+	diag := diags[0]
+	if diag.Summary != "Invalid size" || diag.Subject == nil || diag.Subject.Start.Line != 2 {
+		t.Errorf("DecodeExpression() diagnostic = %+v, expected an invalid size on line 2", diag)
+	}
+	if !strings.Contains(diag.Detail, "unknown unit: gigs") {
+		t.Errorf("DecodeExpression() detail = %q, expected the parse error", diag.Detail)
+	}
+}
+
+// TestCtyValue tests conversions to and from cty values
+func TestCtyValue(t *testing.T) {
+	sizes := []filesize.Size{0, 1500, filesize.Size(100 * filesize.GiB), -4096}
+
+	for _, size := range sizes {
+		val := ToCtyValue(size)
+		if val.Type() != cty.String {
+			t.Errorf("ToCtyValue(%d) type = %s, expected string", int64(size), val.Type().FriendlyName())
+		}
+		decoded, err := FromCtyValue(val)
+		if err != nil || decoded != size {
+			t.Errorf("FromCtyValue(%#v) = %d, %v, expected %d", val, int64(decoded), err, int64(size))
+		}
+	}
+
+	if _, err := FromCtyValue(cty.UnknownVal(cty.String)); err == nil {
+		t.Errorf("FromCtyValue(unknown) expected error but got none")
+	}
+}
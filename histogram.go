@@ -0,0 +1,106 @@
+package filesize
+
+import (
+	"math"
+	"math/bits"
+)
+
+// histogram bucket layout: one bucket for sizes up to 1 KiB, one for each
+// doubling up to 1 GiB and one for everything larger
+const (
+	histogramFirstShift = 10
+	histogramLastShift  = 30
+	histogramBuckets    = histogramLastShift - histogramFirstShift + 2
+)
+
+// Histogram counts sizes in power-of-two buckets: ≤ 1 KiB, ≤ 2 KiB, ≤ 4 KiB
+// and so on up to ≤ 1 GiB, then > 1 GiB
+//
+// It profiles file-size distributions in scanners and allocators in
+// constant memory. The zero value is an empty histogram ready to use. A
+// Histogram is not safe for concurrent use; give each goroutine its own
+// and Merge them.
+type Histogram struct {
+	counts [histogramBuckets]int64
+}
+
+// HistogramBucket is one bucket of a Histogram, counting sizes greater
+// than Min and at most Max
+//
+// The first bucket also counts zero and negative sizes, with Min set to
+// math.MinInt64, and the last bucket has Max set to math.MaxInt64.
+type HistogramBucket struct {
+	Min, Max Size
+	Count    int64
+}
+
+// Add counts one size
+func (h *Histogram) Add(size int64) {
+	h.counts[histogramIndex(size)]++
+}
+
+// Merge adds the counts of other into h
+func (h *Histogram) Merge(other *Histogram) {
+	for i, n := range other.counts {
+		h.counts[i] += n
+	}
+}
+
+// Count returns the number of sizes added
+func (h *Histogram) Count() int64 {
+	var total int64
+	for _, n := range h.counts {
+		total += n
+	}
+	return total
+}
+
+// Each calls fn for every bucket from smallest to largest, empty ones
+// included, until fn returns false
+func (h *Histogram) Each(fn func(HistogramBucket) bool) {
+	for i, n := range h.counts {
+		if !fn(histogramBucket(i, n)) {
+			return
+		}
+	}
+}
+
+// Buckets returns every bucket from smallest to largest
+func (h *Histogram) Buckets() []HistogramBucket {
+	buckets := make([]HistogramBucket, 0, histogramBuckets)
+	h.Each(func(b HistogramBucket) bool {
+		buckets = append(buckets, b)
+		return true
+	})
+	return buckets
+}
+
+// Label describes the bucket's range, e.g. "≤ 1 KiB", "≤ 64 MiB" or
+// "> 1 GiB"
+func (b HistogramBucket) Label() string {
+	if b.Max == math.MaxInt64 {
+		return "> " + formatExact(int64(b.Min), " ")
+	}
+	return "≤ " + formatExact(int64(b.Max), " ")
+}
+
+// histogramIndex returns the bucket a size belongs in; a size in
+// (2^(k-1), 2^k] needs k bits once one is subtracted
+func histogramIndex(size int64) int {
+	if size <= 1<<histogramFirstShift {
+		return 0
+	}
+	return min(bits.Len64(uint64(size-1))-histogramFirstShift, histogramBuckets-1)
+}
+
+// histogramBucket returns the bounds of bucket i with count n
+func histogramBucket(i int, n int64) HistogramBucket {
+	b := HistogramBucket{Min: math.MinInt64, Max: math.MaxInt64, Count: n}
+	if i > 0 {
+		b.Min = Size(int64(1) << (histogramFirstShift + i - 1))
+	}
+	if i < histogramBuckets-1 {
+		b.Max = Size(int64(1) << (histogramFirstShift + i))
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package filesize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultHistogramWidth is the bar width String uses
+const defaultHistogramWidth = 40
+
+// Render draws the histogram as aligned text, one line per bucket from
+// the first non-empty bucket to the last, with a bar of up to barWidth
+// "#" characters scaled to the fullest bucket, the count and its share:
+//
+//	≤ 1 KiB  ########################################  1200   60.0%
+//	≤ 2 KiB  #################                          500   25.0%
+//	≤ 4 KiB  #######                                    200   10.0%
+//	≤ 8 KiB  ###                                        100    5.0%
+//
+// Non-empty buckets always get at least one "#". An empty histogram
+// renders as "". Bars are plain ASCII so the output survives any terminal
+// or log pipeline.
+func (h *Histogram) Render(barWidth int) string {
+	total := h.Count()
+	if total == 0 {
+		return ""
+	}
+	barWidth = max(barWidth, 1)
+
+	// trim empty buckets at either end and size the columns
+	buckets := h.Buckets()
+	first, last := 0, len(buckets)-1
+	for buckets[first].Count == 0 {
+		first++
+	}
+	for buckets[last].Count == 0 {
+		last--
+	}
+	buckets = buckets[first : last+1]
+
+	var labelWidth, countWidth int
+	var fullest int64
+	for _, b := range buckets {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(b.Label()))
+		countWidth = max(countWidth, len(fmt.Sprint(b.Count)))
+		fullest = max(fullest, b.Count)
+	}
+
+	var sb strings.Builder
+	for _, b := range buckets {
+		bar := 0
+		if b.Count > 0 {
+			bar = max(int((b.Count*int64(barWidth)+fullest/2)/fullest), 1)
+		}
+
+		label := b.Label()
+		sb.WriteString(strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label)))
+		sb.WriteString(label)
+		sb.WriteString("  ")
+		sb.WriteString(strings.Repeat("#", bar))
+		sb.WriteString(strings.Repeat(" ", barWidth-bar))
+		fmt.Fprintf(&sb, "  %*d  %5.1f%%\n", countWidth, b.Count, float64(b.Count)*100/float64(total))
+	}
+	return sb.String()
+}
+
+// String renders the histogram with 40-character bars
+func (h *Histogram) String() string {
+	return h.Render(defaultHistogramWidth)
+}
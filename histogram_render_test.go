@@ -0,0 +1,68 @@
+package filesize
+
+import "testing"
+
+// TestHistogram_Render tests bar scaling, alignment and trimming
+func TestHistogram_Render(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 12; i++ {
+		h.Add(100)
+	}
+	for i := 0; i < 5; i++ {
+		h.Add(1500)
+	}
+	h.Add(50 * MiB)
+	h.Add(3 * GiB)
+
+	result := h.Render(10)
+	lines := splitLines(result)
+	if len(lines) != 22 {
+		t.Fatalf("Render() produced %d lines, expected 22:\n%s", len(lines), result)
+	}
+
+	testCases := []struct {
+		line     int
+		expected string
+	}{
+		{0, "  ≤ 1 KiB  ##########  12   63.2%"},
+		{1, "  ≤ 2 KiB  ####         5   26.3%"},
+		{2, "  ≤ 4 KiB               0    0.0%"},
+		{16, " ≤ 64 MiB  #            1    5.3%"},
+		{21, "  > 1 GiB  #            1    5.3%"},
+	}
+	for _, tc := range testCases {
+		if lines[tc.line] != tc.expected {
+			t.Errorf("Render() line %d = %q, expected %q", tc.line, lines[tc.line], tc.expected)
+		}
+	}
+}
+
+// TestHistogram_RenderTrimmed tests that empty outer buckets are dropped
+func TestHistogram_RenderTrimmed(t *testing.T) {
+	var h Histogram
+	if result := h.String(); result != "" {
+		t.Errorf("String() of an empty histogram = %q, expected \"\"", result)
+	}
+
+	h.Add(3 * KiB)
+	h.Add(5 * KiB)
+	h.Add(6 * KiB)
+	expected := "≤ 4 KiB  ##    1   33.3%\n" +
+		"≤ 8 KiB  ####  2   66.7%\n"
+	if result := h.Render(4); result != expected {
+		t.Errorf("Render(4) = %q, expected %q", result, expected)
+	}
+}
+
+// splitLines splits rendered output into lines without the final newline
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	return lines
+}
@@ -0,0 +1,82 @@
+package filesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHistogram_Add tests which bucket sizes land in
+func TestHistogram_Add(t *testing.T) {
+	testCases := []struct {
+		size   int64
+		bucket int
+		label  string
+	}{
+		{-1, 0, "≤ 1 KiB"},
+		{0, 0, "≤ 1 KiB"},
+		{KiB, 0, "≤ 1 KiB"},
+		{KiB + 1, 1, "≤ 2 KiB"},
+		{2 * KiB, 1, "≤ 2 KiB"},
+		{3 * KiB, 2, "≤ 4 KiB"},
+		{MiB, 10, "≤ 1 MiB"},
+		{GiB, 20, "≤ 1 GiB"},
+		{GiB + 1, 21, "> 1 GiB"},
+		{math.MaxInt64, 21, "> 1 GiB"},
+	}
+
+	for _, tc := range testCases {
+		var h Histogram
+		h.Add(tc.size)
+
+		buckets := h.Buckets()
+		if len(buckets) != 22 {
+			t.Fatalf("len(Buckets()) = %d, expected 22", len(buckets))
+		}
+		b := buckets[tc.bucket]
+		if b.Count != 1 {
+			t.Errorf("Add(%d) counted in the wrong bucket, expected %q", tc.size, tc.label)
+		}
+		if b.Label() != tc.label {
+			t.Errorf("Add(%d) bucket label = %q, expected %q", tc.size, b.Label(), tc.label)
+		}
+		if (tc.size > int64(b.Min) || tc.bucket == 0) && tc.size <= int64(b.Max) {
+			continue
+		}
+		t.Errorf("Add(%d) landed in bucket (%d, %d]", tc.size, int64(b.Min), int64(b.Max))
+	}
+}
+
+// TestHistogram_Merge tests combining histograms and counting
+func TestHistogram_Merge(t *testing.T) {
+	var a, b Histogram
+	a.Add(100)
+	a.Add(5 * MiB)
+	b.Add(200)
+	b.Add(2 * GiB)
+
+	a.Merge(&b)
+	if a.Count() != 4 {
+		t.Errorf("Count() = %d, expected 4", a.Count())
+	}
+
+	buckets := a.Buckets()
+	if buckets[0].Count != 2 || buckets[13].Count != 1 || buckets[21].Count != 1 {
+		t.Errorf("Buckets() after Merge() = %+v", buckets)
+	}
+	if buckets[13].Label() != "≤ 8 MiB" {
+		t.Errorf("Buckets()[13].Label() = %q, expected %q", buckets[13].Label(), "≤ 8 MiB")
+	}
+}
+
+// TestHistogram_Each tests stopping iteration early
+func TestHistogram_Each(t *testing.T) {
+	var h Histogram
+	visited := 0
+	h.Each(func(b HistogramBucket) bool {
+		visited++
+		return b.Max < Size(4*KiB)
+	})
+	if visited != 3 {
+		t.Errorf("Each() visited %d buckets, expected 3", visited)
+	}
+}
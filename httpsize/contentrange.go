@@ -0,0 +1,127 @@
+package httpsize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// ParseContentLength parses a Content-Length header value
+//
+// Only a plain non-negative byte count is valid, as RFC 9110 requires;
+// humanized sizes are rejected since no HTTP peer sends them.
+func ParseContentLength(s string) (filesize.Size, error) {
+	s = strings.TrimSpace(s)
+	n, err := parseByteCount(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Content-Length: %q", s)
+	}
+	return filesize.Size(n), nil
+}
+
+// FormatContentLength formats size as a Content-Length header value
+func FormatContentLength(size filesize.Size) string {
+	return strconv.FormatInt(int64(size), 10)
+}
+
+// ContentRange is a parsed Content-Range header value in bytes
+//
+// A range whose total is unknown ("bytes 0-499/*") has Total -1, and the
+// unsatisfied form sent with 416 responses ("bytes */1000") has Start and
+// End -1.
+type ContentRange struct {
+	// Start is the offset of the first byte sent
+	Start filesize.Size
+
+	// End is the offset of the last byte sent, inclusive
+	End filesize.Size
+
+	// Total is the complete length of the representation, -1 when unknown
+	Total filesize.Size
+}
+
+// ParseContentRange parses a Content-Range header value such as
+// "bytes 0-499/1234", "bytes 500-999/*" or "bytes */1234"
+func ParseContentRange(s string) (ContentRange, error) {
+	invalid := fmt.Errorf("invalid Content-Range: %q", s)
+
+	rangeStr, ok := strings.CutPrefix(strings.TrimSpace(s), "bytes ")
+	if !ok {
+		return ContentRange{}, invalid
+	}
+	span, totalStr, ok := strings.Cut(strings.TrimSpace(rangeStr), "/")
+	if !ok {
+		return ContentRange{}, invalid
+	}
+
+	cr := ContentRange{Start: -1, End: -1, Total: -1}
+	if totalStr != "*" {
+		total, err := parseByteCount(totalStr)
+		if err != nil {
+			return ContentRange{}, invalid
+		}
+		cr.Total = filesize.Size(total)
+	}
+
+	// the unsatisfied form needs a known total
+	if span == "*" {
+		if cr.Total < 0 {
+			return ContentRange{}, invalid
+		}
+		return cr, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(span, "-")
+	if !ok {
+		return ContentRange{}, invalid
+	}
+	start, err := parseByteCount(startStr)
+	if err != nil {
+		return ContentRange{}, invalid
+	}
+	end, err := parseByteCount(endStr)
+	if err != nil || end < start || (cr.Total >= 0 && end >= int64(cr.Total)) {
+		return ContentRange{}, invalid
+	}
+
+	cr.Start, cr.End = filesize.Size(start), filesize.Size(end)
+	return cr, nil
+}
+
+// Length returns the number of bytes in the range, zero for the unsatisfied
+// form
+func (cr ContentRange) Length() filesize.Size {
+	if cr.Start < 0 {
+		return 0
+	}
+	return cr.End - cr.Start + 1
+}
+
+// String formats the range as a Content-Range header value
+func (cr ContentRange) String() string {
+	total := "*"
+	if cr.Total >= 0 {
+		total = strconv.FormatInt(int64(cr.Total), 10)
+	}
+
+	if cr.Start < 0 {
+		return "bytes */" + total
+	}
+	return "bytes " + strconv.FormatInt(int64(cr.Start), 10) + "-" + strconv.FormatInt(int64(cr.End), 10) + "/" + total
+}
+
+// FormatContentRange formats the inclusive byte range start-end of a
+// representation of total bytes, a negative total meaning unknown
+func FormatContentRange(start, end, total int64) string {
+	return ContentRange{Start: filesize.Size(start), End: filesize.Size(end), Total: filesize.Size(max(total, -1))}.String()
+}
+
+// parseByteCount parses a non-empty run of ASCII digits
+func parseByteCount(s string) (int64, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid byte count: %q", s)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
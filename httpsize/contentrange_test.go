@@ -0,0 +1,92 @@
+package httpsize
+
+import (
+	"testing"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// TestParseContentLength tests strict Content-Length parsing
+func TestParseContentLength(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected filesize.Size
+		hasError bool
+	}{
+		{"0", 0, false},
+		{"1048576", filesize.Size(filesize.MiB), false},
+		{" 42 ", 42, false},
+		{"-1", 0, true},
+		{"+5", 0, true},
+		{"1k", 0, true},
+		{"", 0, true},
+		{"99999999999999999999", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseContentLength(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseContentLength(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseContentLength(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("ParseContentLength(%q) = %d, expected %d", tc.input, int64(result), int64(tc.expected))
+		}
+	}
+
+	if result := FormatContentLength(filesize.Size(filesize.KiB)); result != "1024" {
+		t.Errorf("FormatContentLength(1 KiB) = %q, expected %q", result, "1024")
+	}
+}
+
+// TestParseContentRange tests parsing and formatting Content-Range values
+func TestParseContentRange(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected ContentRange
+		length   filesize.Size
+		hasError bool
+	}{
+		{"bytes 0-499/1234", ContentRange{0, 499, 1234}, 500, false},
+		{"bytes 500-999/*", ContentRange{500, 999, -1}, 500, false},
+		{"bytes */1234", ContentRange{-1, -1, 1234}, 0, false},
+		{"bytes 1233-1233/1234", ContentRange{1233, 1233, 1234}, 1, false},
+		{"bytes 0-1234/1234", ContentRange{}, 0, true},
+		{"bytes 500-499/1234", ContentRange{}, 0, true},
+		{"bytes */*", ContentRange{}, 0, true},
+		{"bytes 0-499", ContentRange{}, 0, true},
+		{"items 0-4/10", ContentRange{}, 0, true},
+		{"bytes -5-10/20", ContentRange{}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseContentRange(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseContentRange(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseContentRange(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected || result.Length() != tc.length {
+			t.Errorf("ParseContentRange(%q) = %+v (length %d), expected %+v (length %d)",
+				tc.input, result, int64(result.Length()), tc.expected, int64(tc.length))
+		}
+		if result.String() != tc.input {
+			t.Errorf("ParseContentRange(%q).String() = %q, expected the input back", tc.input, result.String())
+		}
+	}
+
+	if result := FormatContentRange(0, filesize.MiB-1, -5); result != "bytes 0-1048575/*" {
+		t.Errorf("FormatContentRange(0, 1 MiB-1, unknown) = %q, expected %q", result, "bytes 0-1048575/*")
+	}
+}
@@ -0,0 +1,111 @@
+// Package httpsize enforces human-configured size limits on HTTP servers.
+//
+// The middleware caps request bodies at a limit written the way operators
+// write it in configuration:
+//
+//	limit, err := httpsize.Middleware("10MiB")
+//	if err != nil {
+//		return err
+//	}
+//	http.ListenAndServe(addr, limit(mux))
+//
+// Requests that announce a larger Content-Length are answered with 413
+// Request Entity Too Large and a humanized message such as "request exceeds
+// 10.0 MiB limit" before the handler runs. Bodies without a length are
+// wrapped with http.MaxBytesReader, and handlers pass the resulting read
+// error to HandleError to send the same response.
+//
+// ParseContentLength and ParseContentRange read the size-carrying headers
+// of responses into filesize.Size values for download managers and range
+// servers, and their Format counterparts write them.
+//
+// MemStatsHandler serves the process's memory statistics with humanized
+// sizes for debug endpoints.
+package httpsize
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// Middleware returns middleware limiting request bodies to limit, a size
+// string such as "10MiB"
+func Middleware(limit string) (func(http.Handler) http.Handler, error) {
+	n, err := filesize.ParseSize(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return LimitBody(next, n)
+	}, nil
+}
+
+// LimitBody returns a handler that serves requests with next after limiting
+// their bodies to limit bytes
+func LimitBody(next http.Handler, limit int64) http.Handler {
+	return LimitBodyFunc(next, func(*http.Request) int64 {
+		return limit
+	})
+}
+
+// LimitBodyFunc is like LimitBody but asks limit for each request's limit,
+// so uploads can be allowed more than API calls; a negative limit disables
+// the check for that request
+func LimitBodyFunc(next http.Handler, limit func(*http.Request) int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := limit(r)
+		if n < 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// reject announced oversized bodies without reading them
+		if r.ContentLength > n {
+			WriteTooLarge(w, n)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, n)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteTooLarge replies with 413 Request Entity Too Large and a message
+// naming the limit, e.g. "request exceeds 10.0 MiB limit"
+func WriteTooLarge(w http.ResponseWriter, limit int64) {
+	http.Error(w, "request exceeds "+filesize.Size(limit).String()+" limit", http.StatusRequestEntityTooLarge)
+}
+
+// HandleError replies with WriteTooLarge and returns true if err came from
+// reading a body past its limit, and returns false otherwise
+func HandleError(w http.ResponseWriter, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return false
+	}
+
+	WriteTooLarge(w, tooLarge.Limit)
+	return true
+}
+
+// HeaderSize reads a size such as "10MiB" from header key of h
+//
+// It reports false when the header is absent or blank, which lets a proxy or
+// gateway pass per-route limits to the service through a header such as
+// "X-Max-Body-Size".
+func HeaderSize(h http.Header, key string) (filesize.Size, bool, error) {
+	value := strings.TrimSpace(h.Get(key))
+	if value == "" {
+		return 0, false, nil
+	}
+
+	n, err := filesize.ParseSize(value)
+	if err != nil {
+		return 0, true, err
+	}
+	return filesize.Size(n), true, nil
+}
@@ -0,0 +1,113 @@
+package httpsize
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// echoLength replies with the number of body bytes it could read
+var echoLength = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if HandleError(w, err) {
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	io.WriteString(w, filesize.Size(len(body)).String())
+})
+
+// TestMiddleware tests bodies under and over the limit
+func TestMiddleware(t *testing.T) {
+	limit, err := Middleware("1KiB")
+	if err != nil {
+		t.Fatalf("Middleware(1KiB) unexpected error: %v", err)
+	}
+	handler := limit(echoLength)
+
+	testCases := []struct {
+		size          int
+		unknownLength bool
+		status        int
+		body          string
+	}{
+		{512, false, http.StatusOK, "512 B"},
+		{1024, true, http.StatusOK, "1.00 KiB"},
+		{2048, false, http.StatusRequestEntityTooLarge, "request exceeds 1.00 KiB limit\n"},
+		{2048, true, http.StatusRequestEntityTooLarge, "request exceeds 1.00 KiB limit\n"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", tc.size)))
+		if tc.unknownLength {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status || rec.Body.String() != tc.body {
+			t.Errorf("POST %d bytes (unknown length %v) = %d %q, expected %d %q",
+				tc.size, tc.unknownLength, rec.Code, rec.Body.String(), tc.status, tc.body)
+		}
+	}
+
+	if _, err := Middleware("big"); err == nil {
+		t.Errorf("Middleware(\"big\") expected error but got none")
+	}
+}
+
+// TestLimitBodyFunc tests per-request limits read from a header
+func TestLimitBodyFunc(t *testing.T) {
+	handler := LimitBodyFunc(echoLength, func(r *http.Request) int64 {
+		if size, ok, err := HeaderSize(r.Header, "X-Max-Body-Size"); ok && err == nil {
+			return int64(size)
+		}
+		return -1
+	})
+
+	testCases := []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"4k", http.StatusOK},
+		{"1k", http.StatusRequestEntityTooLarge},
+		{"huge", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 2000)))
+		if tc.header != "" {
+			req.Header.Set("X-Max-Body-Size", tc.header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("POST with X-Max-Body-Size %q = %d, expected %d", tc.header, rec.Code, tc.status)
+		}
+	}
+}
+
+// TestHeaderSize tests reading sizes from headers
+func TestHeaderSize(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Limit", " 10MiB ")
+	h.Set("X-Bad", "10 parsecs")
+
+	if size, ok, err := HeaderSize(h, "X-Limit"); size != filesize.Size(10*filesize.MiB) || !ok || err != nil {
+		t.Errorf("HeaderSize(X-Limit) = %d, %v, %v, expected 10 MiB", int64(size), ok, err)
+	}
+	if _, ok, err := HeaderSize(h, "X-Missing"); ok || err != nil {
+		t.Errorf("HeaderSize(X-Missing) = %v, %v, expected not found", ok, err)
+	}
+	if _, ok, err := HeaderSize(h, "X-Bad"); !ok || err == nil {
+		t.Errorf("HeaderSize(X-Bad) = %v, %v, expected a parse error", ok, err)
+	}
+}
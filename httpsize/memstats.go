@@ -0,0 +1,48 @@
+package httpsize
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// MemStatsHandler returns a debug handler reporting the process's memory
+// statistics with humanized sizes
+//
+// Each request reads fresh statistics. The response is aligned text such
+// as "HeapAlloc     512 MiB", or a JSON object of filesize.MemStatsMap when
+// the client accepts application/json. Mount it next to net/http/pprof:
+//
+//	mux.Handle("/debug/memstats", httpsize.MemStatsHandler())
+func MemStatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+
+		w.Header().Set("Cache-Control", "no-store")
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(filesize.MemStatsMap(&m))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, line := range filesize.MemStatsLines(&m) {
+			_, _ = w.Write([]byte(line + "\n"))
+		}
+	})
+}
+
+// MemStatsVar reads the current memory statistics as a
+// filesize.MemStatsMap, matching expvar.Func so the humanized figures can
+// be published alongside expvar's raw "memstats":
+//
+//	expvar.Publish("memstats_human", expvar.Func(httpsize.MemStatsVar))
+func MemStatsVar() any {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return filesize.MemStatsMap(&m)
+}
@@ -0,0 +1,48 @@
+package httpsize
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMemStatsHandler tests the text and JSON debug responses
+func TestMemStatsHandler(t *testing.T) {
+	handler := MemStatsHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/memstats", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if !strings.HasPrefix(rec.Body.String(), "HeapAlloc  ") {
+		t.Errorf("text body = %q, expected it to start with HeapAlloc", rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "iB\n") {
+		t.Errorf("text body = %q, expected humanized sizes", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/debug/memstats", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var fields map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(fields["Sys"], "B") {
+		t.Errorf("Sys = %q, expected a humanized size", fields["Sys"])
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, expected application/json", ct)
+	}
+}
+
+// TestMemStatsVar tests the expvar-compatible function
+func TestMemStatsVar(t *testing.T) {
+	fields, ok := MemStatsVar().(map[string]string)
+	if !ok || fields["HeapAlloc"] == "" {
+		t.Errorf("MemStatsVar() = %v, expected a map with HeapAlloc", fields)
+	}
+}
@@ -0,0 +1,113 @@
+package filesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// common huge page sizes on x86-64 and arm64 with 4 KiB base pages
+const (
+	HugePage2M int64 = 2 * MiB
+	HugePage1G int64 = GiB
+)
+
+// hugePageSuffixes are the unit endings ParseHugePages recognizes, longest
+// first
+var hugePageSuffixes = []string{"-hugepages", "-hugepage"}
+
+// ParseHugePages parses a size that may be written as a number of huge
+// pages, such as "512 2M-hugepages" or "4 1G-hugepages"
+//
+// The page size before "-hugepages" is any size ParseSize accepts that is a
+// power of two, so "2MiB-hugepages" works too, and the count must be a
+// whole number. Anything else is parsed with ParseSize, so "1GiB" and
+// "512 2M-hugepages" both yield 1 GiB.
+func ParseHugePages(s string) (Size, error) {
+	count, pageSize, ok, err := splitHugePages(s)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		bytes, err := ParseSize(s)
+		return Size(bytes), err
+	}
+
+	if count > (1<<63-1)/pageSize {
+		return 0, fmt.Errorf("huge pages %q: %w", strings.TrimSpace(s), ErrOverflow)
+	}
+	return Size(count * pageSize), nil
+}
+
+// splitHugePages splits "<count> <size>-hugepages" into the count and page
+// size, reporting false when s is not written in huge pages
+func splitHugePages(s string) (count, pageSize int64, ok bool, err error) {
+	trimmed := strings.TrimSpace(s)
+	lower := strings.ToLower(trimmed)
+
+	var unit string
+	for _, suffix := range hugePageSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			unit = trimmed[:len(trimmed)-len(suffix)]
+			break
+		}
+	}
+	if unit == "" {
+		return 0, 0, false, nil
+	}
+
+	fields := strings.Fields(unit)
+	if len(fields) != 2 {
+		return 0, 0, false, fmt.Errorf("invalid huge page count: %q", trimmed)
+	}
+	count, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || count < 0 {
+		return 0, 0, false, fmt.Errorf("invalid huge page count: %q", trimmed)
+	}
+	pageSize, err = ParseSize(fields[1])
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("invalid huge page size: %w", err)
+	}
+	if !IsPowerOfTwo(pageSize) {
+		return 0, 0, false, fmt.Errorf("invalid huge page size: %s", fields[1])
+	}
+	return count, pageSize, true, nil
+}
+
+// HugePagesFor returns how many huge pages of pageSize bytes are needed to
+// back sizeStr, rounding up, e.g. HugePagesFor("1.5GiB", HugePage1G) is 2
+//
+// sizeStr may be anything ParseHugePages accepts, so sizes written in one
+// huge page size can be converted to another.
+func HugePagesFor(sizeStr string, pageSize int64) (int64, error) {
+	size, err := ParseHugePages(sizeStr)
+	if err != nil {
+		return 0, err
+	}
+	return PageCount(int64(size), pageSize)
+}
+
+// FormatHugePages formats a number of huge pages in the form
+// ParseHugePages reads, e.g. FormatHugePages(512, HugePage2M) is
+// "512 2M-hugepages"
+//
+// The page size is written with the largest short unit that divides it.
+func FormatHugePages(count, pageSize int64) string {
+	return strconv.FormatInt(count, 10) + " " + shortSize(pageSize) + "-hugepages"
+}
+
+// shortSize formats bytes with the largest exact single-letter binary
+// unit, such as "2M" or "1G", falling back to a plain byte count
+func shortSize(bytes int64) string {
+	for _, unit := range []struct {
+		letter     string
+		multiplier int64
+	}{
+		{"E", EiB}, {"P", PiB}, {"T", TiB}, {"G", GiB}, {"M", MiB}, {"K", KiB},
+	} {
+		if bytes != 0 && bytes%unit.multiplier == 0 {
+			return strconv.FormatInt(bytes/unit.multiplier, 10) + unit.letter
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
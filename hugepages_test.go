@@ -0,0 +1,114 @@
+package filesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseHugePages tests sizes written as huge page counts
+func TestParseHugePages(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{"512 2M-hugepages", 512 * 2 * MiB, false},
+		{"4 1G-hugepages", 4 * GiB, false},
+		{"1 1G-hugepage", GiB, false},
+		{" 16  2MiB-HugePages ", 32 * MiB, false},
+		{"0 2M-hugepages", 0, false},
+		{"1GiB", GiB, false},
+		{"4k", 4 * KiB, false},
+
+		// invalid huge page counts
+		{"-hugepages", 0, true},
+		{"2M-hugepages", 0, true},
+		{"1.5 1G-hugepages", 0, true},
+		{"-1 2M-hugepages", 0, true},
+		{"4 2MB-hugepages", 0, true},
+		{"4 xyz-hugepages", 0, true},
+		{"1 2 2M-hugepages", 0, true},
+		{"9000000000 1G-hugepages", 0, true},
+		{"1xy", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseHugePages(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseHugePages(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseHugePages(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("ParseHugePages(%q) = %d, expected %d", tc.input, int64(result), tc.expected)
+		}
+	}
+
+	if _, err := ParseHugePages("9000000000 1G-hugepages"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseHugePages() overflow error = %v, expected ErrOverflow", err)
+	}
+}
+
+// TestHugePagesFor tests rounding sizes up to whole huge pages
+func TestHugePagesFor(t *testing.T) {
+	testCases := []struct {
+		input    string
+		pageSize int64
+		expected int64
+		hasError bool
+	}{
+		{"1GiB", HugePage2M, 512, false},
+		{"1.5GiB", HugePage1G, 2, false},
+		{"1", HugePage2M, 1, false},
+		{"0", HugePage1G, 0, false},
+		{"512 2M-hugepages", HugePage1G, 1, false},
+		{"3 1G-hugepages", HugePage2M, 1536, false},
+		{"1GiB", 3 * MiB, 0, true},
+		{"lots", HugePage2M, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := HugePagesFor(tc.input, tc.pageSize)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("HugePagesFor(%q, %d) expected error but got none", tc.input, tc.pageSize)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("HugePagesFor(%q, %d) unexpected error: %v", tc.input, tc.pageSize, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("HugePagesFor(%q, %d) = %d, expected %d", tc.input, tc.pageSize, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatHugePages tests that formatted counts parse back
+func TestFormatHugePages(t *testing.T) {
+	testCases := []struct {
+		count, pageSize int64
+		expected        string
+	}{
+		{512, HugePage2M, "512 2M-hugepages"},
+		{4, HugePage1G, "4 1G-hugepages"},
+		{8, 16 * KiB, "8 16K-hugepages"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatHugePages(tc.count, tc.pageSize)
+		if result != tc.expected {
+			t.Errorf("FormatHugePages(%d, %d) = %q, expected %q", tc.count, tc.pageSize, result, tc.expected)
+			continue
+		}
+		if size, err := ParseHugePages(result); err != nil || int64(size) != tc.count*tc.pageSize {
+			t.Errorf("ParseHugePages(%q) = %d, %v, expected %d", result, int64(size), err, tc.count*tc.pageSize)
+		}
+	}
+}
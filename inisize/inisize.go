@@ -0,0 +1,126 @@
+// Package inisize reads human-readable sizes from INI files loaded with
+// go-ini (gopkg.in/ini.v1).
+//
+// go-ini reads a filesize.Size field as a plain integer, so values such as
+// "cache_size = 512MiB" fail to map. The helpers here parse such keys
+// directly, and DecodeSection fills the Size fields MapTo cannot:
+//
+//	cfg, _ := ini.Load("app.ini")
+//	size, err := inisize.SectionSize(cfg, "cache", "max_size", 64*filesize.MiB)
+//
+// Keys missing from a section are looked up in its parent sections, as
+// go-ini does, and then in the DEFAULT section, so shared defaults can be
+// declared once at the top of the file.
+package inisize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"gopkg.in/ini.v1"
+)
+
+// sizeType is the reflect type of filesize.Size
+var sizeType = reflect.TypeOf(filesize.Size(0))
+
+// Key parses the value of key as a size
+func Key(key *ini.Key) (filesize.Size, error) {
+	var size filesize.Size
+	if err := size.UnmarshalText([]byte(key.String())); err != nil {
+		return 0, fmt.Errorf("key %q: %w", key.Name(), err)
+	}
+	return size, nil
+}
+
+// MustKey parses the value of key as a size, like go-ini's Must methods
+//
+// When parsing fails and a default is given, the key is set to the default
+// and the default is returned; without a default the error is swallowed and
+// zero is returned.
+func MustKey(key *ini.Key, defaultVal ...int64) filesize.Size {
+	size, err := Key(key)
+	if err != nil && len(defaultVal) > 0 {
+		size = filesize.Size(defaultVal[0])
+		text, _ := size.MarshalText()
+		key.SetValue(string(text))
+	}
+	return size
+}
+
+// SectionSize reads the named key of a section of file as a size
+//
+// The key is looked up in the section, its parent sections and finally the
+// DEFAULT section. When it is found nowhere, def is returned. A key that
+// exists but does not parse is an error.
+func SectionSize(file *ini.File, section, name string, def int64) (filesize.Size, error) {
+	key := lookupKey(file, file.Section(section), name)
+	if key == nil {
+		return filesize.Size(def), nil
+	}
+	return Key(key)
+}
+
+// DecodeSection maps a section of file onto the struct pointed to by v with
+// go-ini's MapTo, then fills every filesize.Size field from its key
+//
+// Size fields are matched by their ini tag name or, without one, their field
+// name; go-ini's NameMapper is not applied. Fields whose key is missing from
+// the section, its parents and DEFAULT keep their current value, so defaults
+// can be set on v beforehand.
+func DecodeSection(file *ini.File, section string, v any) error {
+	sec := file.Section(section)
+
+	// MapTo skips size fields it cannot parse unless the mapping is strict
+	if err := sec.MapTo(v); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DecodeSection: expected a pointer to a struct, got %T", v)
+	}
+	value = value.Elem()
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Type != sizeType || !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("ini"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		key := lookupKey(file, sec, name)
+		if key == nil {
+			continue
+		}
+		size, err := Key(key)
+		if err != nil {
+			return fmt.Errorf("section %q: %w", sec.Name(), err)
+		}
+		value.Field(i).SetInt(int64(size))
+	}
+	return nil
+}
+
+// lookupKey finds a key in sec, its parents or the DEFAULT section of file
+func lookupKey(file *ini.File, sec *ini.Section, name string) *ini.Key {
+	// GetKey already walks parent sections
+	if key, err := sec.GetKey(name); err == nil {
+		return key
+	}
+
+	key, err := file.Section(ini.DefaultSection).GetKey(name)
+	if err != nil {
+		return nil
+	}
+	return key
+}
@@ -0,0 +1,122 @@
+package inisize
+
+import (
+	"testing"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"gopkg.in/ini.v1"
+)
+
+// testINI has a DEFAULT section, a parent-child pair and a bad value
+const testINI = `
+max_upload = 10MiB
+block_size = 4k
+
+[cache]
+max_size = 512MiB
+
+[cache.disk]
+max_size = 20GiB
+
+[broken]
+max_size = lots
+`
+
+// loadTestINI parses testINI
+func loadTestINI(t *testing.T) *ini.File {
+	t.Helper()
+
+	file, err := ini.Load([]byte(testINI))
+	if err != nil {
+		t.Fatalf("ini.Load() unexpected error: %v", err)
+	}
+	return file
+}
+
+// TestSectionSize tests lookups with parent and DEFAULT fallbacks
+func TestSectionSize(t *testing.T) {
+	file := loadTestINI(t)
+
+	testCases := []struct {
+		section  string
+		name     string
+		expected filesize.Size
+		hasError bool
+	}{
+		{"cache", "max_size", filesize.Size(512 * filesize.MiB), false},
+		{"cache.disk", "max_size", filesize.Size(20 * filesize.GiB), false},
+		{"cache", "max_upload", filesize.Size(10 * filesize.MiB), false},
+		{"cache.disk", "block_size", 4096, false},
+		{"cache", "missing", filesize.Size(filesize.KiB), false},
+		{"", "max_upload", filesize.Size(10 * filesize.MiB), false},
+		{"broken", "max_size", 0, true},
+	}
+
+	for _, tc := range testCases {
+		size, err := SectionSize(file, tc.section, tc.name, filesize.KiB)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("SectionSize(%q, %q) expected error but got none", tc.section, tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SectionSize(%q, %q) unexpected error: %v", tc.section, tc.name, err)
+			continue
+		}
+		if size != tc.expected {
+			t.Errorf("SectionSize(%q, %q) = %d, expected %d", tc.section, tc.name, int64(size), int64(tc.expected))
+		}
+	}
+}
+
+// TestMustKey tests the default fallback of MustKey
+func TestMustKey(t *testing.T) {
+	file := loadTestINI(t)
+
+	if size := MustKey(file.Section("cache").Key("max_size")); size != filesize.Size(512*filesize.MiB) {
+		t.Errorf("MustKey(max_size) = %d, expected %d", int64(size), 512*filesize.MiB)
+	}
+
+	key := file.Section("broken").Key("max_size")
+	if size := MustKey(key, 64*filesize.MiB); size != filesize.Size(64*filesize.MiB) {
+		t.Errorf("MustKey(lots, 64MiB) = %d, expected %d", int64(size), 64*filesize.MiB)
+	}
+	if key.String() != "64MiB" {
+		t.Errorf("MustKey(lots, 64MiB) left key = %q, expected %q", key.String(), "64MiB")
+	}
+}
+
+// TestDecodeSection tests filling size fields of a struct
+func TestDecodeSection(t *testing.T) {
+	type cacheConfig struct {
+		MaxSize   filesize.Size `ini:"max_size"`
+		MaxUpload filesize.Size `ini:"max_upload"`
+		BlockSize filesize.Size `ini:"block_size"`
+		Spill     filesize.Size `ini:"spill"`
+		Name      string        `ini:"name"`
+	}
+
+	file := loadTestINI(t)
+	file.Section("cache").Key("name").SetValue("hot")
+
+	cfg := cacheConfig{Spill: filesize.Size(filesize.GiB)}
+	if err := DecodeSection(file, "cache", &cfg); err != nil {
+		t.Fatalf("DecodeSection() unexpected error: %v", err)
+	}
+
+	expected := cacheConfig{
+		MaxSize:   filesize.Size(512 * filesize.MiB),
+		MaxUpload: filesize.Size(10 * filesize.MiB),
+		BlockSize: 4096,
+		Spill:     filesize.Size(filesize.GiB),
+		Name:      "hot",
+	}
+	if cfg != expected {
+		t.Errorf("DecodeSection() = %+v, expected %+v", cfg, expected)
+	}
+
+	if err := DecodeSection(file, "broken", &cfg); err == nil {
+		t.Errorf("DecodeSection(broken) expected error but got none")
+	}
+}
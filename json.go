@@ -0,0 +1,133 @@
+package filesize
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MarshalJSON implements json.Marshaler
+//
+// Sizes are written as a number of bytes by default, which any JSON consumer
+// understands. After SetDefaultFormat(WithJSONString()) they are written as
+// canonical strings such as "1.5GiB" instead.
+func (s Size) MarshalJSON() ([]byte, error) {
+	cfg := defaultFormatConfig()
+	return cfg.appendJSON(nil, int64(s)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+//
+// Both representations are accepted regardless of the output setting: a
+// number is taken as a byte count and a string is parsed like UnmarshalText,
+// so request bodies may contain either 4096 or "4k". A JSON null leaves the
+// size unchanged.
+func (s *Size) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	// strings carry a human-readable size
+	if len(data) > 0 && data[0] == '"' {
+		str, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("invalid JSON size: %s", data)
+		}
+		return s.UnmarshalText([]byte(str))
+	}
+
+	bytes, err := parseJSONNumber(string(data))
+	if err != nil {
+		return err
+	}
+
+	*s = Size(bytes)
+	return nil
+}
+
+// AppendJSON appends the JSON encoding of bytes to dst using the formatter's
+// WithJSONString setting
+//
+// This lets encoders that bypass encoding/json render sizes the same way a
+// Size field would with the formatter's options as the package default.
+func (f Formatter) AppendJSON(dst []byte, bytes int64) []byte {
+	cfg := f.config()
+	return cfg.appendJSON(dst, bytes)
+}
+
+// appendJSON appends bytes as a JSON number or string per the configuration
+func (cfg formatConfig) appendJSON(dst []byte, bytes int64) []byte {
+	if !cfg.jsonString {
+		return strconv.AppendInt(dst, bytes, 10)
+	}
+
+	// the canonical form contains only letters, digits, "." and "-", so it
+	// never needs escaping
+	dst = append(dst, '"')
+	dst = append(dst, formatExact(bytes, "")...)
+	return append(dst, '"')
+}
+
+// MarshalJSON implements json.Marshaler
+//
+// Rates are always written as canonical strings such as "10MiB/s", since a
+// bare number would lose the period.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	text, _ := r.MarshalText()
+	dst := make([]byte, 0, len(text)+2)
+	dst = append(dst, '"')
+	dst = append(dst, text...)
+	return append(dst, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+//
+// A string is parsed like UnmarshalText and a number is taken as bytes per
+// second, so both "5MiB/s" and 5242880 are accepted. A JSON null leaves the
+// rate unchanged.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		str, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("invalid JSON rate: %s", data)
+		}
+		return r.UnmarshalText([]byte(str))
+	}
+
+	bytes, err := parseJSONNumber(string(data))
+	if err != nil {
+		return err
+	}
+
+	*r = PerSecond(bytes)
+	return nil
+}
+
+// parseJSONNumber converts a JSON number to a whole byte count
+//
+// Exponent and fraction forms such as 1e9 are accepted as long as they denote
+// an integer that fits in an int64.
+func parseJSONNumber(number string) (int64, error) {
+	if bytes, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return bytes, nil
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid JSON size: %s", number)
+	}
+	if value != math.Trunc(value) {
+		return 0, fmt.Errorf("size is not a whole number of bytes: %s", number)
+	}
+	if value >= math.MaxInt64 || value < math.MinInt64 {
+		return 0, fmt.Errorf("size too large: %s", number)
+	}
+	return int64(value), nil
+}
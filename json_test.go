@@ -0,0 +1,171 @@
+package filesize
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSize_MarshalJSON tests numeric and string JSON output
+func TestSize_MarshalJSON(t *testing.T) {
+	defer SetDefaultFormat()
+
+	type limits struct {
+		Max Size `json:"max"`
+	}
+
+	testCases := []struct {
+		opts     []FormatOption
+		input    Size
+		expected string
+	}{
+		{nil, Size(GiB * 3 / 2), `{"max":1610612736}`},
+		{nil, -1024, `{"max":-1024}`},
+		{[]FormatOption{WithJSONString()}, Size(GiB * 3 / 2), `{"max":"1.5GiB"}`},
+		{[]FormatOption{WithJSONString()}, 1500, `{"max":"1500B"}`},
+	}
+
+	for _, tc := range testCases {
+		SetDefaultFormat(tc.opts...)
+		data, err := json.Marshal(limits{Max: tc.input})
+		if err != nil {
+			t.Errorf("json.Marshal(%d) unexpected error: %v", int64(tc.input), err)
+			continue
+		}
+		if string(data) != tc.expected {
+			t.Errorf("json.Marshal(%d) = %s, expected %s", int64(tc.input), data, tc.expected)
+		}
+	}
+}
+
+// TestSize_UnmarshalJSON tests that numbers and strings both decode
+func TestSize_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Size
+		hasError bool
+	}{
+		{`4096`, 4096, false},
+		{`"4k"`, 4096, false},
+		{`"1.5 GiB"`, Size(GiB * 3 / 2), false},
+		{`1e3`, 1000, false},
+		{`-512`, -512, false},
+		{`"-1k"`, -1024, false},
+		{`null`, 7, false},
+		{`1.5`, 0, true},
+		{`1e30`, 0, true},
+		{`"1xy"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tc := range testCases {
+		s := Size(7)
+		err := json.Unmarshal([]byte(tc.input), &s)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("json.Unmarshal(%s) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("json.Unmarshal(%s) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if s != tc.expected {
+			t.Errorf("json.Unmarshal(%s) = %d, expected %d", tc.input, int64(s), int64(tc.expected))
+		}
+	}
+}
+
+// TestFormatter_AppendJSON tests formatter-scoped JSON output
+func TestFormatter_AppendJSON(t *testing.T) {
+	if result := string(NewFormatter().AppendJSON(nil, MiB)); result != "1048576" {
+		t.Errorf("AppendJSON(1 MiB) = %s, expected %s", result, "1048576")
+	}
+	if result := string(NewFormatter(WithJSONString()).AppendJSON(nil, MiB)); result != `"1MiB"` {
+		t.Errorf("AppendJSON(1 MiB) = %s, expected %s", result, `"1MiB"`)
+	}
+}
+
+// TestRate_JSON tests that rates round-trip through JSON as strings
+func TestRate_JSON(t *testing.T) {
+	type limits struct {
+		Upload   Rate `json:"upload"`
+		Transfer Rate `json:"transfer"`
+	}
+
+	data, err := json.Marshal(limits{PerSecond(5 * MiB), Rate{Bytes: Size(100 * GiB), Per: 730 * time.Hour}})
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+	expected := `{"upload":"5MiB/s","transfer":"100GiB/month"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, expected %s", data, expected)
+	}
+
+	var decoded limits
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(%s) unexpected error: %v", data, err)
+	}
+	if decoded != (limits{PerSecond(5 * MiB), Rate{Bytes: Size(100 * GiB), Per: 730 * time.Hour}}) {
+		t.Errorf("json.Unmarshal(%s) = %+v, expected the marshaled values", data, decoded)
+	}
+}
+
+// TestRate_UnmarshalJSON tests the accepted JSON representations
+func TestRate_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Rate
+		hasError bool
+	}{
+		{`"10MB/s"`, PerSecond(10 * MB), false},
+		{`"1 GiB/day"`, Rate{Bytes: Size(GiB), Per: 24 * time.Hour}, false},
+		{`"-1KiB/s"`, PerSecond(-KiB), false},
+		{`1048576`, PerSecond(MiB), false},
+		{`null`, PerSecond(7), false},
+		{`"10MB"`, Rate{}, true},
+		{`1.5`, Rate{}, true},
+		{`[]`, Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		r := PerSecond(7)
+		err := json.Unmarshal([]byte(tc.input), &r)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("json.Unmarshal(%s) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("json.Unmarshal(%s) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if r != tc.expected {
+			t.Errorf("json.Unmarshal(%s) = %+v, expected %+v", tc.input, r, tc.expected)
+		}
+	}
+}
+
+// TestSize_JSONStringRoundTrip tests that string mode preserves every bit of
+// large sizes
+func TestSize_JSONStringRoundTrip(t *testing.T) {
+	defer SetDefaultFormat()
+	SetDefaultFormat(WithJSONString())
+
+	sizes := []Size{9007199254740993, 1<<62 + 512, math.MaxInt64, math.MinInt64}
+	for _, size := range sizes {
+		data, err := json.Marshal(size)
+		if err != nil {
+			t.Errorf("json.Marshal(%d) unexpected error: %v", int64(size), err)
+			continue
+		}
+
+		var decoded Size
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != size {
+			t.Errorf("json.Unmarshal(%s) = %d, %v, expected %d", data, int64(decoded), err, int64(size))
+		}
+	}
+}
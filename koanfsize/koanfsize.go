@@ -0,0 +1,77 @@
+// Package koanfsize connects filesize.Size to knadh/koanf configuration.
+//
+// koanf's default Unmarshal already decodes Size fields: strings from YAML,
+// TOML or environment layers go through Size.UnmarshalText and numbers are
+// taken as byte counts. This package covers services that pass their own
+// mapstructure.DecoderConfig to UnmarshalWithConf, which replaces koanf's
+// default hooks:
+//
+//	err := k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{
+//		DecoderConfig: koanfsize.DecoderConfig(&cfg),
+//	})
+//
+// The hook is also stricter than weak typing about fractional numbers, which
+// would otherwise be truncated silently.
+package koanfsize
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/go-viper/mapstructure/v2"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// sizeType is the reflect type of filesize.Size
+var sizeType = reflect.TypeOf(filesize.Size(0))
+
+// DecodeHook returns a mapstructure hook that decodes strings and numbers
+// into filesize.Size fields
+//
+// Strings are parsed like Size.UnmarshalText. Floating-point numbers, as
+// produced by JSON parsers, must be whole byte counts. Other targets are
+// left untouched, so the hook composes with any others.
+func DecodeHook() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if to != sizeType {
+			return data, nil
+		}
+
+		switch v := data.(type) {
+		case string:
+			var size filesize.Size
+			if err := size.UnmarshalText([]byte(v)); err != nil {
+				return nil, err
+			}
+			return size, nil
+		case float64:
+			if v != math.Trunc(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+				return nil, fmt.Errorf("size is not a whole number of bytes: %v", v)
+			}
+			return filesize.Size(v), nil
+		case float32:
+			if float64(v) != math.Trunc(float64(v)) {
+				return nil, fmt.Errorf("size is not a whole number of bytes: %v", v)
+			}
+			return filesize.Size(v), nil
+		}
+		return data, nil
+	}
+}
+
+// DecoderConfig returns the decoder configuration koanf uses by default with
+// DecodeHook added, writing into result
+//
+// Tag names are filled in by koanf from UnmarshalConf.Tag.
+func DecoderConfig(result any) *mapstructure.DecoderConfig {
+	return &mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			DecodeHook(),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+		Result:           result,
+		WeaklyTypedInput: true,
+	}
+}
@@ -0,0 +1,91 @@
+package koanfsize
+
+import (
+	"strings"
+	"testing"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/providers/env"
+	"github.com/knadh/koanf/v2"
+)
+
+// serviceConfig is a koanf-configured struct with size fields
+type serviceConfig struct {
+	Cache struct {
+		MaxSize filesize.Size `koanf:"max_size"`
+	} `koanf:"cache"`
+	UploadLimit filesize.Size `koanf:"upload_limit"`
+}
+
+// loadLayers loads a JSON-like defaults layer and an environment layer
+func loadLayers(t *testing.T, defaults map[string]any) *koanf.Koanf {
+	t.Helper()
+
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(defaults, "."), nil); err != nil {
+		t.Fatalf("Load(confmap) unexpected error: %v", err)
+	}
+
+	// APP_CACHE__MAX_SIZE becomes cache.max_size
+	envProvider := env.Provider("APP_", ".", func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(s, "APP_")), "__", ".")
+	})
+	if err := k.Load(envProvider, nil); err != nil {
+		t.Fatalf("Load(env) unexpected error: %v", err)
+	}
+	return k
+}
+
+// TestDefaultUnmarshal tests the TextUnmarshaler path koanf uses by default
+func TestDefaultUnmarshal(t *testing.T) {
+	t.Setenv("APP_CACHE__MAX_SIZE", "2GiB")
+
+	k := loadLayers(t, map[string]any{
+		"cache.max_size": "512MiB",
+		"upload_limit":   float64(1048576),
+	})
+
+	var cfg serviceConfig
+	if err := k.Unmarshal("", &cfg); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if cfg.Cache.MaxSize != filesize.Size(2*filesize.GiB) || cfg.UploadLimit != filesize.Size(filesize.MiB) {
+		t.Errorf("Unmarshal() = %+v, expected max size %d and upload limit %d", cfg, 2*filesize.GiB, filesize.MiB)
+	}
+}
+
+// TestDecoderConfig tests custom decoder configurations using the hook
+func TestDecoderConfig(t *testing.T) {
+	testCases := []struct {
+		uploadLimit any
+		expected    filesize.Size
+		hasError    bool
+	}{
+		{"10MB", filesize.Size(10 * filesize.MB), false},
+		{float64(4096), 4096, false},
+		{4096, 4096, false},
+		{float64(1.5), 0, true},
+		{"ten megs", 0, true},
+	}
+
+	for _, tc := range testCases {
+		k := loadLayers(t, map[string]any{"upload_limit": tc.uploadLimit})
+
+		var cfg serviceConfig
+		err := k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{DecoderConfig: DecoderConfig(&cfg)})
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("UnmarshalWithConf(%v) expected error but got none", tc.uploadLimit)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("UnmarshalWithConf(%v) unexpected error: %v", tc.uploadLimit, err)
+			continue
+		}
+		if cfg.UploadLimit != tc.expected {
+			t.Errorf("UnmarshalWithConf(%v) = %d, expected %d", tc.uploadLimit, int64(cfg.UploadLimit), int64(tc.expected))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package kongsize teaches alecthomas/kong to parse human-readable sizes.
+//
+// Registering Option makes filesize.Size fields parse "512k" or "10MiB" from
+// arguments, defaults and environment variables, and lets plain int64 fields
+// opt in with the "filesize" type tag:
+//
+//	var cli struct {
+//		MaxSize  filesize.Size `help:"Largest file to keep." default:"10MiB"`
+//		MinFree  int64         `help:"Space to leave free." type:"filesize" default:"1GiB"`
+//	}
+//	kong.Parse(&cli, kongsize.Option())
+package kongsize
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/alecthomas/kong"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// Mapper is a kong.Mapper decoding sizes into any signed integer field
+type Mapper struct{}
+
+// Decode implements kong.Mapper
+//
+// Strings are parsed with filesize.ParseSize and whole numbers from
+// configuration resolvers are taken as byte counts. Errors spell out the
+// accepted syntax, since kong shows them directly to the user.
+func (Mapper) Decode(ctx *kong.DecodeContext, target reflect.Value) error {
+	token, err := ctx.Scan.PopValue("size")
+	if err != nil {
+		return err
+	}
+
+	var bytes int64
+	switch v := token.Value.(type) {
+	case string:
+		bytes, err = filesize.ParseSize(v)
+		if err != nil {
+			return fmt.Errorf("expected a size such as 512k, 10MiB or 1.5GB but got %q: %w", v, err)
+		}
+	case int:
+		bytes = int64(v)
+	case int64:
+		bytes = v
+	case float64:
+		if v != float64(int64(v)) {
+			return fmt.Errorf("expected a whole number of bytes but got %v", v)
+		}
+		bytes = int64(v)
+	default:
+		return fmt.Errorf("expected a size but got %q (%T)", token, token.Value)
+	}
+
+	switch target.Kind() {
+	case reflect.Int, reflect.Int64:
+		target.SetInt(bytes)
+		return nil
+	default:
+		return fmt.Errorf("cannot store a size in a field of type %s", target.Type())
+	}
+}
+
+// PlaceHolder implements kong.PlaceHolderProvider, showing the default or
+// "SIZE" in help output
+func (Mapper) PlaceHolder(flag *kong.Flag) string {
+	if flag.PlaceHolder != "" {
+		return flag.PlaceHolder
+	}
+	if flag.HasDefault {
+		return flag.Default
+	}
+	return "SIZE"
+}
+
+// Option registers Mapper for filesize.Size fields and as the "filesize"
+// named mapper
+func Option() kong.Option {
+	return kong.OptionFunc(func(k *kong.Kong) error {
+		if err := kong.TypeMapper(reflect.TypeOf(filesize.Size(0)), Mapper{}).Apply(k); err != nil {
+			return err
+		}
+		return kong.NamedMapper("filesize", Mapper{}).Apply(k)
+	})
+}
@@ -0,0 +1,100 @@
+package kongsize
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// cli is a kong command-line definition with size fields
+type cli struct {
+	MaxSize filesize.Size `help:"Largest file to keep." default:"10MiB"`
+	MinFree int64         `help:"Space to leave free." type:"filesize" default:"1GiB"`
+	Buffer  filesize.Size `help:"Buffer size." env:"FILESIZE_TEST_BUFFER"`
+}
+
+// newParser builds a kong parser for cli writing help and errors to out
+func newParser(t *testing.T, target *cli, out *bytes.Buffer) *kong.Kong {
+	t.Helper()
+
+	parser, err := kong.New(target,
+		Option(),
+		kong.Name("prune"),
+		kong.Writers(out, out),
+		kong.Exit(func(int) {}),
+	)
+	if err != nil {
+		t.Fatalf("kong.New() unexpected error: %v", err)
+	}
+	return parser
+}
+
+// TestMapper tests parsing sizes from arguments and defaults
+func TestMapper(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		maxSize  filesize.Size
+		minFree  int64
+		hasError bool
+	}{
+		{nil, filesize.Size(10 * filesize.MiB), filesize.GiB, false},
+		{[]string{"--max-size", "1.5GB"}, filesize.Size(1500 * filesize.MB), filesize.GiB, false},
+		{[]string{"--min-free=512m"}, filesize.Size(10 * filesize.MiB), 512 * filesize.MiB, false},
+		{[]string{"--max-size", "huge"}, 0, 0, true},
+		{[]string{"--min-free", "-1k"}, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		var c cli
+		parser := newParser(t, &c, &bytes.Buffer{})
+
+		_, err := parser.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if c.MaxSize != tc.maxSize || c.MinFree != tc.minFree {
+			t.Errorf("Parse(%q) = (%d, %d), expected (%d, %d)", tc.args, int64(c.MaxSize), c.MinFree, int64(tc.maxSize), tc.minFree)
+		}
+	}
+}
+
+// TestMapper_Env tests sourcing a size from an environment variable
+func TestMapper_Env(t *testing.T) {
+	t.Setenv("FILESIZE_TEST_BUFFER", "64k")
+
+	var c cli
+	if _, err := newParser(t, &c, &bytes.Buffer{}).Parse(nil); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if c.Buffer != 64*1024 {
+		t.Errorf("Parse() buffer = %d, expected %d", int64(c.Buffer), 64*1024)
+	}
+}
+
+// TestMapper_Messages tests error and help text
+func TestMapper_Messages(t *testing.T) {
+	var c cli
+	_, err := newParser(t, &c, &bytes.Buffer{}).Parse([]string{"--max-size", "10 parsecs"})
+	if err == nil || !strings.Contains(err.Error(), `--max-size: expected a size such as 512k, 10MiB or 1.5GB but got "10 parsecs"`) {
+		t.Errorf("Parse(10 parsecs) error = %v, expected the accepted syntax", err)
+	}
+
+	var out bytes.Buffer
+	_, _ = newParser(t, &c, &out).Parse([]string{"--help"})
+	help := out.String()
+	for _, expected := range []string{"--max-size=10MiB", "--min-free=1GiB", "--buffer=SIZE"} {
+		if !strings.Contains(help, expected) {
+			t.Errorf("help output = %q, expected it to contain %q", help, expected)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package filesize
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token-bucket bandwidth limiter
+//
+// Tokens are bytes: they accumulate at the configured rate up to the burst
+// size, and every byte read or written through the limiter spends one.
+// Readers and writers wrapped by the same Limiter share its bandwidth, so a
+// single Limiter caps a whole transfer pool. A Limiter is safe for
+// concurrent use.
+type Limiter struct {
+	mu          sync.Mutex
+	bytesPerSec float64
+	burst       int64
+	tokens      float64
+	last        time.Time
+
+	// now and sleep are the clock, replaceable in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewLimiter returns a limiter allowing rate with bursts of up to burst bytes
+//
+// A burst of zero or less defaults to one second's worth of the rate. A zero
+// rate means no limit, matching the usual meaning of "--bwlimit 0". The
+// bucket starts full.
+func NewLimiter(rate Rate, burst int64) *Limiter {
+	return newLimiter(rate, burst, time.Now, sleepContext)
+}
+
+// newLimiter returns a limiter using the given clock
+func newLimiter(rate Rate, burst int64, now func() time.Time, sleep func(context.Context, time.Duration) error) *Limiter {
+	bytesPerSec := max(rate.BytesPerSecond(), 0)
+	if burst <= 0 {
+		burst = max(int64(math.Min(bytesPerSec, math.MaxInt64/2)), 1)
+	}
+
+	return &Limiter{
+		bytesPerSec: bytesPerSec,
+		burst:       burst,
+		tokens:      float64(burst),
+		last:        now(),
+		now:         now,
+		sleep:       sleep,
+	}
+}
+
+// Burst returns the largest number of bytes the limiter lets through at once
+func (l *Limiter) Burst() int64 {
+	return l.burst
+}
+
+// WaitN blocks until n bytes may be transferred or ctx is done
+//
+// Requests larger than the burst are admitted in burst-sized steps. The
+// bytes are reserved before waiting, so concurrent callers are served in
+// order; a reservation abandoned because ctx ended is not refunded.
+func (l *Limiter) WaitN(ctx context.Context, n int64) error {
+	if l.bytesPerSec == 0 {
+		return ctx.Err()
+	}
+
+	for n > 0 {
+		step := min(n, l.burst)
+		if err := l.sleep(ctx, l.reserve(step)); err != nil {
+			return err
+		}
+		n -= step
+	}
+	return nil
+}
+
+// reserve takes n tokens and returns how long to wait until they are covered
+func (l *Limiter) reserve(n int64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// refill for the time elapsed since the last reservation
+	now := l.now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*l.bytesPerSec)
+		l.last = now
+	}
+
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(-l.tokens / l.bytesPerSec * float64(time.Second)))
+}
+
+// Reader returns a reader that reads from r no faster than the limiter allows
+func (l *Limiter) Reader(r io.Reader) io.Reader {
+	return &limitedReader{ctx: context.Background(), r: r, l: l}
+}
+
+// ReaderContext is like Reader but stops waiting with ctx's error when ctx
+// is done
+func (l *Limiter) ReaderContext(ctx context.Context, r io.Reader) io.Reader {
+	return &limitedReader{ctx: ctx, r: r, l: l}
+}
+
+// Writer returns a writer that writes to w no faster than the limiter allows
+func (l *Limiter) Writer(w io.Writer) io.Writer {
+	return &limitedWriter{ctx: context.Background(), w: w, l: l}
+}
+
+// WriterContext is like Writer but stops waiting with ctx's error when ctx
+// is done
+func (l *Limiter) WriterContext(ctx context.Context, w io.Writer) io.Writer {
+	return &limitedWriter{ctx: ctx, w: w, l: l}
+}
+
+// limitedReader throttles reads through a Limiter
+type limitedReader struct {
+	ctx context.Context
+	r   io.Reader
+	l   *Limiter
+}
+
+// Read reads at most one burst and then waits for the bytes it got; an
+// unlimited limiter passes reads straight through
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.l.bytesPerSec > 0 && int64(len(p)) > lr.l.burst {
+		p = p[:lr.l.burst]
+	}
+
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		if waitErr := lr.l.WaitN(lr.ctx, int64(n)); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// limitedWriter throttles writes through a Limiter
+type limitedWriter struct {
+	ctx context.Context
+	w   io.Writer
+	l   *Limiter
+}
+
+// Write waits for and writes p in burst-sized chunks; an unlimited limiter
+// passes writes straight through
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.l.bytesPerSec == 0 {
+		if err := lw.ctx.Err(); err != nil {
+			return 0, err
+		}
+		return lw.w.Write(p)
+	}
+
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(int64(len(p)), lw.l.burst)]
+		if err := lw.l.WaitN(lw.ctx, int64(len(chunk))); err != nil {
+			return written, err
+		}
+
+		n, err := lw.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
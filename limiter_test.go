@@ -0,0 +1,148 @@
+package filesize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose sleeps advance a fake clock
+func newTestLimiter(rate Rate, burst int64) (*Limiter, *fakeClock) {
+	clock := newFakeClock()
+	sleep := func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		clock.Advance(d)
+		return nil
+	}
+	return newLimiter(rate, burst, clock.Now, sleep), clock
+}
+
+// TestLimiter_Reader tests that reads are paced to the rate
+func TestLimiter_Reader(t *testing.T) {
+	l, clock := newTestLimiter(PerSecond(MiB), 64*KiB)
+	start := clock.Now()
+
+	n, err := io.Copy(io.Discard, l.Reader(bytes.NewReader(make([]byte, 4*MiB+64*KiB))))
+	if err != nil || n != 4*MiB+64*KiB {
+		t.Fatalf("io.Copy() = %d, %v, expected %d bytes", n, err, 4*MiB+64*KiB)
+	}
+
+	// the initial burst is free, the rest takes four seconds
+	if elapsed := clock.Now().Sub(start); elapsed != 4*time.Second {
+		t.Errorf("reading 4 MiB + burst at 1 MiB/s took %v, expected 4s", elapsed)
+	}
+}
+
+// TestLimiter_Writer tests that writes are paced and chunked
+func TestLimiter_Writer(t *testing.T) {
+	l, clock := newTestLimiter(PerSecond(1000), 100)
+	start := clock.Now()
+
+	var out bytes.Buffer
+	n, err := l.Writer(&out).Write([]byte(strings.Repeat("x", 2100)))
+	if err != nil || n != 2100 || out.Len() != 2100 {
+		t.Fatalf("Write() = %d, %v, expected 2100 bytes written", n, err)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 2*time.Second {
+		t.Errorf("writing 2100 bytes at 1000 B/s with burst 100 took %v, expected 2s", elapsed)
+	}
+}
+
+// TestLimiter_Refill tests that idle time refills up to the burst
+func TestLimiter_Refill(t *testing.T) {
+	l, clock := newTestLimiter(PerSecond(100), 1000)
+	ctx := context.Background()
+
+	// drain the bucket, then idle long enough to refill it several times
+	if err := l.WaitN(ctx, 1000); err != nil {
+		t.Fatalf("WaitN(1000) unexpected error: %v", err)
+	}
+	clock.Advance(time.Minute)
+
+	start := clock.Now()
+	if err := l.WaitN(ctx, 1500); err != nil {
+		t.Fatalf("WaitN(1500) unexpected error: %v", err)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 5*time.Second {
+		t.Errorf("WaitN(1500) after idling took %v, expected 5s", elapsed)
+	}
+}
+
+// TestLimiter_Defaults tests burst defaults and unlimited rates
+func TestLimiter_Defaults(t *testing.T) {
+	if burst := NewLimiter(PerSecond(5*MiB), 0).Burst(); burst != 5*MiB {
+		t.Errorf("NewLimiter(5 MiB/s, 0).Burst() = %d, expected %d", burst, 5*MiB)
+	}
+	if burst := NewLimiter(Rate{Bytes: 1, Per: time.Hour}, 0).Burst(); burst != 1 {
+		t.Errorf("NewLimiter(1 B/h, 0).Burst() = %d, expected 1", burst)
+	}
+
+	l, clock := newTestLimiter(Rate{}, 0)
+	start := clock.Now()
+	if _, err := io.Copy(io.Discard, l.Reader(bytes.NewReader(make([]byte, MiB)))); err != nil {
+		t.Fatalf("io.Copy() unexpected error: %v", err)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 0 {
+		t.Errorf("unlimited copy took %v, expected no waiting", elapsed)
+	}
+}
+
+// TestLimiter_Context tests cancellation while waiting
+func TestLimiter_Context(t *testing.T) {
+	l := NewLimiter(PerSecond(10), 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := io.Copy(io.Discard, l.ReaderContext(ctx, bytes.NewReader(make([]byte, 1000))))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("io.Copy() with expiring context = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+// callCounter counts the Read and Write calls made on it
+type callCounter struct {
+	r             io.Reader
+	reads, writes int
+}
+
+// Read counts the call and reads from the wrapped reader
+func (c *callCounter) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+// Write counts the call and discards p
+func (c *callCounter) Write(p []byte) (int, error) {
+	c.writes++
+	return len(p), nil
+}
+
+// TestLimiter_UnlimitedPassThrough tests that a zero rate neither truncates
+// reads nor chunks writes
+func TestLimiter_UnlimitedPassThrough(t *testing.T) {
+	l := NewLimiter(Rate{}, 0)
+	data := make([]byte, 64*KiB)
+
+	src := &callCounter{r: bytes.NewReader(data)}
+	buf := make([]byte, len(data))
+	if n, err := io.ReadFull(l.Reader(src), buf); n != len(data) || err != nil {
+		t.Fatalf("io.ReadFull() = %d, %v, expected %d bytes", n, err, len(data))
+	}
+	if src.reads != 1 {
+		t.Errorf("reading 64 KiB unlimited made %d reads, expected 1", src.reads)
+	}
+
+	dst := &callCounter{}
+	if n, err := l.Writer(dst).Write(data); n != len(data) || err != nil {
+		t.Fatalf("Write() = %d, %v, expected %d bytes", n, err, len(data))
+	}
+	if dst.writes != 1 {
+		t.Errorf("writing 64 KiB unlimited made %d writes, expected 1", dst.writes)
+	}
+}
@@ -0,0 +1,161 @@
+package filesize
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"strings"
+)
+
+// Matcher selects files by size, as find -size and fd --size do
+//
+// A Matcher is an inclusive range of byte counts built by ParseMatcher or
+// AnySize. The zero value matches only empty files.
+type Matcher struct {
+	min, max int64
+}
+
+// AnySize returns a Matcher accepting every size
+func AnySize() Matcher {
+	return Matcher{min: 0, max: math.MaxInt64}
+}
+
+// ParseMatcher builds a Matcher from a size filter expression
+//
+// An expression is one or more comma-separated conditions, all of which
+// must hold:
+//
+//	">=1G", "+1G"   at least 1 GiB
+//	">1G"           more than 1 GiB
+//	"<=10M", "-10M" at most 10 MiB
+//	"<10M"          less than 10 MiB
+//	"=4k", "4k"     exactly 4 KiB
+//	"100k-10M"      from 100 KiB to 10 MiB inclusive
+//
+// Sizes are anything ParseSize accepts, so ">=1.5GB,<2GiB" works too.
+func ParseMatcher(expr string) (Matcher, error) {
+	m := AnySize()
+	for _, cond := range strings.Split(expr, ",") {
+		low, high, err := parseCondition(strings.TrimSpace(cond))
+		if err != nil {
+			return Matcher{}, err
+		}
+		m.min, m.max = max(m.min, low), min(m.max, high)
+	}
+
+	// contradictory conditions all mean "nothing"
+	if m.min > m.max {
+		m = Matcher{min: 1, max: 0}
+	}
+	return m, nil
+}
+
+// parseCondition converts one condition to inclusive bounds
+func parseCondition(cond string) (int64, int64, error) {
+	if cond == "" {
+		return 0, 0, fmt.Errorf("empty size condition")
+	}
+
+	// operators, longest first so ">=" is not read as ">"
+	for _, op := range []string{">=", "<=", ">", "<", "=", "+", "-"} {
+		sizeStr, ok := strings.CutPrefix(cond, op)
+		if !ok {
+			continue
+		}
+		bytes, err := ParseSize(sizeStr)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid size condition %q: %w", cond, err)
+		}
+
+		switch op {
+		case ">=", "+":
+			return bytes, math.MaxInt64, nil
+		case ">":
+			// nothing is larger than the largest size
+			if bytes == math.MaxInt64 {
+				return 1, 0, nil
+			}
+			return bytes + 1, math.MaxInt64, nil
+		case "<=", "-":
+			return 0, bytes, nil
+		case "<":
+			return 0, bytes - 1, nil
+		default:
+			return bytes, bytes, nil
+		}
+	}
+
+	// ranges and exact sizes
+	if lowStr, highStr, ok := strings.Cut(cond, "-"); ok {
+		low, err := ParseSize(lowStr)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid size range %q: %w", cond, err)
+		}
+		high, err := ParseSize(highStr)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid size range %q: %w", cond, err)
+		}
+		if low > high {
+			return 0, 0, fmt.Errorf("invalid size range %q: lower bound exceeds upper bound", cond)
+		}
+		return low, high, nil
+	}
+
+	bytes, err := ParseSize(cond)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid size condition %q: %w", cond, err)
+	}
+	return bytes, bytes, nil
+}
+
+// Match reports whether size satisfies the matcher
+func (m Matcher) Match(size int64) bool {
+	return size >= m.min && size <= m.max
+}
+
+// MatchInfo reports whether info describes a file, not a directory, whose
+// size satisfies the matcher
+func (m Matcher) MatchInfo(info fs.FileInfo) bool {
+	return !info.IsDir() && m.Match(info.Size())
+}
+
+// MatchEntry is MatchInfo for the entries filepath.WalkDir and fs.WalkDir
+// pass to their callbacks
+//
+//	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+//		if err != nil {
+//			return err
+//		}
+//		if ok, err := m.MatchEntry(d); ok {
+//			fmt.Println(path)
+//		} else if err != nil {
+//			return err
+//		}
+//		return nil
+//	})
+func (m Matcher) MatchEntry(d fs.DirEntry) (bool, error) {
+	if d.IsDir() {
+		return false, nil
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return false, err
+	}
+	return m.MatchInfo(info), nil
+}
+
+// String returns an expression ParseMatcher reads back as the same matcher
+func (m Matcher) String() string {
+	switch {
+	case m.min > m.max:
+		return "<0"
+	case m.min == m.max:
+		return "=" + formatExact(m.min, "")
+	case m.max == math.MaxInt64:
+		return ">=" + formatExact(m.min, "")
+	case m.min == 0:
+		return "<=" + formatExact(m.max, "")
+	}
+	return formatExact(m.min, "") + "-" + formatExact(m.max, "")
+}
@@ -0,0 +1,102 @@
+package filesize
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// TestParseMatcher tests filter expressions against sizes
+func TestParseMatcher(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		matches  []int64
+		rejects  []int64
+		hasError bool
+	}{
+		{">=1G", []int64{GiB, 2 * GiB}, []int64{GiB - 1, 0}, false},
+		{"+1G", []int64{GiB}, []int64{GiB - 1}, false},
+		{">1G", []int64{GiB + 1}, []int64{GiB}, false},
+		{"<=10M", []int64{0, 10 * MiB}, []int64{10*MiB + 1}, false},
+		{"-10M", []int64{10 * MiB}, []int64{10*MiB + 1}, false},
+		{"<10M", []int64{10*MiB - 1}, []int64{10 * MiB}, false},
+		{"=4k", []int64{4096}, []int64{4095, 4097}, false},
+		{"4k", []int64{4096}, []int64{4000}, false},
+		{"100k-10M", []int64{100 * KiB, 10 * MiB}, []int64{100*KiB - 1, 10*MiB + 1}, false},
+		{">=1.5GB, <2GiB", []int64{1500 * MB, 2*GiB - 1}, []int64{1500*MB - 1, 2 * GiB}, false},
+		{">1G,<1G", nil, []int64{GiB - 1, GiB, GiB + 1}, false},
+		{">9223372036854775807", nil, []int64{0, 1 << 62}, false},
+		{"10M-1M", nil, nil, true},
+		{">=lots", nil, nil, true},
+		{"", nil, nil, true},
+		{">=1G,", nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		m, err := ParseMatcher(tc.expr)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseMatcher(%q) expected error but got none", tc.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMatcher(%q) unexpected error: %v", tc.expr, err)
+			continue
+		}
+
+		for _, size := range tc.matches {
+			if !m.Match(size) {
+				t.Errorf("ParseMatcher(%q).Match(%d) = false, expected true", tc.expr, size)
+			}
+		}
+		for _, size := range tc.rejects {
+			if m.Match(size) {
+				t.Errorf("ParseMatcher(%q).Match(%d) = true, expected false", tc.expr, size)
+			}
+		}
+
+		// the string form parses back to the same matcher
+		if again, err := ParseMatcher(m.String()); err != nil || again != m {
+			t.Errorf("ParseMatcher(%q).String() = %q, which parses to %+v, %v", tc.expr, m.String(), again, err)
+		}
+	}
+}
+
+// TestMatcher_WalkDir tests selecting files while walking an fs.FS
+func TestMatcher_WalkDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"small.txt":      {Data: make([]byte, 10)},
+		"logs/app.log":   {Data: make([]byte, 200*1024)},
+		"logs/old.log":   {Data: make([]byte, 50*1024)},
+		"media/clip.mp4": {Data: make([]byte, 2*1024*1024)},
+	}
+	m, err := ParseMatcher("100k-10M")
+	if err != nil {
+		t.Fatalf("ParseMatcher() unexpected error: %v", err)
+	}
+
+	var matched []string
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ok, err := m.MatchEntry(d)
+		if ok {
+			matched = append(matched, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WalkDir() unexpected error: %v", err)
+	}
+
+	expected := []string{"logs/app.log", "media/clip.mp4"}
+	if !slices.Equal(matched, expected) {
+		t.Errorf("matched %v, expected %v", matched, expected)
+	}
+	if !AnySize().Match(0) || AnySize().String() != ">=0B" {
+		t.Errorf("AnySize() = %v, expected to match everything", AnySize())
+	}
+}
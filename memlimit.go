@@ -0,0 +1,153 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strconv"
+)
+
+// ParseMemLimit parses a memory limit with exactly the syntax the Go
+// runtime accepts for GOMEMLIMIT
+//
+// That is a non-negative integer, optionally followed by "B", "KiB",
+// "MiB", "GiB" or "TiB", or the word "off" for no limit, which is
+// math.MaxInt64. The syntax is deliberately narrower than ParseSize: no
+// fractions, spaces, decimal units or lowercase, so a value accepted here
+// is accepted by the runtime and vice versa.
+func ParseMemLimit(s string) (Size, error) {
+	if s == "off" {
+		return Size(math.MaxInt64), nil
+	}
+
+	limit, ok := parseMemLimit(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid memory limit: %q", s)
+	}
+	return Size(limit), nil
+}
+
+// parseMemLimit mirrors the runtime's parseByteCount
+func parseMemLimit(s string) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+
+	// a trailing digit means a plain byte count
+	last := s[len(s)-1]
+	if last >= '0' && last <= '9' {
+		return memLimitInt(s, 1)
+	}
+
+	// otherwise the value ends in "B", after a digit or a binary prefix
+	if last != 'B' || len(s) < 2 {
+		return 0, false
+	}
+	if c := s[len(s)-2]; c >= '0' && c <= '9' {
+		return memLimitInt(s[:len(s)-1], 1)
+	} else if c != 'i' || len(s) < 4 {
+		return 0, false
+	}
+
+	var multiplier int64
+	switch s[len(s)-3] {
+	case 'K':
+		multiplier = KiB
+	case 'M':
+		multiplier = MiB
+	case 'G':
+		multiplier = GiB
+	case 'T':
+		multiplier = TiB
+	default:
+		return 0, false
+	}
+	return memLimitInt(s[:len(s)-3], multiplier)
+}
+
+// memLimitInt parses digits the way the runtime does, allowing a leading
+// "-" that only zero survives, and scales them by multiplier
+func memLimitInt(s string, multiplier int64) (int64, bool) {
+	digits := s
+	if digits != "" && digits[0] == '-' {
+		digits = digits[1:]
+	}
+	if digits == "" || scanDigits(digits, 0) != len(digits) {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/multiplier {
+		return 0, false
+	}
+	return n * multiplier, true
+}
+
+// FormatMemLimit formats a limit so that ParseMemLimit and GOMEMLIMIT
+// accept it, using the largest binary unit that divides it exactly, e.g.
+// "2GiB" or "1500B"
+//
+// math.MaxInt64, the runtime's "no limit", formats as "off".
+func FormatMemLimit(limit int64) string {
+	if limit == math.MaxInt64 {
+		return "off"
+	}
+
+	for _, unit := range []struct {
+		name       string
+		multiplier int64
+	}{
+		{"TiB", TiB},
+		{"GiB", GiB},
+		{"MiB", MiB},
+		{"KiB", KiB},
+	} {
+		if limit != 0 && limit%unit.multiplier == 0 {
+			return strconv.FormatInt(limit/unit.multiplier, 10) + unit.name
+		}
+	}
+	return strconv.FormatInt(limit, 10) + "B"
+}
+
+// SetMemLimit parses s like ParseMemLimit and applies it with
+// debug.SetMemoryLimit, returning the previous limit
+//
+// The limit is left unchanged when s does not parse.
+func SetMemLimit(s string) (Size, error) {
+	limit, err := ParseMemLimit(s)
+	if err != nil {
+		return 0, err
+	}
+	return Size(debug.SetMemoryLimit(int64(limit))), nil
+}
+
+// MemLimitValue is a flag.Value that sets the runtime's soft memory limit,
+// so "--mem-limit 2GiB" behaves exactly like GOMEMLIMIT=2GiB
+//
+// Setting the flag calls debug.SetMemoryLimit and the value reads back the
+// limit in force, which is the GOMEMLIMIT setting until the flag is given.
+// Like SizeValue it implements flag.Getter and pflag's Type method:
+//
+//	flag.Var(filesize.MemLimitValue{}, "mem-limit", "soft memory limit")
+type MemLimitValue struct{}
+
+// Set parses s like ParseMemLimit and applies it
+func (MemLimitValue) Set(s string) error {
+	_, err := SetMemLimit(s)
+	return err
+}
+
+// String returns the current limit formatted with FormatMemLimit
+func (MemLimitValue) String() string {
+	return FormatMemLimit(debug.SetMemoryLimit(-1))
+}
+
+// Get implements flag.Getter, returning the current limit as a Size
+func (MemLimitValue) Get() any {
+	return Size(debug.SetMemoryLimit(-1))
+}
+
+// Type returns "memlimit", the placeholder pflag shows in help output
+func (MemLimitValue) Type() string {
+	return "memlimit"
+}
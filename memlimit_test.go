@@ -0,0 +1,123 @@
+package filesize
+
+import (
+	"flag"
+	"math"
+	"runtime/debug"
+	"testing"
+)
+
+// TestParseMemLimit tests the GOMEMLIMIT syntax, including what it rejects
+func TestParseMemLimit(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{"0", 0, false},
+		{"1500", 1500, false},
+		{"1500B", 1500, false},
+		{"4KiB", 4 * KiB, false},
+		{"512MiB", 512 * MiB, false},
+		{"2GiB", 2 * GiB, false},
+		{"1TiB", TiB, false},
+		{"off", math.MaxInt64, false},
+		{"9223372036854775807", math.MaxInt64, false},
+		{"-0", 0, false},
+
+		// ParseSize accepts these but the runtime does not
+		{"", 0, true},
+		{"B", 0, true},
+		{"iB", 0, true},
+		{"KiB", 0, true},
+		{"1.5GiB", 0, true},
+		{"2GB", 0, true},
+		{"2G", 0, true},
+		{"2gib", 0, true},
+		{"2 GiB", 0, true},
+		{" 2GiB", 0, true},
+		{"1PiB", 0, true},
+		{"+1", 0, true},
+		{"-1", 0, true},
+		{"-1KiB", 0, true},
+		{"OFF", 0, true},
+		{"8388608TiB", 0, true},
+		{"9223372036854775808", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseMemLimit(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseMemLimit(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMemLimit(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("ParseMemLimit(%q) = %d, expected %d", tc.input, int64(result), tc.expected)
+		}
+	}
+}
+
+// TestFormatMemLimit tests that formatted limits parse back
+func TestFormatMemLimit(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0B"},
+		{1500, "1500B"},
+		{4 * KiB, "4KiB"},
+		{1536 * KiB, "1536KiB"},
+		{2 * GiB, "2GiB"},
+		{3 * TiB, "3TiB"},
+		{math.MaxInt64, "off"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatMemLimit(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatMemLimit(%d) = %q, expected %q", tc.input, result, tc.expected)
+			continue
+		}
+		if parsed, err := ParseMemLimit(result); err != nil || int64(parsed) != tc.input {
+			t.Errorf("ParseMemLimit(%q) = %d, %v, expected %d", result, int64(parsed), err, tc.input)
+		}
+	}
+}
+
+// TestMemLimitValue tests setting the runtime limit through a flag
+func TestMemLimitValue(t *testing.T) {
+	original := debug.SetMemoryLimit(-1)
+	defer debug.SetMemoryLimit(original)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(MemLimitValue{}, "mem-limit", "soft memory limit")
+	if err := fs.Parse([]string{"-mem-limit", "2GiB"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if limit := debug.SetMemoryLimit(-1); limit != 2*GiB {
+		t.Errorf("memory limit = %d, expected %d", limit, 2*GiB)
+	}
+	if result := (MemLimitValue{}).String(); result != "2GiB" {
+		t.Errorf("String() = %q, expected %q", result, "2GiB")
+	}
+
+	// a malformed value leaves the limit alone
+	if err := fs.Set("mem-limit", "2GB"); err == nil {
+		t.Errorf("Set(%q) expected error but got none", "2GB")
+	}
+	if limit := (MemLimitValue{}).Get().(Size); int64(limit) != 2*GiB {
+		t.Errorf("Get() = %d, expected %d", int64(limit), 2*GiB)
+	}
+
+	previous, err := SetMemLimit("off")
+	if err != nil || int64(previous) != 2*GiB {
+		t.Errorf("SetMemLimit(%q) = %d, %v, expected %d", "off", int64(previous), err, 2*GiB)
+	}
+}
@@ -0,0 +1,75 @@
+package filesize
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// memStatsFields are the runtime.MemStats fields MemStatsMap reports, in
+// the order MemStatsLines lists them
+var memStatsFields = []struct {
+	name  string
+	value func(m *runtime.MemStats) uint64
+	bytes bool
+}{
+	{"HeapAlloc", func(m *runtime.MemStats) uint64 { return m.HeapAlloc }, true},
+	{"HeapInuse", func(m *runtime.MemStats) uint64 { return m.HeapInuse }, true},
+	{"HeapIdle", func(m *runtime.MemStats) uint64 { return m.HeapIdle }, true},
+	{"HeapReleased", func(m *runtime.MemStats) uint64 { return m.HeapReleased }, true},
+	{"HeapSys", func(m *runtime.MemStats) uint64 { return m.HeapSys }, true},
+	{"HeapObjects", func(m *runtime.MemStats) uint64 { return m.HeapObjects }, false},
+	{"StackInuse", func(m *runtime.MemStats) uint64 { return m.StackInuse }, true},
+	{"StackSys", func(m *runtime.MemStats) uint64 { return m.StackSys }, true},
+	{"Sys", func(m *runtime.MemStats) uint64 { return m.Sys }, true},
+	{"TotalAlloc", func(m *runtime.MemStats) uint64 { return m.TotalAlloc }, true},
+	{"NextGC", func(m *runtime.MemStats) uint64 { return m.NextGC }, true},
+	{"NumGC", func(m *runtime.MemStats) uint64 { return uint64(m.NumGC) }, false},
+}
+
+// FormatMemStats returns a one-line humanized summary of m for logs, e.g.
+// "heap 512 MiB (1843201 objects), sys 1.02 GiB, next GC 768 MiB, total
+// alloc 12.3 GiB, 42 GCs"
+func FormatMemStats(m *runtime.MemStats) string {
+	return fmt.Sprintf("heap %s (%d objects), sys %s, next GC %s, total alloc %s, %d GCs",
+		FormatSizeUint64(m.HeapAlloc), m.HeapObjects, FormatSizeUint64(m.Sys), FormatSizeUint64(m.NextGC),
+		FormatSizeUint64(m.TotalAlloc), m.NumGC)
+}
+
+// MemStatsMap returns the commonly watched fields of m keyed by their
+// runtime.MemStats names, byte counts humanized ("HeapAlloc": "512 MiB")
+// and counts as plain numbers ("NumGC": "42")
+//
+// The map encodes to JSON with sorted keys, which suits expvar and debug
+// endpoints; the httpsize package serves it over HTTP.
+func MemStatsMap(m *runtime.MemStats) map[string]string {
+	fields := make(map[string]string, len(memStatsFields))
+	for _, field := range memStatsFields {
+		fields[field.name] = memStatsValue(field.value(m), field.bytes)
+	}
+	return fields
+}
+
+// MemStatsLines returns the fields of MemStatsMap as aligned text lines
+// such as "HeapAlloc     512 MiB", in a fixed order
+func MemStatsLines(m *runtime.MemStats) []string {
+	width := 0
+	for _, field := range memStatsFields {
+		width = max(width, len(field.name))
+	}
+
+	lines := make([]string, 0, len(memStatsFields))
+	for _, field := range memStatsFields {
+		value := memStatsValue(field.value(m), field.bytes)
+		lines = append(lines, fmt.Sprintf("%-*s  %s", width, field.name, value))
+	}
+	return lines
+}
+
+// memStatsValue formats one field value as a size or a count
+func memStatsValue(value uint64, bytes bool) string {
+	if bytes {
+		return FormatSizeUint64(value)
+	}
+	return strconv.FormatUint(value, 10)
+}
@@ -0,0 +1,69 @@
+package filesize
+
+import (
+	"runtime"
+	"testing"
+)
+
+// testMemStats is a fixed snapshot for formatting tests
+var testMemStats = runtime.MemStats{
+	HeapAlloc:   512 * 1024 * 1024,
+	HeapInuse:   520 * 1024 * 1024,
+	HeapIdle:    100 * 1024 * 1024,
+	HeapSys:     620 * 1024 * 1024,
+	HeapObjects: 1843201,
+	StackInuse:  2 * 1024 * 1024,
+	StackSys:    2 * 1024 * 1024,
+	Sys:         1044 * 1024 * 1024,
+	TotalAlloc:  12 * 1024 * 1024 * 1024,
+	NextGC:      768 * 1024 * 1024,
+	NumGC:       42,
+}
+
+// TestFormatMemStats tests the one-line summary
+func TestFormatMemStats(t *testing.T) {
+	expected := "heap 512 MiB (1843201 objects), sys 1.02 GiB, next GC 768 MiB, total alloc 12.0 GiB, 42 GCs"
+	if result := FormatMemStats(&testMemStats); result != expected {
+		t.Errorf("FormatMemStats() = %q, expected %q", result, expected)
+	}
+}
+
+// TestMemStatsMap tests humanized and count fields
+func TestMemStatsMap(t *testing.T) {
+	fields := MemStatsMap(&testMemStats)
+
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"HeapAlloc", "512 MiB"},
+		{"HeapReleased", "0 B"},
+		{"HeapObjects", "1843201"},
+		{"NextGC", "768 MiB"},
+		{"NumGC", "42"},
+	}
+	for _, tc := range testCases {
+		if fields[tc.name] != tc.expected {
+			t.Errorf("MemStatsMap()[%q] = %q, expected %q", tc.name, fields[tc.name], tc.expected)
+		}
+	}
+	if len(fields) != len(memStatsFields) {
+		t.Errorf("len(MemStatsMap()) = %d, expected %d", len(fields), len(memStatsFields))
+	}
+}
+
+// TestMemStatsLines tests that lines are aligned and ordered
+func TestMemStatsLines(t *testing.T) {
+	lines := MemStatsLines(&testMemStats)
+	if len(lines) != len(memStatsFields) {
+		t.Fatalf("len(MemStatsLines()) = %d, expected %d", len(lines), len(memStatsFields))
+	}
+	if lines[0] != "HeapAlloc     512 MiB" {
+		t.Errorf("MemStatsLines()[0] = %q, expected %q", lines[0], "HeapAlloc     512 MiB")
+	}
+	for _, line := range lines {
+		if line[12:14] != "  " || line[14] == ' ' {
+			t.Errorf("MemStatsLines() line %q is not aligned", line)
+		}
+	}
+}
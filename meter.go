@@ -0,0 +1,131 @@
+package filesize
+
+import (
+	"sync"
+	"time"
+)
+
+// meterBuckets is the number of buckets a ThroughputMeter divides its window
+// into, trading memory for precision at the window's trailing edge
+const meterBuckets = 20
+
+// ThroughputMeter measures a rolling transfer rate
+//
+// Feed it byte counts with Add, or use it as an io.Writer sink such as the
+// second writer of io.TeeReader, and read the rate over the trailing window
+// with Rate or String. Counts are grouped into buckets of a twentieth of the
+// window, so the window's edge moves in those steps. A ThroughputMeter is
+// safe for concurrent use.
+type ThroughputMeter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	bucket  time.Duration
+	started time.Time
+	buckets []meterBucket
+	total   int64
+
+	// now is the clock, replaceable in tests
+	now func() time.Time
+}
+
+// meterBucket holds the bytes counted during one bucket interval
+type meterBucket struct {
+	start time.Time
+	bytes int64
+}
+
+// NewThroughputMeter returns a meter reporting the rate over the trailing
+// window, which must be positive
+func NewThroughputMeter(window time.Duration) *ThroughputMeter {
+	return newThroughputMeter(window, time.Now)
+}
+
+// newThroughputMeter returns a meter reading time from now
+func newThroughputMeter(window time.Duration, now func() time.Time) *ThroughputMeter {
+	if window <= 0 {
+		panic("filesize: NewThroughputMeter window must be positive")
+	}
+
+	bucket := window / meterBuckets
+	if bucket <= 0 {
+		bucket = 1
+	}
+	return &ThroughputMeter{window: window, bucket: bucket, started: now(), now: now}
+}
+
+// Add records n transferred bytes
+func (m *ThroughputMeter) Add(n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.prune(now)
+	m.total += n
+
+	// start a new bucket once the current one has run its course
+	if len(m.buckets) == 0 || now.Sub(m.buckets[len(m.buckets)-1].start) >= m.bucket {
+		m.buckets = append(m.buckets, meterBucket{start: now.Truncate(m.bucket)})
+	}
+	m.buckets[len(m.buckets)-1].bytes += n
+}
+
+// Write implements io.Writer by counting len(p) bytes
+func (m *ThroughputMeter) Write(p []byte) (int, error) {
+	m.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// Rate returns the bytes counted over the trailing window, or since the
+// meter started or was reset when that is more recent
+func (m *ThroughputMeter) Rate() Rate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.prune(now)
+
+	elapsed := min(now.Sub(m.started), m.window)
+	if elapsed <= 0 {
+		return Rate{}
+	}
+
+	var bytes int64
+	for _, b := range m.buckets {
+		bytes += b.bytes
+	}
+	return Rate{Bytes: Size(bytes), Per: elapsed}
+}
+
+// Total returns every byte counted since the meter started or was reset
+func (m *ThroughputMeter) Total() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+// Reset discards all counts and restarts the meter
+func (m *ThroughputMeter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started = m.now()
+	m.buckets = m.buckets[:0]
+	m.total = 0
+}
+
+// String formats the current rate per second, e.g. "1.50 MiB/s"
+func (m *ThroughputMeter) String() string {
+	return FormatRate(m.Rate().BytesPerSecond())
+}
+
+// prune drops buckets that ended before the window
+func (m *ThroughputMeter) prune(now time.Time) {
+	cutoff := now.Add(-m.window)
+	i := 0
+	for i < len(m.buckets) && !m.buckets[i].start.Add(m.bucket).After(cutoff) {
+		i++
+	}
+	if i > 0 {
+		m.buckets = append(m.buckets[:0], m.buckets[i:]...)
+	}
+}
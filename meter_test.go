@@ -0,0 +1,119 @@
+package filesize
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for time-dependent tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// newFakeClock returns a clock set to a fixed instant
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// Now returns the clock's current time
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestThroughputMeter tests the rolling rate
+func TestThroughputMeter(t *testing.T) {
+	clock := newFakeClock()
+	m := newThroughputMeter(10*time.Second, clock.Now)
+
+	if rate := m.Rate(); rate != (Rate{}) {
+		t.Errorf("Rate() before any time passed = %+v, expected zero", rate)
+	}
+
+	// 1 MiB per second for five seconds
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Second)
+		m.Add(MiB)
+	}
+	if bps := m.Rate().BytesPerSecond(); bps != float64(MiB) {
+		t.Errorf("Rate() after 5s at 1 MiB/s = %g B/s, expected %d", bps, MiB)
+	}
+	if s := m.String(); s != "1.00 MiB/s" {
+		t.Errorf("String() = %q, expected %q", s, "1.00 MiB/s")
+	}
+
+	// another ten seconds at 2 MiB per second pushes the old counts out
+	for i := 0; i < 10; i++ {
+		clock.Advance(time.Second)
+		m.Add(2 * MiB)
+	}
+	// step past the bucket holding the last count of the first phase
+	clock.Advance(500 * time.Millisecond)
+	if bps := m.Rate().BytesPerSecond(); bps != float64(2*MiB) {
+		t.Errorf("Rate() after 10s at 2 MiB/s = %g B/s, expected %d", bps, 2*MiB)
+	}
+	if total := m.Total(); total != 25*MiB {
+		t.Errorf("Total() = %d, expected %d", total, 25*MiB)
+	}
+
+	// a stall decays the rate to zero
+	clock.Advance(11 * time.Second)
+	if bps := m.Rate().BytesPerSecond(); bps != 0 {
+		t.Errorf("Rate() after stalling = %g B/s, expected 0", bps)
+	}
+
+	m.Reset()
+	clock.Advance(time.Second)
+	if total, bps := m.Total(), m.Rate().BytesPerSecond(); total != 0 || bps != 0 {
+		t.Errorf("after Reset() Total() = %d and rate %g, expected 0 and 0", total, bps)
+	}
+}
+
+// TestThroughputMeter_Writer tests metering a copy through io.TeeReader
+func TestThroughputMeter_Writer(t *testing.T) {
+	clock := newFakeClock()
+	m := newThroughputMeter(time.Minute, clock.Now)
+
+	clock.Advance(2 * time.Second)
+	n, err := io.Copy(io.Discard, io.TeeReader(strings.NewReader(strings.Repeat("x", 4096)), m))
+	if err != nil || n != 4096 {
+		t.Fatalf("io.Copy() = %d, %v, expected 4096 bytes", n, err)
+	}
+	if rate := m.Rate(); rate != (Rate{Bytes: 4096, Per: 2 * time.Second}) {
+		t.Errorf("Rate() = %+v, expected 4096 bytes per 2s", rate)
+	}
+}
+
+// TestThroughputMeter_Concurrent tests concurrent Add calls
+func TestThroughputMeter_Concurrent(t *testing.T) {
+	m := NewThroughputMeter(time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				m.Add(1)
+				_ = m.Rate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if total := m.Total(); total != 8000 {
+		t.Errorf("Total() = %d, expected 8000", total)
+	}
+}
@@ -0,0 +1,45 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxMmapLength is the largest mapping a process can hope to address: the
+// 128 TiB user half of a 48-bit address space on 64-bit systems and the int
+// range that mmap lengths are passed in on 32-bit ones
+const maxMmapLength = min(math.MaxInt, 1<<47)
+
+// MmapLength turns a human-configured mapping size such as "256MiB" into a
+// length that is safe to pass to mmap
+//
+// The size is parsed with ParseSize, rounded up to whole pages as mmap
+// requires, and checked against what the process can address: the int
+// range, the user address space and, on Linux, the RLIMIT_AS soft limit.
+// Sizes that cannot be mapped return an error matching ErrLimitExceeded
+// that names the limit, rather than failing later inside mmap with ENOMEM.
+// Zero is rejected because mmap does not accept empty mappings.
+func MmapLength(requested string) (int, error) {
+	n, err := ParseSize(requested)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, errors.New("mmap length must be positive")
+	}
+
+	length, err := PageAlign(n)
+	if err != nil {
+		return 0, err
+	}
+	if length > maxMmapLength {
+		return 0, fmt.Errorf("mmap length %s exceeds the %s address space: %w",
+			length, Size(maxMmapLength), ErrLimitExceeded)
+	}
+	if limit, ok := addressSpaceLimit(); ok && uint64(length) > limit {
+		return 0, fmt.Errorf("mmap length %s exceeds the RLIMIT_AS limit of %s: %w",
+			length, Size(clampUint64(limit)), ErrLimitExceeded)
+	}
+	return int(length), nil
+}
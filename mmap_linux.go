@@ -0,0 +1,13 @@
+package filesize
+
+import "golang.org/x/sys/unix"
+
+// addressSpaceLimit returns the RLIMIT_AS soft limit, reporting false when
+// the address space is unlimited or the limit cannot be read
+func addressSpaceLimit() (uint64, bool) {
+	var rl unix.Rlimit
+	if err := unix.Getrlimit(unix.RLIMIT_AS, &rl); err != nil || rl.Cur == unix.RLIM_INFINITY {
+		return 0, false
+	}
+	return rl.Cur, true
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package filesize
+
+// addressSpaceLimit reports that no address space limit is checked on this
+// platform
+func addressSpaceLimit() (uint64, bool) {
+	return 0, false
+}
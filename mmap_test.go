@@ -0,0 +1,47 @@
+package filesize
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// TestMmapLength tests parsing, page alignment and limit checks
+func TestMmapLength(t *testing.T) {
+	pageSize := os.Getpagesize()
+
+	testCases := []struct {
+		input    string
+		expected int
+		hasError bool
+	}{
+		{"1", pageSize, false},
+		{"1MiB", 1 << 20, false},
+		{"10000", (10000 + pageSize - 1) / pageSize * pageSize, false},
+		{"0", 0, true},
+		{"", 0, true},
+		{"-4k", 0, true},
+		{"1EiB", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := MmapLength(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("MmapLength(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("MmapLength(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("MmapLength(%q) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+
+	if _, err := MmapLength("1EiB"); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("MmapLength(%q) error = %v, expected ErrLimitExceeded", "1EiB", err)
+	}
+}
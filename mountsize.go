@@ -0,0 +1,143 @@
+package filesize
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MountSize is the value of a tmpfs-style "size=" mount option, either an
+// absolute size or a percentage of physical memory
+//
+// The zero value is "size=0", which tmpfs reads as no limit.
+type MountSize struct {
+	// Bytes is the absolute size, used when IsPercent is false
+	Bytes Size
+
+	// Value is the share of RAM in percent, used when IsPercent is true
+	Value     int64
+	IsPercent bool
+}
+
+// mountSizeUnits are memparse's suffixes, all 1024-based
+var mountSizeUnits = []struct {
+	suffix     byte
+	multiplier int64
+}{
+	{'e', EiB}, {'p', PiB}, {'t', TiB}, {'g', GiB}, {'m', MiB}, {'k', KiB},
+}
+
+// ParseMountSize parses the size syntax of tmpfs and similar mount
+// options, such as "size=512m", "512m" or "size=50%"
+//
+// It follows the kernel's memparse: an integer, in hex with "0x" or octal
+// with a leading "0", optionally followed by one of the 1024-based suffixes
+// k, m, g, t, p or e in either case, or by "%" for a share of RAM. Fractions
+// and suffixes such as "MiB" are rejected, as mount would reject them.
+func ParseMountSize(s string) (MountSize, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(s), "size=")
+
+	// the number runs up to the first character that is not a digit
+	// in its base
+	end := mountSizeDigits(value)
+	if end == 0 {
+		return MountSize{}, fmt.Errorf("invalid mount size: %q", s)
+	}
+	n, err := strconv.ParseInt(value[:end], 0, 64)
+	if err != nil {
+		return MountSize{}, fmt.Errorf("invalid mount size: %q", s)
+	}
+
+	suffix := value[end:]
+	switch {
+	case suffix == "":
+		return MountSize{Bytes: Size(n)}, nil
+	case suffix == "%":
+		return MountSize{Value: n, IsPercent: true}, nil
+	case len(suffix) == 1:
+		for _, unit := range mountSizeUnits {
+			if suffix[0]|0x20 != unit.suffix {
+				continue
+			}
+			if n > (1<<63-1)/unit.multiplier {
+				return MountSize{}, fmt.Errorf("mount size %q: %w", s, ErrOverflow)
+			}
+			return MountSize{Bytes: Size(n * unit.multiplier)}, nil
+		}
+	}
+	return MountSize{}, fmt.Errorf("invalid mount size: %q", s)
+}
+
+// mountSizeDigits returns the length of the leading number of s in the
+// base its prefix selects
+func mountSizeDigits(s string) int {
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		i := 2
+		for i < len(s) && strings.IndexByte("0123456789abcdefABCDEF", s[i]) >= 0 {
+			i++
+		}
+		if i == 2 {
+			return 0
+		}
+		return i
+	}
+	return scanDigits(s, 0)
+}
+
+// Resolve returns the size tmpfs would use on a machine with totalRAM
+// bytes of memory: the percentage of RAM for relative sizes, rounded up to
+// whole pages like the kernel does
+func (m MountSize) Resolve(totalRAM int64) (Size, error) {
+	bytes := int64(m.Bytes)
+	if m.IsPercent {
+		share := new(big.Int).Mul(big.NewInt(m.Value), big.NewInt(totalRAM))
+		share.Quo(share, big.NewInt(100))
+		if !share.IsInt64() {
+			return 0, fmt.Errorf("mount size %s: %w", m, ErrOverflow)
+		}
+		bytes = share.Int64()
+	}
+	return PageAlignTo(bytes, int64(os.Getpagesize()))
+}
+
+// String returns the normalized value without the "size=" prefix, using
+// the largest suffix that represents the size exactly, e.g. "512m", "2g" or
+// "50%"
+func (m MountSize) String() string {
+	if m.IsPercent {
+		return strconv.FormatInt(m.Value, 10) + "%"
+	}
+
+	bytes := int64(m.Bytes)
+	for _, unit := range mountSizeUnits {
+		if bytes != 0 && bytes%unit.multiplier == 0 {
+			return strconv.FormatInt(bytes/unit.multiplier, 10) + string(unit.suffix)
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// Option returns the normalized mount option, e.g. "size=512m"
+func (m MountSize) Option() string {
+	return "size=" + m.String()
+}
+
+// ResolveMountSize parses s like ParseMountSize and resolves it against the
+// physical memory of this machine, as mounting it here would
+func ResolveMountSize(s string) (Size, error) {
+	m, err := ParseMountSize(s)
+	if err != nil {
+		return 0, err
+	}
+	if !m.IsPercent {
+		return m.Resolve(0)
+	}
+
+	total, err := SystemMemory()
+	if err != nil {
+		return 0, err
+	}
+	return m.Resolve(int64(total))
+}
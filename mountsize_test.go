@@ -0,0 +1,145 @@
+package filesize
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+)
+
+// TestParseMountSize tests the kernel's memparse syntax
+func TestParseMountSize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected MountSize
+		hasError bool
+	}{
+		{"size=512m", MountSize{Bytes: Size(512 * MiB)}, false},
+		{"512M", MountSize{Bytes: Size(512 * MiB)}, false},
+		{"size=2g", MountSize{Bytes: Size(2 * GiB)}, false},
+		{"size=1T", MountSize{Bytes: Size(TiB)}, false},
+		{"size=4096", MountSize{Bytes: 4096}, false},
+		{"size=0", MountSize{}, false},
+		{"size=0x100k", MountSize{Bytes: Size(256 * KiB)}, false},
+		{"size=010m", MountSize{Bytes: Size(8 * MiB)}, false},
+		{"size=50%", MountSize{Value: 50, IsPercent: true}, false},
+		{"150%", MountSize{Value: 150, IsPercent: true}, false},
+
+		// mount rejects these
+		{"", MountSize{}, true},
+		{"size=", MountSize{}, true},
+		{"size=m", MountSize{}, true},
+		{"size=1.5g", MountSize{}, true},
+		{"size=512MiB", MountSize{}, true},
+		{"size=512MB", MountSize{}, true},
+		{"size=-1m", MountSize{}, true},
+		{"size=08", MountSize{}, true},
+		{"size=0x", MountSize{}, true},
+		{"size=1k%", MountSize{}, true},
+		{"size=9e", MountSize{}, true},
+		{"nr_blocks=10", MountSize{}, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseMountSize(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseMountSize(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMountSize(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("ParseMountSize(%q) = %+v, expected %+v", tc.input, result, tc.expected)
+		}
+	}
+
+	if _, err := ParseMountSize("size=9e"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseMountSize(%q) error = %v, expected ErrOverflow", "size=9e", err)
+	}
+}
+
+// TestMountSize_Option tests normalizing options
+func TestMountSize_Option(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"size=524288k", "size=512m"},
+		{"size=2048M", "size=2g"},
+		{"size=0x1000", "size=4k"},
+		{"size=1000", "size=1000"},
+		{"size=0", "size=0"},
+		{"size=50%", "size=50%"},
+	}
+
+	for _, tc := range testCases {
+		m, err := ParseMountSize(tc.input)
+		if err != nil {
+			t.Errorf("ParseMountSize(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result := m.Option(); result != tc.expected {
+			t.Errorf("ParseMountSize(%q).Option() = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestMountSize_Resolve tests percent-of-RAM resolution and page rounding
+func TestMountSize_Resolve(t *testing.T) {
+	pageSize := int64(os.Getpagesize())
+
+	testCases := []struct {
+		size     MountSize
+		totalRAM int64
+		expected int64
+	}{
+		{MountSize{Value: 50, IsPercent: true}, 16 * GiB, 8 * GiB},
+		{MountSize{Value: 150, IsPercent: true}, 2 * GiB, 3 * GiB},
+		{MountSize{Value: 1, IsPercent: true}, 100 * pageSize, pageSize},
+		{MountSize{Bytes: 1}, 0, pageSize},
+		{MountSize{Bytes: Size(512 * MiB)}, 0, 512 * MiB},
+	}
+
+	for _, tc := range testCases {
+		result, err := tc.size.Resolve(tc.totalRAM)
+		if err != nil {
+			t.Errorf("%s.Resolve(%d) unexpected error: %v", tc.size, tc.totalRAM, err)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("%s.Resolve(%d) = %d, expected %d", tc.size, tc.totalRAM, int64(result), tc.expected)
+		}
+	}
+
+	huge := MountSize{Value: 1 << 40, IsPercent: true}
+	if _, err := huge.Resolve(EiB); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Resolve() overflow error = %v, expected ErrOverflow", err)
+	}
+}
+
+// TestResolveMountSize tests resolving against this machine's memory
+func TestResolveMountSize(t *testing.T) {
+	size, err := ResolveMountSize("size=64m")
+	if err != nil || int64(size) != 64*MiB {
+		t.Errorf("ResolveMountSize(%q) = %d, %v, expected %d", "size=64m", int64(size), err, 64*MiB)
+	}
+
+	size, err = ResolveMountSize("size=50%")
+	if runtime.GOOS != "linux" {
+		if err == nil {
+			t.Errorf("ResolveMountSize(%q) on %s expected error but got none", "size=50%", runtime.GOOS)
+		}
+		return
+	}
+	total, err2 := SystemMemory()
+	if err != nil || err2 != nil {
+		t.Fatalf("ResolveMountSize(%q) unexpected error: %v, %v", "size=50%", err, err2)
+	}
+	if size <= 0 || size > total {
+		t.Errorf("ResolveMountSize(%q) = %d, expected about half of %d", "size=50%", int64(size), int64(total))
+	}
+}
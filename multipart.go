@@ -0,0 +1,73 @@
+package filesize
+
+import "fmt"
+
+// MultipartLimits are the part constraints of a multipart upload API
+//
+// A zero MaxPartSize or MaxObjectSize means no limit.
+type MultipartLimits struct {
+	// MinPartSize is the smallest allowed part, except for the last one
+	MinPartSize int64
+
+	// MaxPartSize is the largest allowed part
+	MaxPartSize int64
+
+	// MaxParts is the largest number of parts in one upload
+	MaxParts int64
+
+	// MaxObjectSize is the largest object that can be uploaded
+	MaxObjectSize int64
+}
+
+// S3Limits are the Amazon S3 multipart limits, which most S3-compatible
+// stores share: parts of 5 MiB to 5 GiB, at most 10,000 of them, and
+// objects of up to 5 TiB
+var S3Limits = MultipartLimits{
+	MinPartSize:   5 * MiB,
+	MaxPartSize:   5 * GiB,
+	MaxParts:      10000,
+	MaxObjectSize: 5 * TiB,
+}
+
+// multipartAlign is the granularity part sizes are rounded up to
+const multipartAlign = MiB
+
+// PlanMultipart picks the part size and count for uploading size bytes
+//
+// It chooses the smallest part size within limits that needs no more than
+// MaxParts parts, rounded up to a whole MiB, so a 48 GiB object with
+// S3Limits uploads as 9831 parts of 5 MiB. The result is a ChunkPlan whose
+// chunks are the parts; an empty object plans zero parts.
+func PlanMultipart(size int64, limits MultipartLimits) (ChunkPlan, error) {
+	if size < 0 {
+		return ChunkPlan{}, fmt.Errorf("size cannot be negative: %d", size)
+	}
+	if limits.MaxParts <= 0 || limits.MinPartSize < 0 {
+		return ChunkPlan{}, fmt.Errorf("invalid multipart limits: %+v", limits)
+	}
+	if limits.MaxObjectSize > 0 && size > limits.MaxObjectSize {
+		return ChunkPlan{}, fmt.Errorf("object of %s exceeds the %s maximum", Size(size), Size(limits.MaxObjectSize))
+	}
+
+	// the smallest part that keeps the count within MaxParts
+	partSize := max(ceilDiv(size, limits.MaxParts), limits.MinPartSize, 1)
+
+	// round to whole MiB where the limits allow it
+	if aligned := ceilDiv(partSize, multipartAlign) * multipartAlign; aligned > partSize &&
+		(limits.MaxPartSize <= 0 || aligned <= limits.MaxPartSize) {
+		partSize = aligned
+	}
+
+	if limits.MaxPartSize > 0 && partSize > limits.MaxPartSize {
+		return ChunkPlan{}, fmt.Errorf("object of %s needs parts larger than the %s maximum", Size(size), Size(limits.MaxPartSize))
+	}
+	return Chunks(size, partSize)
+}
+
+// ceilDiv returns a / b rounded up for non-negative a and positive b
+func ceilDiv(a, b int64) int64 {
+	if a == 0 {
+		return 0
+	}
+	return (a-1)/b + 1
+}
@@ -0,0 +1,62 @@
+package filesize
+
+import "testing"
+
+// TestPlanMultipart tests part planning against S3 limits
+func TestPlanMultipart(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		partSize int64
+		parts    int64
+		hasError bool
+	}{
+		{48 * GiB, 5 * MiB, 9831, false},
+		{100 * GiB, 11 * MiB, 9310, false},
+		{5 * TiB, 525 * MiB, 9987, false},
+		{MiB, 5 * MiB, 1, false},
+		{0, 5 * MiB, 0, false},
+		{5*TiB + 1, 0, 0, true},
+		{-1, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		plan, err := PlanMultipart(tc.size, S3Limits)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("PlanMultipart(%d) expected error but got none", tc.size)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("PlanMultipart(%d) unexpected error: %v", tc.size, err)
+			continue
+		}
+		if plan.ChunkSize != tc.partSize || plan.Count != tc.parts {
+			t.Errorf("PlanMultipart(%d) = %d parts of %d, expected %d parts of %d",
+				tc.size, plan.Count, plan.ChunkSize, tc.parts, tc.partSize)
+		}
+		if plan.Count > S3Limits.MaxParts {
+			t.Errorf("PlanMultipart(%d) planned %d parts, more than the limit", tc.size, plan.Count)
+		}
+	}
+}
+
+// TestPlanMultipart_CustomLimits tests limits without alignment headroom
+func TestPlanMultipart_CustomLimits(t *testing.T) {
+	limits := MultipartLimits{MinPartSize: 100, MaxPartSize: 1000, MaxParts: 10}
+
+	plan, err := PlanMultipart(9995, limits)
+	if err != nil {
+		t.Fatalf("PlanMultipart(9995) unexpected error: %v", err)
+	}
+	if plan.ChunkSize != 1000 || plan.Count != 10 || plan.Last != 995 {
+		t.Errorf("PlanMultipart(9995) = %+v, expected 10 parts of 1000", plan)
+	}
+
+	if _, err := PlanMultipart(10001, limits); err == nil {
+		t.Errorf("PlanMultipart(10001) expected error for parts over the maximum but got none")
+	}
+	if _, err := PlanMultipart(10, MultipartLimits{}); err == nil {
+		t.Errorf("PlanMultipart() with zero MaxParts expected error but got none")
+	}
+}
@@ -0,0 +1,93 @@
+package filesize
+
+import (
+	"strconv"
+)
+
+// maxExactDecimals is the number of fractional digits a canonical value may
+// carry before a smaller unit is preferred
+const maxExactDecimals = 3
+
+// Normalize parses a size string and rewrites it in canonical form
+//
+// The canonical form uses the largest binary unit that represents the value
+// exactly with at most three decimal places, so "1024k" becomes "1 MiB",
+// "1.5MiB" becomes "1.5 MiB" and "1500" stays "1500 B". Unlike FormatSize the
+// result never loses precision: ParseSize(Normalize(s)) always equals
+// ParseSize(s). This is intended for config linters and --fix tooling that
+// rewrite user files into a consistent style.
+func Normalize(sizeStr string) (string, error) {
+	bytes, err := ParseSize(sizeStr)
+	if err != nil {
+		return "", err
+	}
+
+	return formatExact(bytes, " "), nil
+}
+
+// SameSize reports whether two size strings describe the same byte count
+//
+// Strings are compared by value, so SameSize("1MiB", "1024KiB") is true while
+// SameSize("1MB", "1MiB") is false. This lets config-diff tools ignore purely
+// cosmetic edits. An error is returned if either string fails to parse.
+func SameSize(a, b string) (bool, error) {
+	aBytes, err := ParseSize(a)
+	if err != nil {
+		return false, err
+	}
+
+	bBytes, err := ParseSize(b)
+	if err != nil {
+		return false, err
+	}
+
+	return aBytes == bBytes, nil
+}
+
+// formatExact renders bytes in the largest binary unit that expresses it
+// exactly, joining the number and unit with separator
+func formatExact(bytes int64, separator string) string {
+	sign := ""
+	if bytes < 0 {
+		sign = "-"
+	}
+	magnitude := absInt64(bytes)
+
+	// try units from largest to smallest until one is exact
+	for _, unit := range binaryByteUnits {
+		multiplier := uint64(unit.multiplier)
+		if magnitude < multiplier {
+			continue
+		}
+
+		// the remainder must be expressible in a few decimal places
+		whole, remainder := magnitude/multiplier, magnitude%multiplier
+		if number, ok := exactFraction(whole, remainder, multiplier); ok {
+			return sign + number + separator + unit.name
+		}
+	}
+
+	return sign + strconv.FormatUint(magnitude, 10) + separator + "B"
+}
+
+// exactFraction renders whole + remainder/multiplier as a decimal number if it
+// terminates within maxExactDecimals places
+func exactFraction(whole, remainder, multiplier uint64) (string, bool) {
+	number := strconv.FormatUint(whole, 10)
+	if remainder == 0 {
+		return number, true
+	}
+
+	// long division, one decimal digit at a time
+	digits := make([]byte, 0, maxExactDecimals)
+	for i := 0; i < maxExactDecimals && remainder != 0; i++ {
+		remainder *= 10
+		digits = append(digits, byte('0'+remainder/multiplier))
+		remainder %= multiplier
+	}
+	if remainder != 0 {
+		return "", false
+	}
+
+	return number + "." + string(digits), true
+}
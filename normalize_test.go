@@ -0,0 +1,94 @@
+package filesize
+
+import (
+	"testing"
+)
+
+// TestNormalize tests the Normalize function
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"0", "0 B", false},
+		{"1500", "1500 B", false},
+		{"1024", "1 KiB", false},
+		{"1024k", "1 MiB", false},
+		{"1.5MiB", "1.5 MiB", false},
+		{" 1536 k ", "1.5 MiB", false},
+		{"1.25g", "1.25 GiB", false},
+		{"1KB", "1000 B", false},
+		{"1MB", "1000000 B", false},
+		{"4096kib", "4 MiB", false},
+		{"1EiB", "1 EiB", false},
+
+		// the invariant holds beyond float64 precision
+		{"4611686018427388416", "4503599627370496.5 KiB", false},
+		{"9007199254740993", "9007199254740993 B", false},
+		{"9223372036854775807", "9223372036854775807 B", false},
+		{"7.875EiB", "7.875 EiB", false},
+		{"8EiB", "", true},
+
+		// invalid input is reported, not normalized
+		{"", "", true},
+		{"1xy", "", true},
+	}
+
+	for _, tc := range testCases {
+		result, err := Normalize(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Normalize(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Normalize(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", tc.input, result, tc.expected)
+		}
+
+		// the canonical form must describe exactly the same byte count
+		original, _ := ParseSize(tc.input)
+		if parsed, err := ParseSize(result); err != nil || parsed != original {
+			t.Errorf("ParseSize(%q) = %d, %v, expected %d", result, parsed, err, original)
+		}
+	}
+}
+
+// TestSameSize tests the SameSize function
+func TestSameSize(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+		hasError bool
+	}{
+		{"1MiB", "1024KiB", true, false},
+		{"1m", "1048576", true, false},
+		{"1.5k", "1536 B", true, false},
+		{"1MB", "1MiB", false, false},
+		{"1k", "1KB", false, false},
+		{"1k", "1xy", false, true},
+		{"", "1k", false, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := SameSize(tc.a, tc.b)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("SameSize(%q, %q) expected error but got none", tc.a, tc.b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SameSize(%q, %q) unexpected error: %v", tc.a, tc.b, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("SameSize(%q, %q) = %t, expected %t", tc.a, tc.b, result, tc.expected)
+		}
+	}
+}
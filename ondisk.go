@@ -0,0 +1,54 @@
+package filesize
+
+import "fmt"
+
+// OnDisk returns the space a file of size bytes occupies on a filesystem
+// allocating in blocks of blockSize bytes
+//
+// The size is rounded up to whole blocks, so OnDisk(1, 4096) is 4 KiB and
+// an empty file takes no blocks. Summing OnDisk over files estimates the
+// storage they need far better than summing their lengths. Block sizes of
+// zero or less leave the size unchanged, as do results that would overflow.
+// Filesystems that pack small files into shared blocks or store them
+// inline use less than this.
+func OnDisk(size, blockSize int64) Size {
+	if size <= 0 || blockSize <= 0 {
+		return Size(size)
+	}
+
+	blocks := ceilDiv(size, blockSize)
+	if blocks > (1<<63-1)/blockSize {
+		return Size(size)
+	}
+	return Size(blocks * blockSize)
+}
+
+// BlockSize returns the allocation unit of the filesystem holding path,
+// which may be a file or a directory
+//
+// On Unix systems this is the fragment size, f_frsize, falling back to
+// f_bsize when the kernel leaves it unset; Linux's f_bsize is only the
+// preferred I/O size, which NFS reports as its transfer size, often 1 MiB.
+// On Windows it is the cluster size. Alignment and on-disk estimates can so
+// use the real value rather than assuming 4096. Platforms without a way to
+// query it return an error.
+func BlockSize(path string) (Size, error) {
+	bs, err := blockSize(path)
+	if err != nil {
+		return 0, err
+	}
+	if bs <= 0 {
+		return 0, fmt.Errorf("invalid block size %d reported for %s", bs, path)
+	}
+	return Size(bs), nil
+}
+
+// OnDiskAt is like OnDisk using the block size of the filesystem holding
+// path, which may be the file itself or the directory it will be written to
+func OnDiskAt(path string, size int64) (Size, error) {
+	bs, err := BlockSize(path)
+	if err != nil {
+		return 0, err
+	}
+	return OnDisk(size, int64(bs)), nil
+}
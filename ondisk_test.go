@@ -0,0 +1,74 @@
+package filesize
+
+import (
+	"math"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestOnDisk tests rounding sizes up to whole blocks
+func TestOnDisk(t *testing.T) {
+	testCases := []struct {
+		size, blockSize int64
+		expected        Size
+	}{
+		{0, 4096, 0},
+		{1, 4096, 4096},
+		{4096, 4096, 4096},
+		{4097, 4096, 8192},
+		{10 * MiB, 4096, Size(10 * MiB)},
+		{1000, 512, 1024},
+		{1000, 0, 1000},
+		{-5, 4096, -5},
+		{math.MaxInt64, 4096, math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		if result := OnDisk(tc.size, tc.blockSize); result != tc.expected {
+			t.Errorf("OnDisk(%d, %d) = %d, expected %d", tc.size, tc.blockSize, int64(result), int64(tc.expected))
+		}
+	}
+}
+
+// TestOnDiskAt tests rounding with the temporary directory's block size
+func TestOnDiskAt(t *testing.T) {
+	size, err := OnDiskAt(t.TempDir(), 1)
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" || runtime.GOOS == "plan9" || runtime.GOOS == "aix" {
+		if err == nil {
+			t.Errorf("OnDiskAt() on %s expected error but got none", runtime.GOOS)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("OnDiskAt() unexpected error: %v", err)
+	}
+
+	// one byte takes a whole block, which is a power of two on every
+	// common filesystem
+	if size < 512 || size&(size-1) != 0 {
+		t.Errorf("OnDiskAt(1 byte) = %d, expected a power-of-two block size", int64(size))
+	}
+}
+
+// TestBlockSize tests querying the temporary directory's allocation unit
+func TestBlockSize(t *testing.T) {
+	dir := t.TempDir()
+	size, err := BlockSize(dir)
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" || runtime.GOOS == "plan9" || runtime.GOOS == "aix" {
+		if err == nil {
+			t.Errorf("BlockSize() on %s expected error but got none", runtime.GOOS)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("BlockSize() unexpected error: %v", err)
+	}
+	if size < 512 || size&(size-1) != 0 {
+		t.Errorf("BlockSize(%q) = %d, expected a power of two", dir, int64(size))
+	}
+
+	if _, err := BlockSize(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("BlockSize() of a missing path expected error but got none")
+	}
+}
@@ -0,0 +1,55 @@
+package filesize
+
+import (
+	"fmt"
+	"os"
+)
+
+// PageSize returns the memory page size of the running system, as
+// reported by os.Getpagesize
+func PageSize() Size {
+	return Size(os.Getpagesize())
+}
+
+// PageAlign rounds size up to a whole number of system pages, the length
+// mmap and shared-memory calls work in
+//
+// PageAlign(1) is one page and PageAlign(0) is 0. Negative sizes are
+// rejected, and sizes that cannot be rounded up within the int64 range
+// return ErrOverflow.
+func PageAlign(size int64) (Size, error) {
+	return PageAlignTo(size, int64(os.Getpagesize()))
+}
+
+// PagesFor returns the number of system pages needed to hold size bytes
+func PagesFor(size int64) (int64, error) {
+	return PageCount(size, int64(os.Getpagesize()))
+}
+
+// PageAlignTo is like PageAlign for an explicit page size, such as the
+// 16 KiB pages of Apple silicon or the 64 KiB pages of some arm64 kernels
+// when planning for a machine other than this one
+//
+// The page size must be a positive power of two.
+func PageAlignTo(size, pageSize int64) (Size, error) {
+	pages, err := PageCount(size, pageSize)
+	if err != nil {
+		return 0, err
+	}
+	if pages > (1<<63-1)/pageSize {
+		return 0, fmt.Errorf("page-aligning %d bytes: %w", size, ErrOverflow)
+	}
+	return Size(pages * pageSize), nil
+}
+
+// PageCount returns the number of pages of pageSize bytes needed to hold
+// size bytes, like PagesFor for an explicit page size
+func PageCount(size, pageSize int64) (int64, error) {
+	if !IsPowerOfTwo(pageSize) {
+		return 0, fmt.Errorf("invalid page size: %d", pageSize)
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("size cannot be negative: %d", size)
+	}
+	return ceilDiv(size, pageSize), nil
+}
@@ -0,0 +1,75 @@
+package filesize
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+// TestPageAlignTo tests rounding up to explicit page sizes
+func TestPageAlignTo(t *testing.T) {
+	testCases := []struct {
+		size, pageSize int64
+		expected       int64
+		pages          int64
+		hasError       bool
+	}{
+		{0, 4096, 0, 0, false},
+		{1, 4096, 4096, 1, false},
+		{4096, 4096, 4096, 1, false},
+		{4097, 4096, 8192, 2, false},
+		{10 * MiB, 16 * KiB, 10 * MiB, 640, false},
+		{100 * KiB, 64 * KiB, 128 * KiB, 2, false},
+		{math.MaxInt64 - 4095, 4096, math.MaxInt64 - 4095, 1<<51 - 1, false},
+
+		// invalid arguments
+		{-1, 4096, 0, 0, true},
+		{1, 0, 0, 0, true},
+		{1, -4096, 0, 0, true},
+		{1, 3000, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := PageAlignTo(tc.size, tc.pageSize)
+		pages, pagesErr := PageCount(tc.size, tc.pageSize)
+		if tc.hasError {
+			if err == nil || pagesErr == nil {
+				t.Errorf("PageAlignTo(%d, %d) expected error but got none", tc.size, tc.pageSize)
+			}
+			continue
+		}
+		if err != nil || pagesErr != nil {
+			t.Errorf("PageAlignTo(%d, %d) unexpected error: %v, %v", tc.size, tc.pageSize, err, pagesErr)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("PageAlignTo(%d, %d) = %d, expected %d", tc.size, tc.pageSize, int64(result), tc.expected)
+		}
+		if pages != tc.pages {
+			t.Errorf("PageCount(%d, %d) = %d, expected %d", tc.size, tc.pageSize, pages, tc.pages)
+		}
+	}
+
+	if _, err := PageAlignTo(math.MaxInt64, 4096); !errors.Is(err, ErrOverflow) {
+		t.Errorf("PageAlignTo(MaxInt64, 4096) error = %v, expected ErrOverflow", err)
+	}
+}
+
+// TestPageAlign tests rounding with the system page size
+func TestPageAlign(t *testing.T) {
+	pageSize := int64(os.Getpagesize())
+	if PageSize() != Size(pageSize) {
+		t.Errorf("PageSize() = %d, expected %d", int64(PageSize()), pageSize)
+	}
+
+	result, err := PageAlign(pageSize + 1)
+	if err != nil || int64(result) != 2*pageSize {
+		t.Errorf("PageAlign(%d) = %d, %v, expected %d", pageSize+1, int64(result), err, 2*pageSize)
+	}
+
+	pages, err := PagesFor(3*pageSize - 1)
+	if err != nil || pages != 3 {
+		t.Errorf("PagesFor(%d) = %d, %v, expected 3", 3*pageSize-1, pages, err)
+	}
+}
@@ -0,0 +1,124 @@
+package filesize
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// Parser parses size strings against its own set of units
+//
+// A new Parser understands every unit ParseSize does, and domain specific
+// units can be added with RegisterUnit without affecting ParseSize or other
+// Parser instances. Register units before sharing a Parser between
+// goroutines; concurrent calls to Parse are safe once registration is done.
+type Parser struct {
+	// units maps lowercase unit names and aliases to their definitions
+	units map[string]UnitInfo
+
+	// custom lists registered units in registration order
+	custom []UnitInfo
+}
+
+// NewParser returns a Parser that accepts the units of the given systems
+//
+// Without arguments the Parser accepts the same units as ParseSize. When
+// several systems define the same spelling, the first system wins, so
+// NewParser(JEDEC, DefaultUnits) reads "KB" as 1024 bytes while still
+// accepting "KiB" and friends.
+func NewParser(systems ...UnitSystem) *Parser {
+	if len(systems) == 0 {
+		return &Parser{units: maps.Clone(unitMap)}
+	}
+
+	p := &Parser{units: make(map[string]UnitInfo)}
+	for _, sys := range systems {
+		for key, unit := range sys.lookup {
+			if _, exists := p.units[key]; !exists {
+				p.units[key] = unit
+			}
+		}
+	}
+	return p
+}
+
+// RegisterUnit adds a custom unit, e.g. "blk" for 512-byte blocks
+//
+// The name and aliases are matched case-insensitively and must consist of
+// ASCII letters only. Registering a name that is already known to the Parser,
+// including the built-in units, is an error.
+func (p *Parser) RegisterUnit(name string, multiplier int64, aliases ...string) error {
+	if multiplier <= 0 {
+		return fmt.Errorf("invalid multiplier for unit %s: %d", name, multiplier)
+	}
+
+	// validate every spelling before changing anything
+	names := append([]string{name}, aliases...)
+	for i, unitName := range names {
+		if !isUnitName(unitName) {
+			return fmt.Errorf("invalid unit name: %q", unitName)
+		}
+		key := strings.ToLower(unitName)
+		if _, exists := p.units[key]; exists {
+			return fmt.Errorf("unit already defined: %s", unitName)
+		}
+		for _, earlier := range names[:i] {
+			if strings.EqualFold(earlier, unitName) {
+				return fmt.Errorf("unit already defined: %s", unitName)
+			}
+		}
+	}
+
+	unit := UnitInfo{
+		Name:       name,
+		Aliases:    append([]string(nil), aliases...),
+		Multiplier: multiplier,
+		Convention: ConventionCustom,
+	}
+	for _, unitName := range names {
+		p.units[strings.ToLower(unitName)] = unit
+	}
+	p.custom = append(p.custom, unit)
+
+	return nil
+}
+
+// Parse converts a size string to bytes using the Parser's units
+//
+// It accepts the same syntax as ParseSize.
+func (p *Parser) Parse(sizeStr string) (int64, error) {
+	return parseWithUnits(sizeStr, p.units)
+}
+
+// ParseWithUnit parses a size string and also returns the number, unit and
+// multiplier as written, see ParseSizeWithUnit
+func (p *Parser) ParseWithUnit(sizeStr string) (value float64, unit string, multiplier int64, err error) {
+	return parseWithUnitDetails(sizeStr, p.units)
+}
+
+// CustomUnits returns the units added with RegisterUnit in registration order
+//
+// Pass the result to WithCustomUnits to let formatting choose them as well.
+func (p *Parser) CustomUnits() []UnitInfo {
+	units := make([]UnitInfo, len(p.custom))
+	for i, unit := range p.custom {
+		units[i] = unit
+		units[i].Aliases = append([]string(nil), unit.Aliases...)
+	}
+	return units
+}
+
+// isUnitName reports whether s is a non-empty string of ASCII letters, the
+// only characters the size syntax allows in a unit
+func isUnitName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
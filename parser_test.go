@@ -0,0 +1,116 @@
+package filesize
+
+import (
+	"testing"
+)
+
+// TestParser_RegisterUnit tests parsing with custom units
+func TestParser_RegisterUnit(t *testing.T) {
+	p := NewParser()
+	if err := p.RegisterUnit("blk", 512, "block", "blocks"); err != nil {
+		t.Fatalf("RegisterUnit(blk) unexpected error: %v", err)
+	}
+	if err := p.RegisterUnit("seg", 8*MiB); err != nil {
+		t.Fatalf("RegisterUnit(seg) unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{"1blk", 512, false},
+		{"4 BLK", 2048, false},
+		{"8blocks", 4096, false},
+		{"2seg", 16 * 1024 * 1024, false},
+		{"1.5seg", 12 * 1024 * 1024, false},
+
+		// built-in units keep working
+		{"4k", 4096, false},
+		{"1KB", 1000, false},
+
+		{"1xy", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := p.Parse(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("Parse(%q) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+
+	// custom units are reported as written
+	value, unit, multiplier, err := p.ParseWithUnit("3 Blk")
+	if err != nil || value != 3 || unit != "Blk" || multiplier != 512 {
+		t.Errorf("ParseWithUnit(%q) = (%g, %q, %d, %v), expected (3, %q, 512, nil)", "3 Blk", value, unit, multiplier, err, "Blk")
+	}
+
+	// registration must not leak into the package-level parser
+	if _, err := ParseSize("1blk"); err == nil {
+		t.Errorf("ParseSize(%q) accepted a unit registered on a Parser", "1blk")
+	}
+	if _, err := NewParser().Parse("1blk"); err == nil {
+		t.Errorf("NewParser().Parse(%q) accepted a unit registered on another Parser", "1blk")
+	}
+}
+
+// TestParser_RegisterUnitErrors tests invalid registrations
+func TestParser_RegisterUnitErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		multiplier int64
+		aliases    []string
+	}{
+		{"", 512, nil},
+		{"blk2", 512, nil},
+		{"blk", 0, nil},
+		{"blk", -1, nil},
+		{"KiB", 1024, nil},
+		{"blk", 512, []string{"k"}},
+		{"blk", 512, []string{"BLK"}},
+	}
+
+	for _, tc := range testCases {
+		p := NewParser()
+		if err := p.RegisterUnit(tc.name, tc.multiplier, tc.aliases...); err == nil {
+			t.Errorf("RegisterUnit(%q, %d, %q) expected error but got none", tc.name, tc.multiplier, tc.aliases)
+		}
+		if len(p.CustomUnits()) != 0 {
+			t.Errorf("RegisterUnit(%q, %d, %q) registered a unit despite failing", tc.name, tc.multiplier, tc.aliases)
+		}
+	}
+}
+
+// TestParser_CustomUnitFormatting tests formatting with registered units
+func TestParser_CustomUnitFormatting(t *testing.T) {
+	p := NewParser()
+	_ = p.RegisterUnit("seg", 8*MiB)
+	_ = p.RegisterUnit("kibi", KiB)
+
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{16 * MiB, "2.00 seg"},
+		{4 * MiB, "4.00 MiB"},
+		{2 * GiB, "2.00 GiB"},
+		{1024, "1.00 KiB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithCustomUnits(p.CustomUnits()...))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithCustomUnits(...)) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
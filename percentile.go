@@ -0,0 +1,56 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Percentiles returns the given percentiles of sizes, such as p50, p90 and
+// p99 of object sizes: Percentiles(sizes, 50, 90, 99)
+//
+// Values between two sizes are interpolated linearly, the default method
+// of numpy.percentile and most spreadsheets, and rounded to the nearest
+// byte, so results line up with analyses done elsewhere. Percentiles must
+// lie in [0, 100]; sizes must not be empty and is not modified.
+func Percentiles(sizes []int64, ps ...float64) ([]Size, error) {
+	if len(sizes) == 0 {
+		return nil, errors.New("percentiles of no sizes")
+	}
+	for _, p := range ps {
+		if !(p >= 0 && p <= 100) {
+			return nil, fmt.Errorf("invalid percentile: %g", p)
+		}
+	}
+
+	sorted := slices.Clone(sizes)
+	slices.Sort(sorted)
+
+	results := make([]Size, len(ps))
+	for i, p := range ps {
+		results[i] = Size(percentileOf(sorted, p))
+	}
+	return results, nil
+}
+
+// Percentiles returns percentiles of the sizes like the package-level
+// Percentiles
+func (s SizeSlice) Percentiles(ps ...float64) ([]Size, error) {
+	return Percentiles(s.Int64s(), ps...)
+}
+
+// percentileOf interpolates the p-th percentile of sorted values
+func percentileOf(sorted []int64, p float64) int64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	if lo >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+
+	// the gap is computed unsigned, since it can exceed the int64 range
+	a, b := sorted[lo], sorted[lo+1]
+	gap := uint64(b) - uint64(a)
+	step := uint64(math.Round(float64(gap) * (rank - float64(lo))))
+	return int64(uint64(a) + min(step, gap))
+}
@@ -0,0 +1,66 @@
+package filesize
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestPercentiles tests linear interpolation between sizes
+func TestPercentiles(t *testing.T) {
+	sizes := []int64{40, 10, 30, 20, 50}
+
+	testCases := []struct {
+		ps       []float64
+		expected []Size
+	}{
+		{[]float64{0, 100}, []Size{10, 50}},
+		{[]float64{50}, []Size{30}},
+		{[]float64{25, 75}, []Size{20, 40}},
+		{[]float64{90, 99}, []Size{46, 50}},
+		{[]float64{10}, []Size{14}},
+		{nil, []Size{}},
+	}
+
+	for _, tc := range testCases {
+		result, err := Percentiles(sizes, tc.ps...)
+		if err != nil {
+			t.Errorf("Percentiles(%v) unexpected error: %v", tc.ps, err)
+			continue
+		}
+		if !slices.Equal(result, tc.expected) {
+			t.Errorf("Percentiles(%v) = %v, expected %v", tc.ps, result, tc.expected)
+		}
+	}
+
+	if !slices.Equal(sizes, []int64{40, 10, 30, 20, 50}) {
+		t.Errorf("Percentiles() reordered its input to %v", sizes)
+	}
+}
+
+// TestPercentiles_Edges tests single values, extreme ranges and errors
+func TestPercentiles_Edges(t *testing.T) {
+	result, err := Percentiles([]int64{KiB}, 0, 50, 99)
+	if err != nil || !slices.Equal(result, []Size{Size(KiB), Size(KiB), Size(KiB)}) {
+		t.Errorf("Percentiles(single) = %v, %v, expected all 1 KiB", result, err)
+	}
+
+	result, err = Percentiles([]int64{math.MinInt64, math.MaxInt64}, 50)
+	if err != nil || result[0] != 0 {
+		t.Errorf("Percentiles(full range, 50) = %v, %v, expected [0]", result, err)
+	}
+
+	result, err = SizeSlice{Size(MiB), Size(KiB)}.Percentiles(50)
+	if err != nil || result[0] != Size((MiB+KiB)/2) {
+		t.Errorf("SizeSlice.Percentiles(50) = %v, %v, expected %d", result, err, (MiB+KiB)/2)
+	}
+
+	for _, ps := range [][]float64{{-1}, {100.5}, {math.NaN()}} {
+		if _, err := Percentiles([]int64{1, 2}, ps...); err == nil {
+			t.Errorf("Percentiles(%v) expected error but got none", ps)
+		}
+	}
+	if _, err := Percentiles(nil, 50); err == nil {
+		t.Errorf("Percentiles(nil) expected error but got none")
+	}
+}
@@ -0,0 +1,26 @@
+package pflagsize_test
+
+import (
+	"fmt"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/jessegalley/go-filesize/pflagsize"
+	"github.com/spf13/cobra"
+)
+
+// Example shows a cobra command with a size flag and unit completion
+func Example() {
+	var cacheSize filesize.Size
+	cmd := &cobra.Command{
+		Use: "serve",
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Println("cache size:", cacheSize)
+		},
+	}
+	pflagsize.SizeVarP(cmd.Flags(), &cacheSize, "cache-size", "c", 512*filesize.MiB, "cache size")
+	_ = pflagsize.RegisterCompletion(cmd, "cache-size")
+
+	cmd.SetArgs([]string{"-c", "1.5GiB"})
+	_ = cmd.Execute()
+	// Output: cache size: 1.50 GiB
+}
@@ -0,0 +1,114 @@
+// Package pflagsize declares human-readable size flags for spf13/pflag and
+// cobra commands.
+//
+// The helpers take the flag set explicitly, since cobra commands each own
+// theirs:
+//
+//	var cacheSize filesize.Size
+//	pflagsize.SizeVarP(cmd.Flags(), &cacheSize, "cache-size", "c", 512*filesize.MiB, "cache size")
+//	pflagsize.RegisterCompletion(cmd, "cache-size")
+//
+// Flags accept anything filesize.ParseSize does, show "size" as their
+// placeholder and their default in humanized form in help output, and can
+// offer unit suffixes through shell completion. Rate, RateP, RateVar and
+// RateVarP declare bandwidth flags such as "--bandwidth 10MB/s" the same way.
+package pflagsize
+
+import (
+	"strings"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Size defines a size flag on fs and returns a pointer to its value
+func Size(fs *pflag.FlagSet, name string, value int64, usage string) *filesize.Size {
+	return SizeP(fs, name, "", value, usage)
+}
+
+// SizeP is like Size but also takes a one-letter shorthand
+func SizeP(fs *pflag.FlagSet, name, shorthand string, value int64, usage string) *filesize.Size {
+	p := new(filesize.Size)
+	SizeVarP(fs, p, name, shorthand, value, usage)
+	return p
+}
+
+// SizeVar defines a size flag on fs that stores into p
+func SizeVar(fs *pflag.FlagSet, p *filesize.Size, name string, value int64, usage string) {
+	SizeVarP(fs, p, name, "", value, usage)
+}
+
+// SizeVarP is like SizeVar but also takes a one-letter shorthand
+func SizeVarP(fs *pflag.FlagSet, p *filesize.Size, name, shorthand string, value int64, usage string) {
+	fs.VarP(filesize.NewSizeValue(filesize.Size(value), p), name, shorthand, usage)
+}
+
+// Rate defines a rate flag on fs and returns a pointer to its value
+func Rate(fs *pflag.FlagSet, name string, value filesize.Rate, usage string) *filesize.Rate {
+	return RateP(fs, name, "", value, usage)
+}
+
+// RateP is like Rate but also takes a one-letter shorthand
+func RateP(fs *pflag.FlagSet, name, shorthand string, value filesize.Rate, usage string) *filesize.Rate {
+	p := new(filesize.Rate)
+	RateVarP(fs, p, name, shorthand, value, usage)
+	return p
+}
+
+// RateVar defines a rate flag on fs that stores into p
+func RateVar(fs *pflag.FlagSet, p *filesize.Rate, name string, value filesize.Rate, usage string) {
+	RateVarP(fs, p, name, "", value, usage)
+}
+
+// RateVarP is like RateVar but also takes a one-letter shorthand
+func RateVarP(fs *pflag.FlagSet, p *filesize.Rate, name, shorthand string, value filesize.Rate, usage string) {
+	fs.VarP(filesize.NewRateValue(value, p), name, shorthand, usage)
+}
+
+// completionUnits are the suffixes offered by Complete, most common first
+var completionUnits = []string{
+	"k", "m", "g", "t",
+	"KiB", "MiB", "GiB", "TiB",
+	"KB", "MB", "GB", "TB",
+}
+
+// Complete is a cobra completion function for size flags
+//
+// Once the user has typed a number it suggests that number with each common
+// unit suffix, narrowing the list as a unit is typed, so "4G<TAB>" offers
+// "4GiB" and "4GB". File completion is always disabled.
+func Complete(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	// split the typed text into its number and partial unit
+	i := 0
+	for i < len(toComplete) && (toComplete[i] >= '0' && toComplete[i] <= '9' || toComplete[i] == '.') {
+		i++
+	}
+	number, partial := toComplete[:i], toComplete[i:]
+	if number == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []cobra.Completion
+	for _, unit := range completionUnits {
+		if strings.HasPrefix(unit, partial) {
+			completions = append(completions, number+unit)
+		}
+	}
+
+	// fall back to a case-insensitive match for inputs like "4gi"
+	if len(completions) == 0 {
+		for _, unit := range completionUnits {
+			if len(unit) >= len(partial) && strings.EqualFold(unit[:len(partial)], partial) {
+				completions = append(completions, number+unit)
+			}
+		}
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// RegisterCompletion registers Complete for the named flag of cmd
+func RegisterCompletion(cmd *cobra.Command, name string) error {
+	return cmd.RegisterFlagCompletionFunc(name, Complete)
+}
@@ -0,0 +1,175 @@
+package pflagsize
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	filesize "github.com/jessegalley/go-filesize"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestSizeVarP tests declaring and parsing size flags on a pflag FlagSet
+func TestSizeVarP(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected filesize.Size
+		hasError bool
+	}{
+		{nil, filesize.Size(512 * filesize.MiB), false},
+		{[]string{"--cache-size", "1g"}, filesize.Size(filesize.GiB), false},
+		{[]string{"-c", "4k"}, 4096, false},
+		{[]string{"-c=2GB"}, filesize.Size(2 * filesize.GB), false},
+		{[]string{"--cache-size", "-1k"}, 0, true},
+		{[]string{"--cache-size", "big"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+
+		var size filesize.Size
+		SizeVarP(fs, &size, "cache-size", "c", 512*filesize.MiB, "cache size")
+
+		err := fs.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if size != tc.expected {
+			t.Errorf("Parse(%q) = %d, expected %d", tc.args, int64(size), int64(tc.expected))
+		}
+	}
+}
+
+// TestSize_Usage tests the placeholder and humanized default in help output
+func TestSize_Usage(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	Size(fs, "buffer", 64*filesize.KiB, "buffer size")
+
+	usage := fs.FlagUsages()
+	if !strings.Contains(usage, "--buffer size") || !strings.Contains(usage, "(default 64KiB)") {
+		t.Errorf("FlagUsages() = %q, expected placeholder size and default 64KiB", usage)
+	}
+}
+
+// TestComplete tests unit suffix suggestions
+func TestComplete(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"4G", []string{"4GiB", "4GB"}},
+		{"4gi", []string{"4GiB"}},
+		{"1.5M", []string{"1.5MiB", "1.5MB"}},
+		{"4k", []string{"4k"}},
+		{"4x", nil},
+	}
+
+	for _, tc := range testCases {
+		completions, directive := Complete(nil, nil, tc.input)
+		if !slices.Equal(completions, tc.expected) {
+			t.Errorf("Complete(%q) = %q, expected %q", tc.input, completions, tc.expected)
+		}
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("Complete(%q) directive = %v, expected NoFileComp", tc.input, directive)
+		}
+	}
+
+	completions, _ := Complete(nil, nil, "4")
+	if len(completions) != len(completionUnits) || completions[0] != "4k" {
+		t.Errorf("Complete(%q) = %q, expected every unit starting with 4k", "4", completions)
+	}
+}
+
+// TestCobraCommand tests a size flag on a cobra command end to end
+func TestCobraCommand(t *testing.T) {
+	var cacheSize filesize.Size
+	cmd := &cobra.Command{
+		Use: "serve",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	SizeVarP(cmd.Flags(), &cacheSize, "cache-size", "c", 512*filesize.MiB, "cache size")
+	if err := RegisterCompletion(cmd, "cache-size"); err != nil {
+		t.Fatalf("RegisterCompletion() unexpected error: %v", err)
+	}
+
+	cmd.SetArgs([]string{"--cache-size", "2GiB"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if cacheSize != filesize.Size(2*filesize.GiB) {
+		t.Errorf("Execute() cache size = %d, expected %d", int64(cacheSize), 2*filesize.GiB)
+	}
+
+	// cobra answers completion requests through a hidden command
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{cobra.ShellCompRequestCmd, "--cache-size", "8G"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute(__complete) unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "8GiB\n8GB\n") {
+		t.Errorf("completion output = %q, expected 8GiB and 8GB", out.String())
+	}
+}
+
+// TestRateVarP tests declaring and parsing rate flags on a pflag FlagSet
+func TestRateVarP(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected filesize.Rate
+		hasError bool
+	}{
+		{nil, filesize.PerSecond(10 * filesize.MB), false},
+		{[]string{"--bandwidth", "5MiB/s"}, filesize.PerSecond(5 * filesize.MiB), false},
+		{[]string{"-b=100GB/month"}, filesize.Rate{Bytes: filesize.Size(100 * filesize.GB), Per: 730 * time.Hour}, false},
+		{[]string{"--bandwidth", "fast"}, filesize.Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+
+		var rate filesize.Rate
+		RateVarP(fs, &rate, "bandwidth", "b", filesize.PerSecond(10*filesize.MB), "bandwidth limit")
+
+		err := fs.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if rate != tc.expected {
+			t.Errorf("Parse(%q) = %v, expected %v", tc.args, rate, tc.expected)
+		}
+	}
+}
+
+// TestRate_Usage tests the placeholder and default in help output
+func TestRate_Usage(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	Rate(fs, "bandwidth", filesize.PerSecond(10*filesize.MiB), "bandwidth limit")
+
+	usage := fs.FlagUsages()
+	if !strings.Contains(usage, "--bandwidth rate") || !strings.Contains(usage, "(default 10MiB/s)") {
+		t.Errorf("FlagUsages() = %q, expected placeholder rate and default 10MB/s", usage)
+	}
+}
@@ -0,0 +1,43 @@
+package filesize
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// maxPowerOfTwo is the largest power of two that fits in an int64
+const maxPowerOfTwo = 1 << 62
+
+// IsPowerOfTwo reports whether size is a power of two, as buffer pools,
+// ring buffers and stripe sizes must be
+//
+// Zero and negative sizes are not powers of two; 1 is.
+func IsPowerOfTwo(size int64) bool {
+	return size > 0 && size&(size-1) == 0
+}
+
+// NextPowerOfTwo returns the smallest power of two at or above size, so a
+// power of two is returned unchanged, e.g. NextPowerOfTwo(3000) is 4 KiB
+//
+// Sizes below 1 round up to 1. Sizes above 4 EiB have no power of two in
+// the int64 range and return ErrOverflow.
+func NextPowerOfTwo(size int64) (Size, error) {
+	switch {
+	case size <= 1:
+		return 1, nil
+	case size > maxPowerOfTwo:
+		return 0, fmt.Errorf("next power of two above %d: %w", size, ErrOverflow)
+	}
+	return Size(1) << bits.Len64(uint64(size-1)), nil
+}
+
+// PrevPowerOfTwo returns the largest power of two at or below size, so a
+// power of two is returned unchanged, e.g. PrevPowerOfTwo(3000) is 2 KiB
+//
+// Sizes below 1 have no power of two beneath them and return an error.
+func PrevPowerOfTwo(size int64) (Size, error) {
+	if size < 1 {
+		return 0, fmt.Errorf("no power of two at or below %d", size)
+	}
+	return Size(1) << (bits.Len64(uint64(size)) - 1), nil
+}
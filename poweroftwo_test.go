@@ -0,0 +1,105 @@
+package filesize
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestIsPowerOfTwo tests power-of-two detection
+func TestIsPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected bool
+	}{
+		{1, true},
+		{2, true},
+		{4 * KiB, true},
+		{1 << 62, true},
+		{0, false},
+		{-4, false},
+		{math.MinInt64, false},
+		{3, false},
+		{3 * KiB, false},
+		{math.MaxInt64, false},
+	}
+
+	for _, tc := range testCases {
+		if result := IsPowerOfTwo(tc.input); result != tc.expected {
+			t.Errorf("IsPowerOfTwo(%d) = %v, expected %v", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestNextPowerOfTwo tests rounding up to a power of two
+func TestNextPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected Size
+		hasError bool
+	}{
+		{3000, Size(4 * KiB), false},
+		{4 * KiB, Size(4 * KiB), false},
+		{4*KiB + 1, Size(8 * KiB), false},
+		{1, 1, false},
+		{2, 2, false},
+		{3, 4, false},
+		{0, 1, false},
+		{-KiB, 1, false},
+		{1 << 62, 1 << 62, false},
+		{1<<62 + 1, 0, true},
+		{math.MaxInt64, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := NextPowerOfTwo(tc.input)
+		if tc.hasError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("NextPowerOfTwo(%d) error = %v, expected ErrOverflow", tc.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NextPowerOfTwo(%d) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("NextPowerOfTwo(%d) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestPrevPowerOfTwo tests rounding down to a power of two
+func TestPrevPowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected Size
+		hasError bool
+	}{
+		{3000, Size(2 * KiB), false},
+		{4 * KiB, Size(4 * KiB), false},
+		{4*KiB - 1, Size(2 * KiB), false},
+		{1, 1, false},
+		{3, 2, false},
+		{math.MaxInt64, 1 << 62, false},
+		{0, 0, true},
+		{-KiB, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := PrevPowerOfTwo(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("PrevPowerOfTwo(%d) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("PrevPowerOfTwo(%d) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("PrevPowerOfTwo(%d) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package filesize
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// progressWindow is the trailing window a Progress estimates its rate over
+const progressWindow = 10 * time.Second
+
+// Progress tracks a transfer against an expected total and reports it
+//
+// Bytes are counted with Add, by writing to the Progress (for example as the
+// second writer of io.TeeReader) or by reading through Reader. The rate is
+// estimated over the last ten seconds. The callback receives a
+// ProgressUpdate whose String method renders lines such as
+// "312 MiB / 2.00 GiB (15%, 48.0 MiB/s, ETA 36s)". A Progress is safe for
+// concurrent use.
+type Progress struct {
+	mu       sync.Mutex
+	total    Size
+	every    time.Duration
+	fn       func(ProgressUpdate)
+	meter    *ThroughputMeter
+	reported time.Time
+
+	// now is the clock, replaceable in tests
+	now func() time.Time
+}
+
+// ProgressUpdate is a snapshot of a transfer's progress
+type ProgressUpdate struct {
+	// Done is the number of bytes transferred so far
+	Done Size
+
+	// Total is the expected size, zero or less when unknown
+	Total Size
+
+	// Rate is the recent transfer rate
+	Rate Rate
+
+	// ETA is the estimated time remaining, negative when unknown
+	ETA time.Duration
+}
+
+// NewProgress returns a Progress expecting total bytes that calls fn at most
+// once per every
+//
+// A total of zero or less means the size is unknown, leaving the percentage
+// and ETA out. With every zero or less fn is called on each update. Finish
+// always reports, so the final state is never throttled away.
+func NewProgress(total int64, every time.Duration, fn func(ProgressUpdate)) *Progress {
+	return newProgress(total, every, fn, time.Now)
+}
+
+// newProgress returns a Progress reading time from now
+func newProgress(total int64, every time.Duration, fn func(ProgressUpdate), now func() time.Time) *Progress {
+	return &Progress{
+		total: Size(total),
+		every: every,
+		fn:    fn,
+		meter: newThroughputMeter(progressWindow, now),
+		now:   now,
+	}
+}
+
+// Add records n transferred bytes and reports if the interval has passed
+func (p *Progress) Add(n int64) {
+	p.meter.Add(n)
+
+	p.mu.Lock()
+	now := p.now()
+	due := p.every <= 0 || p.reported.IsZero() || now.Sub(p.reported) >= p.every
+	if due {
+		p.reported = now
+	}
+	p.mu.Unlock()
+
+	if due && p.fn != nil {
+		p.fn(p.Update())
+	}
+}
+
+// Write implements io.Writer by counting len(b) bytes
+func (p *Progress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Reader returns a reader that counts everything read from r
+func (p *Progress) Reader(r io.Reader) io.Reader {
+	return io.TeeReader(r, p)
+}
+
+// Finish reports the current state regardless of the interval
+func (p *Progress) Finish() {
+	if p.fn != nil {
+		p.fn(p.Update())
+	}
+}
+
+// Update returns a snapshot of the current progress
+func (p *Progress) Update() ProgressUpdate {
+	update := ProgressUpdate{
+		Done:  Size(p.meter.Total()),
+		Total: p.total,
+		Rate:  p.meter.Rate(),
+		ETA:   -1,
+	}
+
+	if update.Total > 0 && update.Rate.Bytes > 0 {
+		remaining := max(int64(update.Total-update.Done), 0)
+		update.ETA = TransferTime(remaining, update.Rate)
+	}
+	return update
+}
+
+// Percent returns how much of the total is done, from 0 to 100, or -1 when
+// the total is unknown
+func (u ProgressUpdate) Percent() float64 {
+	if u.Total <= 0 {
+		return -1
+	}
+	return min(float64(u.Done)/float64(u.Total)*100, 100)
+}
+
+// String formats the update, e.g.
+// "312 MiB / 2.00 GiB (15%, 48.0 MiB/s, ETA 36s)"
+//
+// Parts that are unknown are left out, so a transfer of unknown size reads
+// "312 MiB (48.0 MiB/s)".
+func (u ProgressUpdate) String() string {
+	var buf [formatBufferSize * 4]byte
+	dst := append(buf[:0], u.Done.String()...)
+	if u.Total > 0 {
+		dst = append(dst, " / "...)
+		dst = append(dst, u.Total.String()...)
+	}
+
+	dst = append(dst, " ("...)
+	if percent := u.Percent(); percent >= 0 {
+		dst = strconv.AppendFloat(dst, percent, 'f', 0, 64)
+		dst = append(dst, "%, "...)
+	}
+	dst = append(dst, FormatRate(u.Rate.BytesPerSecond())...)
+	if u.ETA >= 0 {
+		dst = append(dst, ", ETA "...)
+		dst = append(dst, u.ETA.Round(time.Second).String()...)
+	}
+	return string(append(dst, ')'))
+}
@@ -0,0 +1,74 @@
+package filesize
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// TestProgress tests the reported values and callback throttling
+func TestProgress(t *testing.T) {
+	clock := newFakeClock()
+	var updates []ProgressUpdate
+	p := newProgress(2*GiB, time.Second, func(u ProgressUpdate) {
+		updates = append(updates, u)
+	}, clock.Now)
+
+	// 24 MiB every half second is 48 MiB/s
+	for i := 0; i < 13; i++ {
+		clock.Advance(500 * time.Millisecond)
+		p.Add(24 * MiB)
+	}
+	if len(updates) != 7 {
+		t.Errorf("callback ran %d times, expected 7 with a one second interval", len(updates))
+	}
+
+	p.Finish()
+	last := updates[len(updates)-1]
+	expected := "312 MiB / 2.00 GiB (15%, 48.0 MiB/s, ETA 36s)"
+	if last.String() != expected {
+		t.Errorf("ProgressUpdate.String() = %q, expected %q", last.String(), expected)
+	}
+	if last.Done != Size(312*MiB) || last.Total != Size(2*GiB) {
+		t.Errorf("ProgressUpdate = %+v, expected 312 MiB of 2 GiB done", last)
+	}
+}
+
+// TestProgress_UnknownTotal tests output without a total
+func TestProgress_UnknownTotal(t *testing.T) {
+	clock := newFakeClock()
+	p := newProgress(0, 0, nil, clock.Now)
+
+	if update := p.Update(); update.String() != "0 B (0 B/s)" {
+		t.Errorf("Update().String() = %q, expected %q", update.String(), "0 B (0 B/s)")
+	}
+
+	clock.Advance(2 * time.Second)
+	p.Add(4 * MiB)
+	update := p.Update()
+	if update.String() != "4.00 MiB (2.00 MiB/s)" {
+		t.Errorf("Update().String() = %q, expected %q", update.String(), "4.00 MiB (2.00 MiB/s)")
+	}
+	if update.Percent() != -1 || update.ETA >= 0 {
+		t.Errorf("Update() = %+v, expected unknown percent and ETA", update)
+	}
+}
+
+// TestProgress_Reader tests counting through a wrapped reader
+func TestProgress_Reader(t *testing.T) {
+	calls := 0
+	p := NewProgress(MiB, 0, func(ProgressUpdate) { calls++ })
+
+	if _, err := io.Copy(io.Discard, p.Reader(bytes.NewReader(make([]byte, MiB)))); err != nil {
+		t.Fatalf("io.Copy() unexpected error: %v", err)
+	}
+
+	update := p.Update()
+	if update.Done != Size(MiB) || update.Percent() != 100 {
+		t.Errorf("Update() = %+v, expected the full MiB done", update)
+	}
+	if calls == 0 {
+		t.Errorf("callback never ran, expected one call per read")
+	}
+}
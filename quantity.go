@@ -0,0 +1,152 @@
+package filesize
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// quantitySuffixes maps Kubernetes quantity suffixes to their base and
+// exponent, so "Mi" is 2^20 and "m" is 10^-3
+var quantitySuffixes = map[string]struct{ base, exp int64 }{
+	"":   {10, 0},
+	"n":  {10, -9},
+	"u":  {10, -6},
+	"m":  {10, -3},
+	"k":  {10, 3},
+	"M":  {10, 6},
+	"G":  {10, 9},
+	"T":  {10, 12},
+	"P":  {10, 15},
+	"E":  {10, 18},
+	"Ki": {2, 10},
+	"Mi": {2, 20},
+	"Gi": {2, 30},
+	"Ti": {2, 40},
+	"Pi": {2, 50},
+	"Ei": {2, 60},
+}
+
+// quantityUnits are the suffixes FormatQuantity tries, binary first so that
+// they win ties
+var quantityUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"Ei", EiB}, {"Pi", PiB}, {"Ti", TiB}, {"Gi", GiB}, {"Mi", MiB}, {"Ki", KiB},
+	{"E", EB}, {"P", PB}, {"T", TB}, {"G", GB}, {"M", MB}, {"k", KB},
+}
+
+// ParseQuantity parses a Kubernetes resource quantity such as "512Mi",
+// "1.5Gi", "2G" or "129e6" into bytes
+//
+// It accepts the full quantity syntax: a signed decimal number followed by
+// a binary suffix (Ki to Ei), a decimal suffix (n, u, m, k, M to E) or a
+// decimal exponent (e3, E-2). Like Quantity.Value in Kubernetes, fractional
+// byte counts are rounded up, so "100m" is 1 byte. Values beyond the int64
+// range return ErrOverflow.
+func ParseQuantity(s string) (Size, error) {
+	// split the sign, digits and suffix
+	i := 0
+	negative := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		negative = s[i] == '-'
+		i++
+	}
+	intEnd := scanDigits(s, i)
+	mantissa := s[i:intEnd]
+	fraction := ""
+	end := intEnd
+	if end < len(s) && s[end] == '.' {
+		end = scanDigits(s, end+1)
+		fraction = s[intEnd+1 : end]
+	}
+	if mantissa == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid quantity: %q", s)
+	}
+
+	base, exp, err := quantityScale(s[end:])
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity: %q", s)
+	}
+
+	// value = digits * base^exp / 10^len(fraction), rounded up
+	num, _ := new(big.Int).SetString(mantissa+fraction, 10)
+	den := bigPow(10, int64(len(fraction)))
+	if exp >= 0 {
+		num.Mul(num, bigPow(base, exp))
+	} else {
+		den.Mul(den, bigPow(base, -exp))
+	}
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if negative {
+		quo.Neg(quo)
+	}
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("quantity %q: %w", s, ErrOverflow)
+	}
+	return Size(quo.Int64()), nil
+}
+
+// quantityScale returns the base and exponent a quantity suffix stands for
+func quantityScale(suffix string) (base, exp int64, err error) {
+	if scale, ok := quantitySuffixes[suffix]; ok {
+		return scale.base, scale.exp, nil
+	}
+	if len(suffix) < 2 || (suffix[0] != 'e' && suffix[0] != 'E') {
+		return 0, 0, fmt.Errorf("unknown suffix: %q", suffix)
+	}
+
+	// exponents past a few dozen cannot give a representable nonzero
+	// byte count, so bound them before building big powers
+	exp, err = strconv.ParseInt(suffix[1:], 10, 64)
+	if err != nil || exp < -64 || exp > 64 {
+		return 0, 0, fmt.Errorf("invalid exponent: %q", suffix)
+	}
+	return 10, exp, nil
+}
+
+// FormatQuantity formats bytes as the most compact Kubernetes quantity
+// that represents it exactly
+//
+// Binary suffixes are preferred when they are as short as the decimal
+// ones, so 2 GiB is "2Gi", 1536 MiB is "1536Mi", 1e9 is "1G" and 1000001
+// is "1000001". The result always parses back with ParseQuantity.
+func FormatQuantity(bytes int64) string {
+	best := strconv.FormatInt(bytes, 10)
+	if bytes == 0 {
+		return best
+	}
+
+	for _, unit := range quantityUnits {
+		if bytes%unit.multiplier != 0 {
+			continue
+		}
+		if candidate := strconv.FormatInt(bytes/unit.multiplier, 10) + unit.suffix; len(candidate) < len(best) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// FormatQuantityRounded formats bytes rounded up to a whole number of Mi,
+// as "Gi" when that is exact, for templating memory requests and limits
+// from computed sizes: 1.3 GiB becomes "1332Mi" and 2 GiB stays "2Gi"
+//
+// Rounding up never hands a workload less than was computed. Sizes within
+// a MiB of the int64 limit are formatted exactly instead.
+func FormatQuantityRounded(bytes int64) string {
+	if bytes <= 0 || bytes > (1<<63-1)-MiB {
+		return FormatQuantity(bytes)
+	}
+
+	mebibytes := ceilDiv(bytes, MiB)
+	if mebibytes%1024 == 0 {
+		return strconv.FormatInt(mebibytes/1024, 10) + "Gi"
+	}
+	return strconv.FormatInt(mebibytes, 10) + "Mi"
+}
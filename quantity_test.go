@@ -0,0 +1,130 @@
+package filesize
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestParseQuantity tests Kubernetes quantity strings
+func TestParseQuantity(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{"0", 0, false},
+		{"1024", 1024, false},
+		{"512Mi", 512 * MiB, false},
+		{"1.5Gi", GiB + GiB/2, false},
+		{"2G", 2 * GB, false},
+		{"1k", 1000, false},
+		{"1Ki", 1024, false},
+		{"129e6", 129 * MB, false},
+		{"129E6", 129 * MB, false},
+		{"1E", EB, false},
+		{"7Ei", 7 * EiB, false},
+		{".5Ki", 512, false},
+		{"5.", 5, false},
+		{"+1Mi", MiB, false},
+		{"-1Mi", -MiB, false},
+
+		// fractional bytes round up
+		{"100m", 1, false},
+		{"1500m", 2, false},
+		{"1n", 1, false},
+		{"0.1", 1, false},
+		{"12e-1", 2, false},
+		{"0m", 0, false},
+
+		// invalid quantities
+		{"", 0, true},
+		{"Mi", 0, true},
+		{".", 0, true},
+		{"1MB", 0, true},
+		{"1mi", 0, true},
+		{"1K", 0, true},
+		{"1 Mi", 0, true},
+		{"1e", 0, true},
+		{"1e1000", 0, true},
+		{"1.2.3", 0, true},
+		{"8Ei", 0, true},
+		{"10E", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseQuantity(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseQuantity(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseQuantity(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if int64(result) != tc.expected {
+			t.Errorf("ParseQuantity(%q) = %d, expected %d", tc.input, int64(result), tc.expected)
+		}
+	}
+
+	if _, err := ParseQuantity("8Ei"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseQuantity(%q) error = %v, expected ErrOverflow", "8Ei", err)
+	}
+}
+
+// TestFormatQuantity tests choosing the most compact exact quantity
+func TestFormatQuantity(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{1000, "1k"},
+		{1024, "1Ki"},
+		{512 * MiB, "512Mi"},
+		{1536 * MiB, "1536Mi"},
+		{2 * GiB, "2Gi"},
+		{GB, "1G"},
+		{1000001, "1000001"},
+		{5 * EiB, "5Ei"},
+		{-MiB, "-1Mi"},
+		{math.MaxInt64, "9223372036854775807"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatQuantity(tc.input)
+		if result != tc.expected {
+			t.Errorf("FormatQuantity(%d) = %q, expected %q", tc.input, result, tc.expected)
+			continue
+		}
+		if parsed, err := ParseQuantity(result); err != nil || int64(parsed) != tc.input {
+			t.Errorf("ParseQuantity(%q) = %d, %v, expected %d", result, int64(parsed), err, tc.input)
+		}
+	}
+}
+
+// TestFormatQuantityRounded tests rounding up to whole Mi
+func TestFormatQuantityRounded(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0"},
+		{1, "1Mi"},
+		{MiB, "1Mi"},
+		{MiB + 1, "2Mi"},
+		{GiB * 13 / 10, "1332Mi"},
+		{2 * GiB, "2Gi"},
+		{2*GiB - 1, "2Gi"},
+		{math.MaxInt64, "9223372036854775807"},
+	}
+
+	for _, tc := range testCases {
+		if result := FormatQuantityRounded(tc.input); result != tc.expected {
+			t.Errorf("FormatQuantityRounded(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate is an amount of data per unit of time, such as 10 MB/s
+//
+// A Rate keeps the byte count and the period it was expressed over, so
+// values read from configuration keep their meaning. The zero Per means one
+// second, which makes Rate{Bytes: 4 * MiB} a valid "4 MiB/s".
+type Rate struct {
+	// Bytes is the amount of data transferred per period
+	Bytes Size
+
+	// Per is the period, zero meaning one second
+	Per time.Duration
+}
+
+// PerSecond returns a rate of bytes per second
+func PerSecond(bytes int64) Rate {
+	return Rate{Bytes: Size(bytes), Per: time.Second}
+}
+
+// ParseRate converts a human-readable rate string to a Rate
+//
+// A rate is a size as accepted by ParseSize, a slash and a time unit, e.g.
+// "10MB/s", "4MiB/s" or "512 k/sec". Besides seconds the units min, h, day,
+// week, month and year are understood, optionally with a count, so backup
+// windows and billing quotas such as "1GB/day", "500MiB/hour", "10TB/month"
+// or "2GiB/10min" keep their period. A month is 730 hours and a year 365
+// days. Rates cannot be negative.
+func ParseRate(rateStr string) (Rate, error) {
+	sizeStr, periodStr, ok := strings.Cut(rateStr, "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("invalid rate format, expected size/period: %s", strings.TrimSpace(rateStr))
+	}
+
+	bytes, err := ParseSize(sizeStr)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	per, err := parsePeriod(periodStr)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	return Rate{Bytes: Size(bytes), Per: per}, nil
+}
+
+// ratePeriod is a named time unit accepted as the denominator of a rate
+type ratePeriod struct {
+	// name is the spelling used when formatting
+	name string
+
+	// aliases are further accepted spellings, matched ignoring case
+	aliases []string
+
+	duration time.Duration
+}
+
+// Day, month and year lengths used by rate periods; a month is a twelfth of
+// a 365-day year (730 hours), the convention of most billing calculators
+const (
+	day   = 24 * time.Hour
+	year  = 365 * day
+	month = year / 12
+)
+
+// ratePeriods lists the time units ParseRate understands
+var ratePeriods = []ratePeriod{
+	{"s", []string{"sec", "second", "seconds"}, time.Second},
+	{"min", []string{"m", "minute", "minutes"}, time.Minute},
+	{"h", []string{"hr", "hour", "hours"}, time.Hour},
+	{"day", []string{"d", "days"}, day},
+	{"week", []string{"w", "weeks"}, 7 * day},
+	{"month", []string{"mo", "months"}, month},
+	{"year", []string{"y", "yr", "years"}, year},
+}
+
+// parsePeriod converts the denominator of a rate to a duration
+//
+// The denominator is a time unit optionally preceded by a whole count, so
+// "s", "day" and "10min" are all accepted.
+func parsePeriod(periodStr string) (time.Duration, error) {
+	periodStr = strings.TrimSpace(periodStr)
+
+	// an optional count multiplies the unit
+	i := scanDigits(periodStr, 0)
+	count := int64(1)
+	if i > 0 {
+		n, err := strconv.ParseInt(periodStr[:i], 10, 64)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid rate period: %s", periodStr)
+		}
+		count = n
+	}
+	unitStr := strings.TrimSpace(periodStr[i:])
+
+	for _, period := range ratePeriods {
+		if !strings.EqualFold(unitStr, period.name) && !slices.ContainsFunc(period.aliases, func(alias string) bool {
+			return strings.EqualFold(unitStr, alias)
+		}) {
+			continue
+		}
+
+		if count > int64(math.MaxInt64/period.duration) {
+			return 0, fmt.Errorf("rate period too long: %s", periodStr)
+		}
+		return time.Duration(count) * period.duration, nil
+	}
+
+	return 0, fmt.Errorf("unknown rate period: %s", periodStr)
+}
+
+// periodName returns the formatting name of a period, or "" when it is not
+// exactly one of the named time units
+func periodName(per time.Duration) string {
+	for _, period := range ratePeriods {
+		if period.duration == per {
+			return period.name
+		}
+	}
+	return ""
+}
+
+// period returns Per, treating zero as one second
+func (r Rate) period() time.Duration {
+	if r.Per <= 0 {
+		return time.Second
+	}
+	return r.Per
+}
+
+// BytesPerSecond returns the rate normalized to bytes per second
+func (r Rate) BytesPerSecond() float64 {
+	return float64(r.Bytes) / r.period().Seconds()
+}
+
+// BytesPer returns the rate normalized to bytes per period d, e.g.
+// r.BytesPer(24*time.Hour) for bytes per day
+func (r Rate) BytesPer(d time.Duration) float64 {
+	return float64(r.Bytes) * (float64(d) / float64(r.period()))
+}
+
+// BytesIn returns the number of bytes transferred at this rate during d,
+// rounded down and saturating at the int64 range
+func (r Rate) BytesIn(d time.Duration) int64 {
+	bytes := math.Floor(r.BytesPerSecond() * d.Seconds())
+	switch {
+	case bytes >= math.MaxInt64:
+		return math.MaxInt64
+	case bytes <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(bytes)
+}
+
+// String formats the rate with the package default options, see Format
+func (r Rate) String() string {
+	return r.Format()
+}
+
+// Format formats the rate with the given options
+//
+// Rates over one of the named time units other than seconds keep their
+// period, e.g. "1.00 GiB/day"; all others are normalized to bytes per
+// second and formatted like FormatRate.
+func (r Rate) Format(opts ...FormatOption) string {
+	name := periodName(r.period())
+	if name == "" || name == "s" {
+		return FormatRate(r.BytesPerSecond(), opts...)
+	}
+
+	cfg := newFormatConfig(opts)
+	bytes := int64(r.Bytes)
+	if bytes < 0 && cfg.clampNegative {
+		bytes = 0
+	}
+
+	var buf [formatBufferSize]byte
+	return string(cfg.appendScaled(buf[:0], compareZero(bytes), float64(absInt64(bytes)), cfg.byteUnits(), "B", "/"+name))
+}
+
+// TransferTime returns how long moving size bytes takes at rate, rounded up
+// to the nanosecond
+//
+// For example TransferTime(40*GiB, PerSecond(25*MiB)) is 27m18.4s. A zero
+// or negative rate never finishes and yields the largest Duration, as do
+// results too long to represent.
+func TransferTime(size int64, rate Rate) time.Duration {
+	if size <= 0 {
+		return 0
+	}
+	if rate.Bytes <= 0 {
+		return math.MaxInt64
+	}
+
+	// size * per / bytes, computed exactly since the product overflows int64
+	var product, quotient, remainder big.Int
+	product.Mul(big.NewInt(size), big.NewInt(int64(rate.period())))
+	quotient.QuoRem(&product, big.NewInt(int64(rate.Bytes)), &remainder)
+	if remainder.Sign() != 0 {
+		quotient.Add(&quotient, big.NewInt(1))
+	}
+
+	if !quotient.IsInt64() {
+		return math.MaxInt64
+	}
+	return time.Duration(quotient.Int64())
+}
+
+// RequiredRate returns the rate needed to move size bytes within deadline
+//
+// The result is exact, expressed as size bytes per deadline, so
+// RequiredRate(size, d) followed by TransferTime gives back d. A deadline of
+// zero or less is treated as one nanosecond, the shortest representable.
+func RequiredRate(size int64, deadline time.Duration) Rate {
+	if deadline <= 0 {
+		deadline = time.Nanosecond
+	}
+	if size < 0 {
+		size = 0
+	}
+	return Rate{Bytes: Size(size), Per: deadline}
+}
+
+// Scale returns the rate multiplied by f, rounded to the nearest byte per
+// period
+//
+// It fails with ErrOverflow when the result does not fit in a Size and
+// rejects NaN and infinite factors.
+func (r Rate) Scale(f float64) (Rate, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Rate{}, fmt.Errorf("invalid rate scale factor: %g", f)
+	}
+
+	bytes := math.Round(float64(r.Bytes) * f)
+	if bytes >= math.MaxInt64 || bytes < math.MinInt64 {
+		return Rate{}, fmt.Errorf("scaling %s by %g: %w", r, f, ErrOverflow)
+	}
+	return Rate{Bytes: Size(bytes), Per: r.Per}, nil
+}
+
+// Add returns the combined rate of r and other
+//
+// The sum is exact: rates over different periods are expressed over the
+// least common multiple of the two, so adding 1GiB/day and 1MiB/s gives
+// 85.375GiB/day. It fails with ErrOverflow when that period or the byte
+// count does not fit.
+func (r Rate) Add(other Rate) (Rate, error) {
+	if r.period() == other.period() {
+		sum := new(big.Int).Add(big.NewInt(int64(r.Bytes)), big.NewInt(int64(other.Bytes)))
+		if !sum.IsInt64() {
+			return Rate{}, fmt.Errorf("adding %s and %s: %w", r, other, ErrOverflow)
+		}
+		return Rate{Bytes: Size(sum.Int64()), Per: r.Per}, nil
+	}
+
+	// lcm(a, b) = a / gcd(a, b) * b
+	a, b := big.NewInt(int64(r.period())), big.NewInt(int64(other.period()))
+	lcm := new(big.Int).GCD(nil, nil, a, b)
+	lcm.Mul(lcm.Quo(a, lcm), b)
+
+	// scale each byte count up to the common period and add
+	sum := new(big.Int).Mul(big.NewInt(int64(r.Bytes)), new(big.Int).Quo(lcm, a))
+	sum.Add(sum, new(big.Int).Mul(big.NewInt(int64(other.Bytes)), new(big.Int).Quo(lcm, b)))
+	if !lcm.IsInt64() || !sum.IsInt64() {
+		return Rate{}, fmt.Errorf("adding %s and %s: %w", r, other, ErrOverflow)
+	}
+	return Rate{Bytes: Size(sum.Int64()), Per: time.Duration(lcm.Int64())}, nil
+}
+
+// PerConnection returns an even share of the rate for each of n connections
+//
+// Shares are exact where possible: when the bytes do not divide evenly the
+// period is lengthened instead, so 10B/s over 3 connections is 10B/3s. Only
+// when that period would not fit is the byte count rounded down. n must be
+// positive.
+func (r Rate) PerConnection(n int) (Rate, error) {
+	if n <= 0 {
+		return Rate{}, fmt.Errorf("invalid connection count: %d", n)
+	}
+
+	count := int64(n)
+	switch {
+	case int64(r.Bytes)%count == 0:
+		return Rate{Bytes: r.Bytes / Size(count), Per: r.Per}, nil
+	case r.period() <= time.Duration(math.MaxInt64/count):
+		return Rate{Bytes: r.Bytes, Per: r.period() * time.Duration(count)}, nil
+	}
+	return Rate{Bytes: r.Bytes / Size(count), Per: r.Per}, nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+//
+// Rates serialize in a canonical form without spaces that ParseRate reads
+// back, such as "10MiB/s", "1GiB/day" or "2GiB/10min". Like Size, YAML
+// libraries pick this up through the text methods.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(formatRateExact(r)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+//
+// It accepts anything ParseRate does, optionally preceded by a sign so that
+// negative rates written by MarshalText round-trip.
+func (r *Rate) UnmarshalText(text []byte) error {
+	sizeStr, periodStr, ok := strings.Cut(string(text), "/")
+	if !ok {
+		return fmt.Errorf("invalid rate format, expected size/period: %s", strings.TrimSpace(string(text)))
+	}
+
+	// the sign is parsed with the size, like Size.UnmarshalText
+	bytes, err := parseSigned(sizeStr)
+	if err != nil {
+		return err
+	}
+	per, err := parsePeriod(periodStr)
+	if err != nil {
+		return err
+	}
+
+	*r = Rate{Bytes: Size(bytes), Per: per}
+	return nil
+}
+
+// formatRateExact renders r in the canonical text form read back by
+// ParseRate, e.g. "10MiB/s", "1GiB/day" or "2GiB/10min"
+//
+// The period is written as a count of the largest time unit that divides it
+// exactly. Periods that are not a whole number of seconds are normalized to
+// bytes per second, rounded to the nearest byte.
+func formatRateExact(r Rate) string {
+	per := r.period()
+	for i := len(ratePeriods) - 1; i >= 0; i-- {
+		period := ratePeriods[i]
+		if per%period.duration != 0 {
+			continue
+		}
+
+		count := per / period.duration
+		if count == 1 {
+			return formatExact(int64(r.Bytes), "") + "/" + period.name
+		}
+		return formatExact(int64(r.Bytes), "") + "/" + strconv.FormatInt(int64(count), 10) + period.name
+	}
+
+	bytes := math.Round(r.BytesPerSecond())
+	bytes = math.Max(math.Min(bytes, math.MaxInt64), math.MinInt64)
+	return formatExact(int64(bytes), "") + "/s"
+}
@@ -0,0 +1,377 @@
+package filesize
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestParseRate tests parsing rate strings
+func TestParseRate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Rate
+		hasError bool
+	}{
+		{"10MB/s", Rate{Bytes: Size(10 * MB), Per: time.Second}, false},
+		{"4MiB/s", Rate{Bytes: Size(4 * MiB), Per: time.Second}, false},
+		{" 512 k / sec ", Rate{Bytes: 512 * 1024, Per: time.Second}, false},
+		{"1.5GiB/second", Rate{Bytes: Size(GiB * 3 / 2), Per: time.Second}, false},
+		{"100/S", Rate{Bytes: 100, Per: time.Second}, false},
+		{"10MB", Rate{}, true},
+		{"10MB/", Rate{}, true},
+		{"/s", Rate{}, true},
+		{"10XB/s", Rate{}, true},
+		{"1GB/day", Rate{Bytes: Size(GB), Per: 24 * time.Hour}, false},
+		{"500MiB/hour", Rate{Bytes: Size(500 * MiB), Per: time.Hour}, false},
+		{"10TB/month", Rate{Bytes: Size(10 * TB), Per: 730 * time.Hour}, false},
+		{"1 GiB / 1 Week", Rate{Bytes: Size(GiB), Per: 7 * 24 * time.Hour}, false},
+		{"2GiB/10min", Rate{Bytes: Size(2 * GiB), Per: 10 * time.Minute}, false},
+		{"1TB/y", Rate{Bytes: Size(TB), Per: 365 * 24 * time.Hour}, false},
+		{"10MB/fortnight", Rate{}, true},
+		{"10MB/0s", Rate{}, true},
+		{"10MB/99999999999year", Rate{}, true},
+		{"-1MB/s", Rate{}, true},
+		{"1MB/s/s", Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseRate(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseRate(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRate(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("ParseRate(%q) = %+v, expected %+v", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestRate_BytesPerSecond tests normalization to bytes per second
+func TestRate_BytesPerSecond(t *testing.T) {
+	testCases := []struct {
+		input    Rate
+		expected float64
+	}{
+		{PerSecond(MiB), 1048576},
+		{Rate{Bytes: Size(4 * KiB)}, 4096},
+		{Rate{Bytes: 1000, Per: 10 * time.Second}, 100},
+		{Rate{Bytes: 1000, Per: time.Millisecond}, 1e6},
+		{Rate{Bytes: 86400, Per: 24 * time.Hour}, 1},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.input.BytesPerSecond(); result != tc.expected {
+			t.Errorf("%+v.BytesPerSecond() = %g, expected %g", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestRate_BytesIn tests the amount transferred over a duration
+func TestRate_BytesIn(t *testing.T) {
+	testCases := []struct {
+		rate     Rate
+		duration time.Duration
+		expected int64
+	}{
+		{PerSecond(MiB), time.Minute, 60 * MiB},
+		{PerSecond(1000), 1500 * time.Millisecond, 1500},
+		{Rate{Bytes: 10, Per: 3 * time.Second}, time.Second, 3},
+		{PerSecond(EiB), time.Hour, math.MaxInt64},
+		{PerSecond(MiB), 0, 0},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.rate.BytesIn(tc.duration); result != tc.expected {
+			t.Errorf("%+v.BytesIn(%v) = %d, expected %d", tc.rate, tc.duration, result, tc.expected)
+		}
+	}
+}
+
+// TestRate_String tests rate formatting
+func TestRate_String(t *testing.T) {
+	testCases := []struct {
+		input    Rate
+		expected string
+	}{
+		{PerSecond(4 * MiB), "4.00 MiB/s"},
+		{Rate{Bytes: Size(10 * MB)}, "9.54 MiB/s"},
+		{Rate{Bytes: 3 * 1024, Per: 2 * time.Second}, "1.50 KiB/s"},
+		{Rate{Bytes: Size(GiB), Per: 24 * time.Hour}, "1.00 GiB/day"},
+		{Rate{Bytes: Size(10 * TiB), Per: 730 * time.Hour}, "10.0 TiB/month"},
+		{Rate{Bytes: 600, Per: 10 * time.Minute}, "1 B/s"},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.input.String(); result != tc.expected {
+			t.Errorf("%+v.String() = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+
+	if result := PerSecond(10 * MB).Format(WithUnitSystem(SI)); result != "10.0 MB/s" {
+		t.Errorf("PerSecond(10MB).Format(SI) = %q, expected %q", result, "10.0 MB/s")
+	}
+}
+
+// TestRate_BytesPer tests conversion between denominators
+func TestRate_BytesPer(t *testing.T) {
+	testCases := []struct {
+		input    string
+		per      time.Duration
+		expected float64
+	}{
+		{"1GB/day", time.Second, 1e9 / 86400},
+		{"500MiB/hour", time.Minute, 500 * 1024 * 1024 / 60},
+		{"10MB/s", 24 * time.Hour, 10e6 * 86400},
+		{"12GB/year", 730 * time.Hour, 1e9},
+	}
+
+	for _, tc := range testCases {
+		rate, err := ParseRate(tc.input)
+		if err != nil {
+			t.Errorf("ParseRate(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result := rate.BytesPer(tc.per); math.Abs(result-tc.expected) > 1e-6*tc.expected {
+			t.Errorf("ParseRate(%q).BytesPer(%v) = %g, expected %g", tc.input, tc.per, result, tc.expected)
+		}
+	}
+}
+
+// TestTransferTime tests transfer time calculation
+func TestTransferTime(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		rate     Rate
+		expected time.Duration
+	}{
+		{40 * GiB, PerSecond(25 * MiB), 1638400 * time.Millisecond},
+		{MiB, PerSecond(MiB), time.Second},
+		{GB, Rate{Bytes: Size(GB), Per: 24 * time.Hour}, 24 * time.Hour},
+		{1, PerSecond(3), 333333334},
+		{0, PerSecond(MiB), 0},
+		{MiB, Rate{}, math.MaxInt64},
+		{EiB, PerSecond(1), math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		if result := TransferTime(tc.size, tc.rate); result != tc.expected {
+			t.Errorf("TransferTime(%d, %+v) = %v, expected %v", tc.size, tc.rate, result, tc.expected)
+		}
+	}
+}
+
+// TestRequiredRate tests the inverse of TransferTime
+func TestRequiredRate(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		deadline time.Duration
+		expected float64
+	}{
+		{40 * GiB, 40 * time.Minute, 40 * 1024 * 1024 * 1024 / 2400.0},
+		{MiB, time.Second, 1024 * 1024},
+		{GB, 24 * time.Hour, 1e9 / 86400},
+	}
+
+	for _, tc := range testCases {
+		rate := RequiredRate(tc.size, tc.deadline)
+		if result := rate.BytesPerSecond(); math.Abs(result-tc.expected) > 1e-9*tc.expected {
+			t.Errorf("RequiredRate(%d, %v) = %g B/s, expected %g", tc.size, tc.deadline, result, tc.expected)
+		}
+		if back := TransferTime(tc.size, rate); back != tc.deadline {
+			t.Errorf("TransferTime(RequiredRate(%d, %v)) = %v, expected %v", tc.size, tc.deadline, back, tc.deadline)
+		}
+	}
+
+	if rate := RequiredRate(MiB, 0); rate.Per != time.Nanosecond {
+		t.Errorf("RequiredRate(1MiB, 0) = %+v, expected a one nanosecond period", rate)
+	}
+}
+
+// TestRate_MarshalText tests that the canonical text parses back exactly
+func TestRate_MarshalText(t *testing.T) {
+	rates := []Rate{
+		{},
+		PerSecond(10 * MiB),
+		PerSecond(1500),
+		PerSecond(-KiB),
+		{Bytes: Size(GiB), Per: day},
+		{Bytes: Size(2 * GiB), Per: 10 * time.Minute},
+		{Bytes: Size(10 * TB), Per: month},
+
+		// the int64 boundaries keep their sign and magnitude
+		PerSecond(math.MaxInt64),
+		PerSecond(math.MinInt64),
+		{Bytes: math.MinInt64, Per: day},
+		{Bytes: math.MaxInt64, Per: 10 * time.Minute},
+	}
+
+	for _, rate := range rates {
+		text, err := rate.MarshalText()
+		if err != nil {
+			t.Errorf("%+v.MarshalText() unexpected error: %v", rate, err)
+			continue
+		}
+
+		var decoded Rate
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q) unexpected error: %v", text, err)
+			continue
+		}
+		if decoded.Bytes != rate.Bytes || decoded.period() != rate.period() {
+			t.Errorf("UnmarshalText(%q) = %+v, expected %+v", text, decoded, rate)
+		}
+	}
+}
+
+// TestRate_UnmarshalText tests signs and malformed text
+func TestRate_UnmarshalText(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Rate
+		hasError bool
+	}{
+		{"10MiB/s", PerSecond(10 * MiB), false},
+		{" +10MiB/s", PerSecond(10 * MiB), false},
+		{"-1.5KiB/day", Rate{Bytes: -1536, Per: day}, false},
+		{"-8EiB/s", PerSecond(math.MinInt64), false},
+		{"-+5MiB/s", Rate{}, true},
+		{"+-5MiB/s", Rate{}, true},
+		{"--5MiB/s", Rate{}, true},
+		{"++5MiB/s", Rate{}, true},
+		{"8EiB/s", Rate{}, true},
+		{"-5MiB", Rate{}, true},
+		{"-/s", Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		var rate Rate
+		err := rate.UnmarshalText([]byte(tc.input))
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("UnmarshalText(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("UnmarshalText(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if rate != tc.expected {
+			t.Errorf("UnmarshalText(%q) = %+v, expected %+v", tc.input, rate, tc.expected)
+		}
+	}
+}
+
+// TestRate_Scale tests scaling rates with overflow detection
+func TestRate_Scale(t *testing.T) {
+	testCases := []struct {
+		rate     Rate
+		factor   float64
+		expected Rate
+		hasError bool
+	}{
+		{PerSecond(10 * MiB), 0.5, PerSecond(5 * MiB), false},
+		{Rate{Bytes: Size(GiB), Per: day}, 3, Rate{Bytes: Size(3 * GiB), Per: day}, false},
+		{PerSecond(3), 0.5, PerSecond(2), false},
+		{PerSecond(EiB), 16, Rate{}, true},
+		{PerSecond(MiB), math.NaN(), Rate{}, true},
+		{PerSecond(MiB), math.Inf(1), Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := tc.rate.Scale(tc.factor)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("%v.Scale(%g) expected error but got none", tc.rate, tc.factor)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v.Scale(%g) unexpected error: %v", tc.rate, tc.factor, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%v.Scale(%g) = %+v, expected %+v", tc.rate, tc.factor, result, tc.expected)
+		}
+	}
+
+	if _, err := PerSecond(EiB).Scale(16); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Scale() overflow error = %v, expected ErrOverflow", err)
+	}
+}
+
+// TestRate_Add tests exact addition across periods
+func TestRate_Add(t *testing.T) {
+	testCases := []struct {
+		a, b     Rate
+		expected Rate
+		hasError bool
+	}{
+		{PerSecond(MiB), PerSecond(MiB), PerSecond(2 * MiB), false},
+		{Rate{Bytes: Size(KiB)}, PerSecond(KiB), Rate{Bytes: Size(2 * KiB)}, false},
+		{Rate{Bytes: Size(GiB), Per: day}, PerSecond(MiB), Rate{Bytes: Size(87424 * MiB), Per: day}, false},
+		{Rate{Bytes: 1, Per: 2 * time.Second}, Rate{Bytes: 1, Per: 3 * time.Second}, Rate{Bytes: 5, Per: 6 * time.Second}, false},
+		{PerSecond(math.MaxInt64), PerSecond(1), Rate{}, true},
+		{Rate{Bytes: Size(EiB), Per: time.Nanosecond}, Rate{Bytes: 1, Per: year}, Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := tc.a.Add(tc.b)
+		if tc.hasError {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("%+v.Add(%+v) error = %v, expected ErrOverflow", tc.a, tc.b, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v.Add(%+v) unexpected error: %v", tc.a, tc.b, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%+v.Add(%+v) = %+v, expected %+v", tc.a, tc.b, result, tc.expected)
+		}
+	}
+}
+
+// TestRate_PerConnection tests splitting a rate between connections
+func TestRate_PerConnection(t *testing.T) {
+	testCases := []struct {
+		rate     Rate
+		n        int
+		expected Rate
+		hasError bool
+	}{
+		{PerSecond(100 * MiB), 4, PerSecond(25 * MiB), false},
+		{PerSecond(10), 3, Rate{Bytes: 10, Per: 3 * time.Second}, false},
+		{Rate{Bytes: 10}, 3, Rate{Bytes: 10, Per: 3 * time.Second}, false},
+		{Rate{Bytes: 10, Per: math.MaxInt64 / 2}, 3, Rate{Bytes: 3, Per: math.MaxInt64 / 2}, false},
+		{PerSecond(MiB), 1, PerSecond(MiB), false},
+		{PerSecond(MiB), 0, Rate{}, true},
+		{PerSecond(MiB), -2, Rate{}, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := tc.rate.PerConnection(tc.n)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("%+v.PerConnection(%d) expected error but got none", tc.rate, tc.n)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v.PerConnection(%d) unexpected error: %v", tc.rate, tc.n, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%+v.PerConnection(%d) = %+v, expected %+v", tc.rate, tc.n, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package filesize
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SchemaFormat is the JSON Schema "format" name for size strings
+const SchemaFormat = "filesize"
+
+// schemaPattern is the regular expression returned by SchemaPattern
+var schemaPattern = buildSchemaPattern(DefaultUnits)
+
+// SchemaPattern returns a regular expression matching exactly the strings
+// ParseSize accepts
+//
+// The pattern is written in the common subset of ECMA-262 and RE2 syntax, so
+// it works both in JSON Schema "pattern" keywords and with Go's regexp
+// package. Units are matched case-insensitively by spelling out both cases,
+// since JSON Schema has no case-insensitive flag.
+func SchemaPattern() string {
+	return schemaPattern
+}
+
+// JSONSchema returns a JSON Schema fragment describing a size string
+//
+// The fragment has "type", "format", "pattern", "description" and "examples"
+// keys and can be embedded as a property schema in JSON Schema documents and
+// OpenAPI 3 specifications. Marshal it with encoding/json or copy its values
+// into a generator's schema type. Each call returns a fresh map that the
+// caller may modify.
+func JSONSchema() map[string]any {
+	return map[string]any{
+		"type":        "string",
+		"format":      SchemaFormat,
+		"pattern":     schemaPattern,
+		"description": "A size in bytes, written as a number with an optional unit such as k, MiB or GB. Binary units (k, KiB) are 1024-based and decimal units (KB) are 1000-based.",
+		"examples":    []any{"4096", "4k", "10MiB", "1.5GB"},
+	}
+}
+
+// CheckFormat validates a value against the "filesize" format
+//
+// Following JSON Schema semantics only strings are checked; other types
+// pass so that the format can be combined with "type" keywords. The
+// signature matches the format functions of santhosh-tekuri/jsonschema.
+func CheckFormat(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	return ValidateSize(s)
+}
+
+// FormatChecker implements the "filesize" format for xeipuuv/gojsonschema
+//
+// Register it with gojsonschema.FormatCheckers.Add(filesize.SchemaFormat,
+// filesize.FormatChecker{}).
+type FormatChecker struct{}
+
+// IsFormat reports whether input satisfies the "filesize" format
+func (FormatChecker) IsFormat(input any) bool {
+	return CheckFormat(input) == nil
+}
+
+// buildSchemaPattern builds the regular expression for a unit system
+func buildSchemaPattern(sys UnitSystem) string {
+	// longer names first so alternation never stops at a prefix
+	names := make([]string, 0, len(sys.lookup))
+	for name := range sys.lookup {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), cmp.Compare(a, b))
+	})
+
+	var units strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			units.WriteByte('|')
+		}
+		for j := 0; j < len(name); j++ {
+			lower := name[j]
+			fmt.Fprintf(&units, "[%c%c]", lower, lower-'a'+'A')
+		}
+	}
+
+	// TrimSpace removes \v around the value, but only splitSize's whitespace
+	// may separate the number from the unit
+	const outer, inner = `[ \t\n\v\f\r]*`, `[ \t\n\f\r]*`
+	return "^" + outer + `[0-9]+(\.[0-9]+)?` + "(" + inner + "(" + units.String() + "))?" + outer + "$"
+}
@@ -0,0 +1,81 @@
+package filesize
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestSchemaPattern tests that the pattern agrees with ParseSize
+func TestSchemaPattern(t *testing.T) {
+	pattern := regexp.MustCompile(SchemaPattern())
+
+	inputs := []string{
+		"0", "1024", "4k", "4K", "4KiB", "4kib", "1.5 MiB", " 10GB ", "100bytes",
+		"2e", "1\tk", "1\vk", "1\v", "1 B",
+		"", " ", "abc", "k1", "1xy", "1.2.3k", "1.k", ".5k", "1k b", "-1k",
+		"1ZiB", "1 kb ", "1kk", "1bytesb", "1,5k",
+	}
+
+	for _, input := range inputs {
+		_, err := ParseSize(input)
+		// overflow is a range error, not a syntax error
+		if err != nil && strings.Contains(err.Error(), "too large") {
+			continue
+		}
+		if matched := pattern.MatchString(input); matched != (err == nil) {
+			t.Errorf("SchemaPattern() match %q = %v, but ParseSize error = %v", input, matched, err)
+		}
+	}
+}
+
+// TestJSONSchema tests the schema fragment
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+	if schema["type"] != "string" || schema["format"] != SchemaFormat || schema["pattern"] != SchemaPattern() {
+		t.Errorf("JSONSchema() = %v, expected a string schema with format and pattern", schema)
+	}
+
+	// every example must satisfy the schema itself
+	pattern := regexp.MustCompile(SchemaPattern())
+	for _, example := range schema["examples"].([]any) {
+		if !pattern.MatchString(example.(string)) || CheckFormat(example) != nil {
+			t.Errorf("JSONSchema() example %q does not satisfy the schema", example)
+		}
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Errorf("json.Marshal(JSONSchema()) unexpected error: %v", err)
+	}
+
+	// callers get their own copy
+	schema["description"] = "changed"
+	if JSONSchema()["description"] == "changed" {
+		t.Errorf("JSONSchema() returned a shared map")
+	}
+}
+
+// TestCheckFormat tests the format checkers
+func TestCheckFormat(t *testing.T) {
+	testCases := []struct {
+		input    any
+		expected bool
+	}{
+		{"4k", true},
+		{"1.5 GiB", true},
+		{"lots", false},
+		{"", false},
+		{float64(4096), true},
+		{nil, true},
+	}
+
+	for _, tc := range testCases {
+		if result := CheckFormat(tc.input) == nil; result != tc.expected {
+			t.Errorf("CheckFormat(%#v) = %v, expected %v", tc.input, result, tc.expected)
+		}
+		if result := (FormatChecker{}).IsFormat(tc.input); result != tc.expected {
+			t.Errorf("IsFormat(%#v) = %v, expected %v", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package filesize
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrOverflow is returned, possibly wrapped, by arithmetic helpers whose
+// result does not fit in an int64
+var ErrOverflow = errors.New("result out of range")
+
+// Size is a byte count that prints itself in human-readable form
+//
+// Size is a plain int64 underneath, so constants such as 4*MiB convert
+// directly and arithmetic works as usual; its String method formats using
+// the package default set with SetDefaultFormat.
+type Size int64
+
+// String formats the size with FormatSize and the package default options
+func (s Size) String() string {
+	return FormatSize(int64(s))
+}
+
+// Format formats the size with FormatSize and the given options
+func (s Size) Format(opts ...FormatOption) string {
+	return FormatSize(int64(s), opts...)
+}
+
+// MarshalText implements encoding.TextMarshaler
+//
+// Sizes serialize as a canonical human string without spaces, using the
+// largest binary unit that represents the value exactly, e.g. "1.5GiB" or
+// "1500B". The text always parses back to the same value.
+//
+// The text methods are also how YAML support works without a dependency:
+// gopkg.in/yaml.v3 decodes fields like "maxCacheSize: 2GiB" through
+// UnmarshalText and plain integers directly, while sigs.k8s.io/yaml converts
+// documents to JSON and goes through UnmarshalJSON.
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(formatExact(int64(s), "")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+//
+// It accepts anything ParseSize does, optionally preceded by a sign so that
+// negative sizes written by MarshalText round-trip.
+func (s *Size) UnmarshalText(text []byte) error {
+	bytes, err := parseSigned(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = Size(bytes)
+	return nil
+}
+
+// parseSigned parses a size string that may start with "+" or "-"
+func parseSigned(sizeStr string) (int64, error) {
+	trimmed := strings.TrimSpace(sizeStr)
+	if trimmed == "" || (trimmed[0] != '-' && trimmed[0] != '+') {
+		return ParseSize(sizeStr)
+	}
+
+	if trimmed[0] == '+' {
+		return ParseSize(trimmed[1:])
+	}
+
+	// the magnitude of math.MinInt64 is one more than math.MaxInt64
+	magnitude, err := parseMagnitude(trimmed[1:], unitMap, 1<<63)
+	if err != nil {
+		return 0, err
+	}
+	return -int64(magnitude), nil
+}
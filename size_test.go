@@ -0,0 +1,171 @@
+package filesize
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestSize_String tests that Size prints in human-readable form
+func TestSize_String(t *testing.T) {
+	testCases := []struct {
+		input    Size
+		expected string
+	}{
+		{0, "0 B"},
+		{1536, "1.50 KiB"},
+		{Size(3 * GiB), "3.00 GiB"},
+		{-1536, "-1.50 KiB"},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.input.String(); result != tc.expected {
+			t.Errorf("Size(%d).String() = %q, expected %q", int64(tc.input), result, tc.expected)
+		}
+		if result := fmt.Sprint(tc.input); result != tc.expected {
+			t.Errorf("fmt.Sprint(Size(%d)) = %q, expected %q", int64(tc.input), result, tc.expected)
+		}
+	}
+}
+
+// TestSetDefaultFormat tests that the package default applies everywhere
+func TestSetDefaultFormat(t *testing.T) {
+	SetDefaultFormat(WithUnitSystem(SI), WithFixedDecimals(1), WithSeparator(""))
+	defer SetDefaultFormat()
+
+	testCases := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"FormatSize", FormatSize(1500000), "1.5MB"},
+		{"Size.String", Size(2500).String(), "2.5kB"},
+		{"FormatDelta", FormatDelta(-1500), "-1.5kB"},
+		{"AppendSize", string(AppendSize(nil, 1500)), "1.5kB"},
+
+		// per-call options are applied on top of the default
+		{"FormatSize with options", FormatSize(1500, WithSeparator(" ")), "1.5 kB"},
+		{"Size.Format with options", Size(1536).Format(WithUnitSystem(IEC)), "1.5KiB"},
+	}
+
+	for _, tc := range testCases {
+		if tc.result != tc.expected {
+			t.Errorf("%s = %q, expected %q", tc.name, tc.result, tc.expected)
+		}
+	}
+
+	// resetting restores the built-in style
+	SetDefaultFormat()
+	if result := FormatSize(1500000); result != "1.43 MiB" {
+		t.Errorf("FormatSize(1500000) after reset = %q, expected %q", result, "1.43 MiB")
+	}
+}
+
+// TestSize_MarshalText tests the canonical text form and its round trip
+func TestSize_MarshalText(t *testing.T) {
+	testCases := []struct {
+		input    Size
+		expected string
+	}{
+		{0, "0B"},
+		{1500, "1500B"},
+		{1536, "1.5KiB"},
+		{Size(GiB * 3 / 2), "1.5GiB"},
+		{Size(4 * MiB), "4MiB"},
+		{-1536, "-1.5KiB"},
+
+		// values above 2^53 must not lose precision on the way back
+		{9007199254740993, "9007199254740993B"},
+		{1<<62 + 512, "4503599627370496.5KiB"},
+		{math.MaxInt64, "9223372036854775807B"},
+		{math.MinInt64, "-8EiB"},
+	}
+
+	for _, tc := range testCases {
+		text, err := tc.input.MarshalText()
+		if err != nil {
+			t.Errorf("Size(%d).MarshalText() unexpected error: %v", int64(tc.input), err)
+			continue
+		}
+		if string(text) != tc.expected {
+			t.Errorf("Size(%d).MarshalText() = %q, expected %q", int64(tc.input), text, tc.expected)
+		}
+
+		var decoded Size
+		if err := decoded.UnmarshalText(text); err != nil || decoded != tc.input {
+			t.Errorf("UnmarshalText(%q) = %d, %v, expected %d", text, int64(decoded), err, int64(tc.input))
+		}
+	}
+}
+
+// TestSize_UnmarshalText tests decoding human strings
+func TestSize_UnmarshalText(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Size
+		hasError bool
+	}{
+		{"4k", 4096, false},
+		{" 1.5 MiB ", Size(MiB * 3 / 2), false},
+		{"+2KB", 2000, false},
+		{"-1k", -1024, false},
+		{"", 0, true},
+		{"-", 0, true},
+		{"1xy", 0, true},
+	}
+
+	for _, tc := range testCases {
+		var s Size
+		err := s.UnmarshalText([]byte(tc.input))
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("UnmarshalText(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("UnmarshalText(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if s != tc.expected {
+			t.Errorf("UnmarshalText(%q) = %d, expected %d", tc.input, int64(s), int64(tc.expected))
+		}
+	}
+}
+
+// TestSize_TextCodecs tests Size inside codecs that use TextMarshaler
+func TestSize_TextCodecs(t *testing.T) {
+	// json map keys use the text form
+	keyed := map[Size]string{Size(MiB): "small", Size(GiB): "large"}
+	data, err := json.Marshal(keyed)
+	if err != nil {
+		t.Fatalf("json.Marshal(map) unexpected error: %v", err)
+	}
+	if string(data) != `{"1GiB":"large","1MiB":"small"}` {
+		t.Errorf("json.Marshal(map) = %s, expected %s", data, `{"1GiB":"large","1MiB":"small"}`)
+	}
+
+	var decodedMap map[Size]string
+	if err := json.Unmarshal([]byte(`{"4k":"page"}`), &decodedMap); err != nil || decodedMap[4096] != "page" {
+		t.Errorf("json.Unmarshal(map) = %v, %v, expected key 4096", decodedMap, err)
+	}
+
+	// xml element text uses the text form
+	type limits struct {
+		Max Size `xml:"max"`
+	}
+	encoded, err := xml.Marshal(limits{Max: Size(2 * GiB)})
+	if err != nil {
+		t.Fatalf("xml.Marshal() unexpected error: %v", err)
+	}
+	if string(encoded) != "<limits><max>2GiB</max></limits>" {
+		t.Errorf("xml.Marshal() = %s, expected %s", encoded, "<limits><max>2GiB</max></limits>")
+	}
+
+	var decoded limits
+	if err := xml.Unmarshal([]byte("<limits><max>512m</max></limits>"), &decoded); err != nil || decoded.Max != Size(512*MiB) {
+		t.Errorf("xml.Unmarshal() = %d, %v, expected %d", int64(decoded.Max), err, 512*MiB)
+	}
+}
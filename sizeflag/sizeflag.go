@@ -0,0 +1,67 @@
+// Package sizeflag declares human-readable size flags for the standard
+// library flag package.
+//
+// Each helper mirrors its flag.Int64 counterpart, so a size flag takes one
+// line:
+//
+//	bufferSize := sizeflag.Size("buffer-size", 4*filesize.KiB, "read buffer size")
+//
+// Users can then pass "-buffer-size 1m" or "-buffer-size=1.5GB", invalid or
+// negative sizes are rejected with the parser's error, and -help shows the
+// default in humanized form ("(default 4KiB)"). The Rate helpers declare
+// bandwidth flags such as "-bandwidth 10MB/s" in the same way.
+package sizeflag
+
+import (
+	"flag"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// Size defines a size flag on flag.CommandLine and returns a pointer to the
+// variable holding its value
+func Size(name string, value int64, usage string) *filesize.Size {
+	return FlagSetSize(flag.CommandLine, name, value, usage)
+}
+
+// SizeVar defines a size flag on flag.CommandLine that stores into p
+func SizeVar(p *filesize.Size, name string, value int64, usage string) {
+	FlagSetSizeVar(flag.CommandLine, p, name, value, usage)
+}
+
+// FlagSetSize defines a size flag on fs and returns a pointer to the variable
+// holding its value
+func FlagSetSize(fs *flag.FlagSet, name string, value int64, usage string) *filesize.Size {
+	p := new(filesize.Size)
+	FlagSetSizeVar(fs, p, name, value, usage)
+	return p
+}
+
+// FlagSetSizeVar defines a size flag on fs that stores into p
+func FlagSetSizeVar(fs *flag.FlagSet, p *filesize.Size, name string, value int64, usage string) {
+	fs.Var(filesize.NewSizeValue(filesize.Size(value), p), name, usage)
+}
+
+// Rate defines a rate flag on flag.CommandLine and returns a pointer to the
+// variable holding its value
+func Rate(name string, value filesize.Rate, usage string) *filesize.Rate {
+	return FlagSetRate(flag.CommandLine, name, value, usage)
+}
+
+// RateVar defines a rate flag on flag.CommandLine that stores into p
+func RateVar(p *filesize.Rate, name string, value filesize.Rate, usage string) {
+	FlagSetRateVar(flag.CommandLine, p, name, value, usage)
+}
+
+// FlagSetRate defines a rate flag on fs and returns a pointer to the variable
+// holding its value
+func FlagSetRate(fs *flag.FlagSet, name string, value filesize.Rate, usage string) *filesize.Rate {
+	p := new(filesize.Rate)
+	FlagSetRateVar(fs, p, name, value, usage)
+	return p
+}
+
+// FlagSetRateVar defines a rate flag on fs that stores into p
+func FlagSetRateVar(fs *flag.FlagSet, p *filesize.Rate, name string, value filesize.Rate, usage string) {
+	fs.Var(filesize.NewRateValue(value, p), name, usage)
+}
@@ -0,0 +1,86 @@
+package sizeflag
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// TestFlagSetSize tests declaring and parsing size flags on a FlagSet
+func TestFlagSetSize(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected filesize.Size
+		hasError bool
+	}{
+		{nil, filesize.Size(4 * filesize.KiB), false},
+		{[]string{"-buffer-size", "1m"}, filesize.Size(filesize.MiB), false},
+		{[]string{"-buffer-size=2KB"}, 2000, false},
+		{[]string{"-buffer-size", "-4k"}, 0, true},
+		{[]string{"-buffer-size", "4 parsecs"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+		size := FlagSetSize(fs, "buffer-size", 4*filesize.KiB, "read buffer size")
+
+		err := fs.Parse(tc.args)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Parse(%q) expected error but got none", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.args, err)
+			continue
+		}
+		if *size != tc.expected {
+			t.Errorf("Parse(%q) = %d, expected %d", tc.args, int64(*size), int64(tc.expected))
+		}
+	}
+}
+
+// TestFlagSetSizeVar tests help output for a declared flag
+func TestFlagSetSizeVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	var cache filesize.Size
+	FlagSetSizeVar(fs, &cache, "cache", 512*filesize.MiB, "cache `size`")
+	if cache != filesize.Size(512*filesize.MiB) {
+		t.Errorf("FlagSetSizeVar() initial value = %d, expected %d", int64(cache), 512*filesize.MiB)
+	}
+
+	fs.PrintDefaults()
+	if help := out.String(); !strings.Contains(help, "-cache size") || !strings.Contains(help, "(default 512MiB)") {
+		t.Errorf("PrintDefaults() = %q, expected the humanized default 512MiB", help)
+	}
+}
+
+// TestFlagSetRate tests declaring and parsing rate flags on a FlagSet
+func TestFlagSetRate(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	rate := FlagSetRate(fs, "bandwidth", filesize.PerSecond(5*filesize.MiB), "bandwidth `limit`")
+	fs.PrintDefaults()
+	if help := out.String(); !strings.Contains(help, "-bandwidth limit") || !strings.Contains(help, "(default 5MiB/s)") {
+		t.Errorf("PrintDefaults() = %q, expected the default 5MiB/s", help)
+	}
+
+	if err := fs.Parse([]string{"-bandwidth", "1GB/h"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	expected := filesize.Rate{Bytes: filesize.Size(filesize.GB), Per: time.Hour}
+	if *rate != expected {
+		t.Errorf("Parse(1GB/h) = %v, expected %v", *rate, expected)
+	}
+}
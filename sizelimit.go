@@ -0,0 +1,113 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrLimitExceeded is returned, wrapped with the limit, by readers and
+// writers that refuse data past a configured size limit
+var ErrLimitExceeded = errors.New("size limit exceeded")
+
+// limitExceeded returns ErrLimitExceeded annotated with the limit
+func limitExceeded(limit int64) error {
+	return fmt.Errorf("%w: %s", ErrLimitExceeded, Size(limit))
+}
+
+// LimitReader returns a reader that reads from r until limit, a size string
+// such as "10MiB", and fails once r holds more than that
+//
+// Unlike io.LimitReader, which silently stops, reading past the limit
+// returns an error matching ErrLimitExceeded, so oversized uploads and
+// imports are rejected rather than truncated. The bytes up to the limit are
+// still delivered. An invalid limit string is reported immediately.
+func LimitReader(r io.Reader, limit string) (io.Reader, error) {
+	n, err := ParseSize(limit)
+	if err != nil {
+		return nil, err
+	}
+	return &limitReader{r: r, limit: n, remaining: n}, nil
+}
+
+// limitReader implements LimitReader
+type limitReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	err       error
+}
+
+// Read reads up to the remaining allowance plus one byte to detect excess
+func (lr *limitReader) Read(p []byte) (int, error) {
+	if lr.err != nil {
+		return 0, lr.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// read one byte past the limit so exceeding it can be noticed; a buffer
+	// longer than remaining implies remaining+1 cannot overflow
+	if int64(len(p)) > lr.remaining {
+		p = p[:lr.remaining+1]
+	}
+	n, err := lr.r.Read(p)
+	if int64(n) > lr.remaining {
+		n = int(lr.remaining)
+		lr.remaining = 0
+		lr.err = limitExceeded(lr.limit)
+		return n, lr.err
+	}
+
+	lr.remaining -= int64(n)
+	return n, err
+}
+
+// MaxBytesWriter is a writer that refuses to write past a size limit
+//
+// Writes are passed through until the limit is reached. The write that
+// would cross it writes only what fits and returns an error matching
+// ErrLimitExceeded, as does every write after it.
+type MaxBytesWriter struct {
+	w       io.Writer
+	limit   int64
+	written int64
+}
+
+// NewMaxBytesWriter returns a MaxBytesWriter writing at most limit, a size
+// string such as "500MiB", to w
+func NewMaxBytesWriter(w io.Writer, limit string) (*MaxBytesWriter, error) {
+	n, err := ParseSize(limit)
+	if err != nil {
+		return nil, err
+	}
+	return &MaxBytesWriter{w: w, limit: n}, nil
+}
+
+// Write writes p to the underlying writer as far as the limit allows
+func (mw *MaxBytesWriter) Write(p []byte) (int, error) {
+	remaining := mw.limit - mw.written
+	if int64(len(p)) <= remaining {
+		n, err := mw.w.Write(p)
+		mw.written += int64(n)
+		return n, err
+	}
+
+	n, err := mw.w.Write(p[:remaining])
+	mw.written += int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, limitExceeded(mw.limit)
+}
+
+// Written returns the number of bytes written so far
+func (mw *MaxBytesWriter) Written() Size {
+	return Size(mw.written)
+}
+
+// Limit returns the configured limit
+func (mw *MaxBytesWriter) Limit() Size {
+	return Size(mw.limit)
+}
@@ -0,0 +1,75 @@
+package filesize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestLimitReader tests reading within and beyond the limit
+func TestLimitReader(t *testing.T) {
+	testCases := []struct {
+		input    int
+		limit    string
+		expected int
+		hasError bool
+	}{
+		{1000, "1KiB", 1000, false},
+		{1024, "1KiB", 1024, false},
+		{1025, "1KiB", 1024, true},
+		{3 * 1024 * 1024, "1m", 1024 * 1024, true},
+		{0, "0", 0, false},
+		{1, "0", 0, true},
+		{1024, "9223372036854775807", 1024, false},
+		{1024, "7.5EiB", 1024, false},
+	}
+
+	for _, tc := range testCases {
+		r, err := LimitReader(bytes.NewReader(make([]byte, tc.input)), tc.limit)
+		if err != nil {
+			t.Errorf("LimitReader(%q) unexpected error: %v", tc.limit, err)
+			continue
+		}
+
+		n, err := io.Copy(io.Discard, r)
+		if tc.hasError != errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("reading %d bytes with limit %q returned error %v", tc.input, tc.limit, err)
+		}
+		if n != int64(tc.expected) {
+			t.Errorf("reading %d bytes with limit %q read %d, expected %d", tc.input, tc.limit, n, tc.expected)
+		}
+	}
+
+	if _, err := LimitReader(strings.NewReader(""), "lots"); err == nil {
+		t.Errorf("LimitReader(\"lots\") expected error but got none")
+	}
+}
+
+// TestMaxBytesWriter tests writes crossing the limit
+func TestMaxBytesWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewMaxBytesWriter(&out, "10")
+	if err != nil {
+		t.Fatalf("NewMaxBytesWriter() unexpected error: %v", err)
+	}
+
+	if n, err := w.Write([]byte("hello")); n != 5 || err != nil {
+		t.Errorf("Write(hello) = %d, %v, expected 5, nil", n, err)
+	}
+	n, err := w.Write([]byte(", world"))
+	if n != 5 || !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Write(, world) = %d, %v, expected 5 and ErrLimitExceeded", n, err)
+	}
+	if err != nil && err.Error() != "size limit exceeded: 10 B" {
+		t.Errorf("Write() error = %q, expected %q", err.Error(), "size limit exceeded: 10 B")
+	}
+	if n, err := w.Write([]byte("!")); n != 0 || !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Write(!) after the limit = %d, %v, expected 0 and ErrLimitExceeded", n, err)
+	}
+
+	if out.String() != "hello, wor" || w.Written() != 10 || w.Limit() != 10 {
+		t.Errorf("wrote %q (%d of %d), expected %q", out.String(), int64(w.Written()), int64(w.Limit()), "hello, wor")
+	}
+}
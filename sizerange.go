@@ -0,0 +1,178 @@
+package filesize
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// SizeRange is a window of sizes between Min and Max, each bound
+// inclusive unless marked exclusive
+//
+// Validators, file filters and schedulers use it to reason about size
+// windows. Since sizes are whole bytes, an exclusive bound is the same as
+// an inclusive one a byte further in, and the results of Intersect and
+// Union always have inclusive bounds. Use math.MaxInt64 as Max for a range
+// without an upper limit. The zero value contains only zero.
+type SizeRange struct {
+	Min, Max                   Size
+	MinExclusive, MaxExclusive bool
+}
+
+// NewSizeRange returns the inclusive range from min to max
+func NewSizeRange(min, max int64) SizeRange {
+	return SizeRange{Min: Size(min), Max: Size(max)}
+}
+
+// bounds returns the inclusive bounds, with ok false for an empty range
+func (r SizeRange) bounds() (low, high int64, ok bool) {
+	low, high = int64(r.Min), int64(r.Max)
+	if r.MinExclusive {
+		if low == math.MaxInt64 {
+			return 0, 0, false
+		}
+		low++
+	}
+	if r.MaxExclusive {
+		if high == math.MinInt64 {
+			return 0, 0, false
+		}
+		high--
+	}
+	return low, high, low <= high
+}
+
+// IsEmpty reports whether the range contains no sizes
+func (r SizeRange) IsEmpty() bool {
+	_, _, ok := r.bounds()
+	return !ok
+}
+
+// Contains reports whether size lies in the range
+func (r SizeRange) Contains(size int64) bool {
+	low, high, ok := r.bounds()
+	return ok && size >= low && size <= high
+}
+
+// Clamp returns the size in the range closest to size, which is size
+// itself when the range contains it
+//
+// An empty range has no sizes to offer and returns size unchanged.
+func (r SizeRange) Clamp(size int64) Size {
+	low, high, ok := r.bounds()
+	if !ok {
+		return Size(size)
+	}
+	return Size(min(max(size, low), high))
+}
+
+// Intersect returns the sizes in both ranges, which may be empty
+func (r SizeRange) Intersect(other SizeRange) SizeRange {
+	low1, high1, ok1 := r.bounds()
+	low2, high2, ok2 := other.bounds()
+	if !ok1 || !ok2 {
+		return emptySizeRange
+	}
+
+	result := NewSizeRange(max(low1, low2), min(high1, high2))
+	if result.Min > result.Max {
+		return emptySizeRange
+	}
+	return result
+}
+
+// Union returns the smallest range holding every size of both ranges,
+// reporting false when the ranges neither overlap nor touch, so that the
+// result also holds sizes from the gap between them
+func (r SizeRange) Union(other SizeRange) (SizeRange, bool) {
+	low1, high1, ok1 := r.bounds()
+	low2, high2, ok2 := other.bounds()
+	switch {
+	case !ok1 && !ok2:
+		return emptySizeRange, true
+	case !ok1:
+		return NewSizeRange(low2, high2), true
+	case !ok2:
+		return NewSizeRange(low1, high1), true
+	}
+
+	// the ranges touch when the later one starts at most a byte after the
+	// earlier one ends
+	if low1 > low2 {
+		low1, high1, low2, high2 = low2, high2, low1, high1
+	}
+	contiguous := high1 == math.MaxInt64 || low2 <= high1+1
+	return NewSizeRange(low1, max(high1, high2)), contiguous
+}
+
+// emptySizeRange is the canonical empty range
+var emptySizeRange = SizeRange{Min: 1, Max: 0}
+
+// String formats the range in interval notation, e.g. "[1 KiB, 1 GiB]",
+// "(0 B, 4 GiB)" or "[1 MiB, ∞)" when Max is math.MaxInt64
+func (r SizeRange) String() string {
+	if r.IsEmpty() {
+		return "∅"
+	}
+
+	open, close := "[", "]"
+	if r.MinExclusive {
+		open = "("
+	}
+	if r.MaxExclusive {
+		close = ")"
+	}
+	high := formatExact(int64(r.Max), " ")
+	if r.Max == math.MaxInt64 {
+		high, close = "∞", ")"
+	}
+	return fmt.Sprintf("%s%s, %s%s", open, formatExact(int64(r.Min), " "), high, close)
+}
+
+// flags for the binary encoding of SizeRange
+const (
+	rangeMinExclusive = 1 << iota
+	rangeMaxExclusive
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+//
+// The encoding is a flags byte for the exclusive bounds followed by Min
+// and Max as zig-zag varints, as Size encodes them.
+func (r SizeRange) MarshalBinary() ([]byte, error) {
+	var flags byte
+	if r.MinExclusive {
+		flags |= rangeMinExclusive
+	}
+	if r.MaxExclusive {
+		flags |= rangeMaxExclusive
+	}
+
+	data := []byte{flags}
+	data = binary.AppendVarint(data, int64(r.Min))
+	data = binary.AppendVarint(data, int64(r.Max))
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *SizeRange) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0]&^(rangeMinExclusive|rangeMaxExclusive) != 0 {
+		return fmt.Errorf("invalid binary size range encoding")
+	}
+	low, n := binary.Varint(data[1:])
+	if n <= 0 {
+		return fmt.Errorf("invalid binary size range encoding")
+	}
+	high, m := binary.Varint(data[1+n:])
+	if m <= 0 || 1+n+m != len(data) {
+		return fmt.Errorf("invalid binary size range encoding")
+	}
+
+	*r = SizeRange{
+		Min:          Size(low),
+		Max:          Size(high),
+		MinExclusive: data[0]&rangeMinExclusive != 0,
+		MaxExclusive: data[0]&rangeMaxExclusive != 0,
+	}
+	return nil
+}
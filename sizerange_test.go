@@ -0,0 +1,164 @@
+package filesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSizeRange_Contains tests membership with inclusive and exclusive bounds
+func TestSizeRange_Contains(t *testing.T) {
+	testCases := []struct {
+		r        SizeRange
+		size     int64
+		expected bool
+	}{
+		{NewSizeRange(KiB, GiB), KiB, true},
+		{NewSizeRange(KiB, GiB), GiB, true},
+		{NewSizeRange(KiB, GiB), KiB - 1, false},
+		{NewSizeRange(KiB, GiB), GiB + 1, false},
+		{SizeRange{Min: Size(KiB), Max: Size(GiB), MinExclusive: true}, KiB, false},
+		{SizeRange{Min: Size(KiB), Max: Size(GiB), MinExclusive: true}, KiB + 1, true},
+		{SizeRange{Min: Size(KiB), Max: Size(GiB), MaxExclusive: true}, GiB, false},
+		{NewSizeRange(0, math.MaxInt64), math.MaxInt64, true},
+		{SizeRange{}, 0, true},
+		{SizeRange{MinExclusive: true}, 0, false},
+		{NewSizeRange(GiB, KiB), MiB, false},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.r.Contains(tc.size); result != tc.expected {
+			t.Errorf("%v.Contains(%d) = %v, expected %v", tc.r, tc.size, result, tc.expected)
+		}
+	}
+}
+
+// TestSizeRange_Clamp tests pulling sizes into the range
+func TestSizeRange_Clamp(t *testing.T) {
+	testCases := []struct {
+		r        SizeRange
+		size     int64
+		expected Size
+	}{
+		{NewSizeRange(KiB, GiB), MiB, Size(MiB)},
+		{NewSizeRange(KiB, GiB), 10, Size(KiB)},
+		{NewSizeRange(KiB, GiB), TiB, Size(GiB)},
+		{SizeRange{Min: Size(KiB), Max: Size(GiB), MinExclusive: true, MaxExclusive: true}, 0, Size(KiB + 1)},
+		{SizeRange{Min: Size(KiB), Max: Size(GiB), MinExclusive: true, MaxExclusive: true}, TiB, Size(GiB - 1)},
+		{NewSizeRange(GiB, KiB), MiB, Size(MiB)},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.r.Clamp(tc.size); result != tc.expected {
+			t.Errorf("%v.Clamp(%d) = %d, expected %d", tc.r, tc.size, result, tc.expected)
+		}
+	}
+}
+
+// TestSizeRange_Intersect tests the overlap of two ranges
+func TestSizeRange_Intersect(t *testing.T) {
+	testCases := []struct {
+		a, b     SizeRange
+		expected SizeRange
+		empty    bool
+	}{
+		{NewSizeRange(KiB, GiB), NewSizeRange(MiB, TiB), NewSizeRange(MiB, GiB), false},
+		{NewSizeRange(KiB, GiB), NewSizeRange(MiB, 2*MiB), NewSizeRange(MiB, 2*MiB), false},
+		{NewSizeRange(0, KiB), NewSizeRange(KiB, MiB), NewSizeRange(KiB, KiB), false},
+		{SizeRange{Max: Size(KiB), MaxExclusive: true}, NewSizeRange(KiB, MiB), SizeRange{}, true},
+		{NewSizeRange(0, KiB), NewSizeRange(MiB, GiB), SizeRange{}, true},
+		{SizeRange{Min: Size(KiB), Max: Size(MiB), MinExclusive: true}, NewSizeRange(0, MiB), NewSizeRange(KiB+1, MiB), false},
+	}
+
+	for _, tc := range testCases {
+		result := tc.a.Intersect(tc.b)
+		if tc.empty {
+			if !result.IsEmpty() {
+				t.Errorf("%v.Intersect(%v) = %v, expected an empty range", tc.a, tc.b, result)
+			}
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%v.Intersect(%v) = %+v, expected %+v", tc.a, tc.b, result, tc.expected)
+		}
+	}
+}
+
+// TestSizeRange_Union tests joining ranges and detecting gaps
+func TestSizeRange_Union(t *testing.T) {
+	testCases := []struct {
+		a, b       SizeRange
+		expected   SizeRange
+		contiguous bool
+	}{
+		{NewSizeRange(KiB, GiB), NewSizeRange(MiB, TiB), NewSizeRange(KiB, TiB), true},
+		{NewSizeRange(MiB, TiB), NewSizeRange(KiB, GiB), NewSizeRange(KiB, TiB), true},
+		{NewSizeRange(0, KiB-1), NewSizeRange(KiB, MiB), NewSizeRange(0, MiB), true},
+		{NewSizeRange(0, KiB), NewSizeRange(MiB, GiB), NewSizeRange(0, GiB), false},
+		{SizeRange{Max: Size(KiB), MaxExclusive: true}, SizeRange{Min: Size(KiB), Max: Size(MiB), MinExclusive: true}, NewSizeRange(0, MiB), false},
+		{NewSizeRange(GiB, KiB), NewSizeRange(KiB, MiB), NewSizeRange(KiB, MiB), true},
+		{NewSizeRange(0, math.MaxInt64), NewSizeRange(KiB, MiB), NewSizeRange(0, math.MaxInt64), true},
+	}
+
+	for _, tc := range testCases {
+		result, contiguous := tc.a.Union(tc.b)
+		if result != tc.expected || contiguous != tc.contiguous {
+			t.Errorf("%v.Union(%v) = (%+v, %v), expected (%+v, %v)",
+				tc.a, tc.b, result, contiguous, tc.expected, tc.contiguous)
+		}
+	}
+}
+
+// TestSizeRange_String tests interval notation
+func TestSizeRange_String(t *testing.T) {
+	testCases := []struct {
+		r        SizeRange
+		expected string
+	}{
+		{NewSizeRange(KiB, GiB), "[1 KiB, 1 GiB]"},
+		{SizeRange{Max: Size(4 * GiB), MinExclusive: true, MaxExclusive: true}, "(0 B, 4 GiB)"},
+		{NewSizeRange(MiB, math.MaxInt64), "[1 MiB, ∞)"},
+		{NewSizeRange(1536, 1500), "∅"},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.r.String(); result != tc.expected {
+			t.Errorf("%+v.String() = %q, expected %q", tc.r, result, tc.expected)
+		}
+	}
+}
+
+// TestSizeRange_MarshalBinary tests that ranges round-trip through binary
+func TestSizeRange_MarshalBinary(t *testing.T) {
+	ranges := []SizeRange{
+		{},
+		NewSizeRange(KiB, GiB),
+		NewSizeRange(-MiB, math.MaxInt64),
+		{Min: Size(KiB), Max: Size(GiB), MinExclusive: true},
+		{Min: math.MinInt64, Max: Size(GiB), MinExclusive: true, MaxExclusive: true},
+	}
+
+	for _, r := range ranges {
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Errorf("%+v.MarshalBinary() unexpected error: %v", r, err)
+			continue
+		}
+
+		var decoded SizeRange
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Errorf("UnmarshalBinary(%x) unexpected error: %v", data, err)
+			continue
+		}
+		if decoded != r {
+			t.Errorf("UnmarshalBinary(%x) = %+v, expected %+v", data, decoded, r)
+		}
+	}
+
+	invalid := [][]byte{nil, {0}, {0, 2}, {4, 2, 2}, {0, 2, 2, 0}, {0, 0x80}}
+	for _, data := range invalid {
+		var decoded SizeRange
+		if err := decoded.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%x) expected error but got none", data)
+		}
+	}
+}
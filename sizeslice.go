@@ -0,0 +1,74 @@
+package filesize
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// SizeSlice is a list of sizes that sorts and searches without converting
+// to and from []int64
+//
+// It implements sort.Interface in ascending order, and since it is a plain
+// []Size underneath, the slices package works on it directly too.
+type SizeSlice []Size
+
+// ParseSizes parses each string with ParseSize, failing on the first
+// invalid one with an error naming its position
+func ParseSizes(strs ...string) (SizeSlice, error) {
+	sizes := make(SizeSlice, len(strs))
+	for i, str := range strs {
+		bytes, err := ParseSize(str)
+		if err != nil {
+			return nil, fmt.Errorf("size %d: %w", i, err)
+		}
+		sizes[i] = Size(bytes)
+	}
+	return sizes, nil
+}
+
+// Len implements sort.Interface
+func (s SizeSlice) Len() int { return len(s) }
+
+// Less implements sort.Interface, ordering smaller sizes first
+func (s SizeSlice) Less(i, j int) bool { return s[i] < s[j] }
+
+// Swap implements sort.Interface
+func (s SizeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// SortAscending sorts the sizes in place, smallest first
+func (s SizeSlice) SortAscending() {
+	slices.Sort(s)
+}
+
+// SortDescending sorts the sizes in place, largest first
+func (s SizeSlice) SortDescending() {
+	slices.SortFunc(s, func(a, b Size) int {
+		return cmp.Compare(b, a)
+	})
+}
+
+// Contains reports whether size is in the slice
+func (s SizeSlice) Contains(size Size) bool {
+	return slices.Contains(s, size)
+}
+
+// Index returns the index of the first occurrence of size, or -1
+func (s SizeSlice) Index(size Size) int {
+	return slices.Index(s, size)
+}
+
+// Search finds size in a slice sorted in ascending order, returning the
+// position where it is or would be inserted and whether it was found
+func (s SizeSlice) Search(size Size) (int, bool) {
+	return slices.BinarySearch(s, size)
+}
+
+// Int64s returns the sizes as a new []int64
+func (s SizeSlice) Int64s() []int64 {
+	values := make([]int64, len(s))
+	for i, size := range s {
+		values[i] = int64(size)
+	}
+	return values
+}
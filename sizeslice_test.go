@@ -0,0 +1,77 @@
+package filesize
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// TestParseSizes tests parsing lists of sizes
+func TestParseSizes(t *testing.T) {
+	sizes, err := ParseSizes("4k", "1MiB", "512")
+	if err != nil {
+		t.Fatalf("ParseSizes() unexpected error: %v", err)
+	}
+	expected := SizeSlice{Size(4 * KiB), Size(MiB), 512}
+	if !slices.Equal(sizes, expected) {
+		t.Errorf("ParseSizes() = %v, expected %v", sizes, expected)
+	}
+
+	if _, err := ParseSizes("4k", "lots"); err == nil {
+		t.Errorf("ParseSizes() with an invalid size expected error but got none")
+	}
+}
+
+// TestSizeSlice_Sort tests ascending, descending and sort.Interface order
+func TestSizeSlice_Sort(t *testing.T) {
+	sizes := SizeSlice{Size(MiB), 10, Size(GiB), Size(KiB)}
+
+	sizes.SortAscending()
+	if expected := (SizeSlice{10, Size(KiB), Size(MiB), Size(GiB)}); !slices.Equal(sizes, expected) {
+		t.Errorf("SortAscending() = %v, expected %v", sizes, expected)
+	}
+
+	sizes.SortDescending()
+	if expected := (SizeSlice{Size(GiB), Size(MiB), Size(KiB), 10}); !slices.Equal(sizes, expected) {
+		t.Errorf("SortDescending() = %v, expected %v", sizes, expected)
+	}
+
+	sort.Sort(sizes)
+	if !sort.IsSorted(sizes) || sizes[0] != 10 {
+		t.Errorf("sort.Sort() = %v, expected ascending order", sizes)
+	}
+}
+
+// TestSizeSlice_Search tests Contains, Index and Search
+func TestSizeSlice_Search(t *testing.T) {
+	sizes := SizeSlice{10, Size(KiB), Size(MiB), Size(KiB)}
+
+	testCases := []struct {
+		size     Size
+		contains bool
+		index    int
+	}{
+		{Size(KiB), true, 1},
+		{Size(MiB), true, 2},
+		{Size(GiB), false, -1},
+	}
+	for _, tc := range testCases {
+		if result := sizes.Contains(tc.size); result != tc.contains {
+			t.Errorf("Contains(%d) = %v, expected %v", int64(tc.size), result, tc.contains)
+		}
+		if result := sizes.Index(tc.size); result != tc.index {
+			t.Errorf("Index(%d) = %d, expected %d", int64(tc.size), result, tc.index)
+		}
+	}
+
+	sizes.SortAscending()
+	if i, found := sizes.Search(Size(MiB)); !found || i != 3 {
+		t.Errorf("Search(1 MiB) = (%d, %v), expected (3, true)", i, found)
+	}
+	if i, found := sizes.Search(100); found || i != 1 {
+		t.Errorf("Search(100) = (%d, %v), expected (1, false)", i, found)
+	}
+	if values := sizes.Int64s(); !slices.Equal(values, []int64{10, KiB, KiB, MiB}) {
+		t.Errorf("Int64s() = %v, expected %v", values, []int64{10, KiB, KiB, MiB})
+	}
+}
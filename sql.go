@@ -0,0 +1,45 @@
+package filesize
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+)
+
+// Value implements driver.Valuer, storing the size as an integer byte count
+//
+// Integer columns keep sizes sortable and summable in SQL, so Value never
+// writes the human-readable form.
+func (s Size) Value() (driver.Value, error) {
+	return int64(s), nil
+}
+
+// Scan implements sql.Scanner
+//
+// Integer columns are taken as byte counts and text columns, delivered as
+// string or []byte, are parsed like UnmarshalText so that "4k" and "1.5GiB"
+// work as well as "4096". Whole-number floats are accepted for drivers that
+// report NUMERIC columns that way. A NULL scans as zero; use sql.Null[Size]
+// to tell the two apart.
+func (s *Size) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = 0
+		return nil
+	case int64:
+		*s = Size(v)
+		return nil
+	case float64:
+		if v != math.Trunc(v) || v >= math.MaxInt64 || v < math.MinInt64 {
+			return fmt.Errorf("cannot scan %v into Size: not a whole number of bytes", v)
+		}
+		*s = Size(v)
+		return nil
+	case string:
+		return s.UnmarshalText([]byte(v))
+	case []byte:
+		return s.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Size", src)
+	}
+}
@@ -0,0 +1,77 @@
+package filesize
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// compile-time checks that Size works with database/sql
+var (
+	_ sql.Scanner   = (*Size)(nil)
+	_ driver.Valuer = Size(0)
+)
+
+// TestSize_Scan tests scanning the column types drivers produce
+func TestSize_Scan(t *testing.T) {
+	testCases := []struct {
+		input    any
+		expected Size
+		hasError bool
+	}{
+		{int64(4096), 4096, false},
+		{"4k", 4096, false},
+		{[]byte("1.5GiB"), Size(GiB * 3 / 2), false},
+		{"1024", 1024, false},
+		{float64(2048), 2048, false},
+		{nil, 0, false},
+		{float64(1.5), 0, true},
+		{"1xy", 0, true},
+		{true, 0, true},
+	}
+
+	for _, tc := range testCases {
+		s := Size(7)
+		err := s.Scan(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("Scan(%#v) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%#v) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if s != tc.expected {
+			t.Errorf("Scan(%#v) = %d, expected %d", tc.input, int64(s), int64(tc.expected))
+		}
+	}
+}
+
+// TestSize_Value tests that sizes are stored as integers
+func TestSize_Value(t *testing.T) {
+	value, err := Size(2 * GiB).Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	if value != driver.Value(2*GiB) {
+		t.Errorf("Value() = %#v, expected %#v", value, 2*GiB)
+	}
+
+	// the driver package must accept the value as-is
+	if !driver.IsValue(value) {
+		t.Errorf("Value() = %#v is not a valid driver.Value", value)
+	}
+}
+
+// TestSize_NullScan tests the sql.Null wrapper for nullable columns
+func TestSize_NullScan(t *testing.T) {
+	var n sql.Null[Size]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Null[Size].Scan(nil) = %+v, %v, expected invalid", n, err)
+	}
+	if err := n.Scan("8m"); err != nil || !n.Valid || n.V != Size(8*MiB) {
+		t.Errorf("Null[Size].Scan(%q) = %+v, %v, expected %d", "8m", n, err, 8*MiB)
+	}
+}
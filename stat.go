@@ -0,0 +1,145 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Stat accumulates count, sum, mean and variance of a stream of sizes in
+// one pass and constant memory
+//
+// The mean and variance use Welford's online algorithm, which stays
+// accurate over billions of values where summing squares would not, and
+// the sum switches to exact big-integer arithmetic if it outgrows an
+// int64. The zero value is an empty accumulator ready to use. A Stat is
+// not safe for concurrent use; give each goroutine its own and Merge them.
+type Stat struct {
+	count    int64
+	mean     float64
+	m2       float64
+	min, max int64
+	sum      int64
+	sumBig   *big.Int
+}
+
+// Add records one size
+func (s *Stat) Add(size int64) {
+	s.count++
+	if s.count == 1 || size < s.min {
+		s.min = size
+	}
+	if s.count == 1 || size > s.max {
+		s.max = size
+	}
+	s.addSum(size)
+
+	delta := float64(size) - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (float64(size) - s.mean)
+}
+
+// Merge adds the values recorded by other, as if each had been added to s
+func (s *Stat) Merge(other *Stat) {
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		*s = *other
+		if other.sumBig != nil {
+			s.sumBig = new(big.Int).Set(other.sumBig)
+		}
+		return
+	}
+
+	// combine the moments with Chan et al.'s parallel formula
+	n := float64(s.count + other.count)
+	delta := other.mean - s.mean
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/n
+	s.mean += delta * float64(other.count) / n
+	s.count += other.count
+	s.min = min(s.min, other.min)
+	s.max = max(s.max, other.max)
+	if other.sumBig == nil {
+		s.addSum(other.sum)
+		return
+	}
+	if s.sumBig == nil {
+		s.sumBig = big.NewInt(s.sum)
+	}
+	s.sumBig.Add(s.sumBig, other.sumBig)
+}
+
+// addSum adds n to the sum, moving to big-integer arithmetic for good once
+// the int64 sum would overflow
+func (s *Stat) addSum(n int64) {
+	if s.sumBig == nil {
+		if sum, ok := addInt64(s.sum, n); ok {
+			s.sum = sum
+			return
+		}
+		s.sumBig = big.NewInt(s.sum)
+	}
+	s.sumBig.Add(s.sumBig, big.NewInt(n))
+}
+
+// Count returns the number of sizes recorded
+func (s *Stat) Count() int64 {
+	return s.count
+}
+
+// Sum returns the exact total of the sizes recorded
+func (s *Stat) Sum() BigSize {
+	if s.sumBig != nil {
+		return NewBigSize(s.sumBig)
+	}
+	return BigSizeFromInt64(s.sum)
+}
+
+// Mean returns the mean size, or 0 when nothing was recorded
+func (s *Stat) Mean() float64 {
+	return s.mean
+}
+
+// Min returns the smallest size recorded, or 0 when nothing was recorded
+func (s *Stat) Min() Size {
+	return Size(s.min)
+}
+
+// Max returns the largest size recorded, or 0 when nothing was recorded
+func (s *Stat) Max() Size {
+	return Size(s.max)
+}
+
+// Variance returns the population variance in square bytes, or 0 for
+// fewer than two sizes
+func (s *Stat) Variance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count)
+}
+
+// SampleVariance returns the unbiased sample variance, dividing by n-1,
+// or 0 for fewer than two sizes
+func (s *Stat) SampleVariance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the population standard deviation in bytes
+func (s *Stat) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// String summarizes the accumulator, e.g. "1.2M sizes, mean 2.97 MiB,
+// stddev 1.10 MiB, min 0 B, max 12.0 GiB"
+func (s *Stat) String() string {
+	if s.count == 0 {
+		return "0 sizes"
+	}
+	return fmt.Sprintf("%s sizes, mean %s, stddev %s, min %s, max %s",
+		formatCount(s.count), Size(math.Round(s.mean)), Size(math.Round(s.StdDev())), s.Min(), s.Max())
+}
@@ -0,0 +1,111 @@
+package filesize
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestStat tests the one-pass figures against known values
+func TestStat(t *testing.T) {
+	var s Stat
+	for _, size := range []int64{2, 4, 4, 4, 5, 5, 7, 9} {
+		s.Add(size)
+	}
+
+	if s.Count() != 8 {
+		t.Errorf("Count() = %d, expected 8", s.Count())
+	}
+	if sum, ok := s.Sum().Int64(); !ok || sum != 40 {
+		t.Errorf("Sum() = %v, expected 40", s.Sum())
+	}
+	if s.Mean() != 5 {
+		t.Errorf("Mean() = %g, expected 5", s.Mean())
+	}
+	if s.Variance() != 4 || s.StdDev() != 2 {
+		t.Errorf("Variance(), StdDev() = %g, %g, expected 4, 2", s.Variance(), s.StdDev())
+	}
+	if math.Abs(s.SampleVariance()-32.0/7) > 1e-12 {
+		t.Errorf("SampleVariance() = %g, expected %g", s.SampleVariance(), 32.0/7)
+	}
+	if s.Min() != 2 || s.Max() != 9 {
+		t.Errorf("Min(), Max() = %d, %d, expected 2, 9", int64(s.Min()), int64(s.Max()))
+	}
+	if result := s.String(); result != "8 sizes, mean 5 B, stddev 2 B, min 2 B, max 9 B" {
+		t.Errorf("String() = %q", result)
+	}
+
+	var empty Stat
+	if empty.String() != "0 sizes" || empty.Variance() != 0 || empty.Mean() != 0 {
+		t.Errorf("empty Stat = %q, variance %g", empty.String(), empty.Variance())
+	}
+}
+
+// TestStat_Precision tests that large offsets do not destroy the variance,
+// the failure mode of summing squares
+func TestStat_Precision(t *testing.T) {
+	var s Stat
+	for _, size := range []int64{4, 7, 13, 16} {
+		s.Add(TiB + size)
+	}
+	if s.Variance() != 22.5 {
+		t.Errorf("Variance() with a 1 TiB offset = %g, expected 22.5", s.Variance())
+	}
+}
+
+// TestStat_Overflow tests exact sums past the int64 range
+func TestStat_Overflow(t *testing.T) {
+	var s Stat
+	s.Add(math.MaxInt64)
+	s.Add(math.MaxInt64)
+	s.Add(2)
+
+	expected := new(big.Int).Lsh(big.NewInt(1), 64)
+	if s.Sum().Int().Cmp(expected) != 0 {
+		t.Errorf("Sum() = %s, expected %s", s.Sum().Int(), expected)
+	}
+}
+
+// TestStat_Merge tests that merging matches adding everything to one Stat
+func TestStat_Merge(t *testing.T) {
+	var all, a, b, empty Stat
+	for i := int64(1); i <= 100; i++ {
+		all.Add(i * i)
+		if i%3 == 0 {
+			a.Add(i * i)
+		} else {
+			b.Add(i * i)
+		}
+	}
+
+	a.Merge(&b)
+	a.Merge(&empty)
+	if a.Count() != all.Count() || a.Min() != all.Min() || a.Max() != all.Max() {
+		t.Errorf("Merge() = %v, expected %v", a.String(), all.String())
+	}
+	if a.Sum().Cmp(all.Sum()) != 0 {
+		t.Errorf("Merge() sum = %v, expected %v", a.Sum(), all.Sum())
+	}
+	if math.Abs(a.Mean()-all.Mean()) > 1e-9 || math.Abs(a.Variance()-all.Variance())/all.Variance() > 1e-12 {
+		t.Errorf("Merge() mean, variance = %g, %g, expected %g, %g", a.Mean(), a.Variance(), all.Mean(), all.Variance())
+	}
+
+	// merging into an empty Stat copies, without sharing the big sum
+	var big1, copied Stat
+	big1.Add(math.MaxInt64)
+	big1.Add(math.MaxInt64)
+	copied.Merge(&big1)
+	big1.Add(1)
+	if copied.Sum().Cmp(big1.Sum()) == 0 {
+		t.Errorf("Merge() shared its sum with the merged Stat")
+	}
+}
+
+// BenchmarkStat_Add benchmarks recording sizes
+func BenchmarkStat_Add(b *testing.B) {
+	var s Stat
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(int64(i) * KiB)
+	}
+}
@@ -0,0 +1,82 @@
+package filesize
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Stats describes a collection of sizes
+//
+// All figures are Sizes, so they print in human-readable form. The mean is
+// rounded to the nearest byte and the median of an even number of sizes is
+// the mean of the middle two, rounded down.
+type Stats struct {
+	Count                  int
+	Min, Max, Mean, Median Size
+}
+
+// ComputeStats returns the statistics of sizes, or zero Stats when it is
+// empty
+//
+// The input is not modified. The mean is computed exactly, so it is right
+// even when the total would overflow an int64.
+func ComputeStats(sizes []int64) Stats {
+	if len(sizes) == 0 {
+		return Stats{}
+	}
+
+	sorted := slices.Clone(sizes)
+	slices.Sort(sorted)
+	return statsOfSorted(sorted)
+}
+
+// statsOfSorted implements ComputeStats for sizes already sorted ascending,
+// which must not be empty
+func statsOfSorted(sorted []int64) Stats {
+	return Stats{
+		Count:  len(sorted),
+		Min:    Size(sorted[0]),
+		Max:    Size(sorted[len(sorted)-1]),
+		Mean:   Size(meanOf(SumBig(sorted).Int(), int64(len(sorted)))),
+		Median: Size(medianOf(sorted)),
+	}
+}
+
+// Stats returns the statistics of the sizes like ComputeStats
+func (s SizeSlice) Stats() Stats {
+	return ComputeStats(s.Int64s())
+}
+
+// String formats the statistics on one line, e.g. "3 sizes: min 1.00 KiB,
+// median 4.00 KiB, mean 5.67 KiB, max 12.0 KiB"
+func (s Stats) String() string {
+	if s.Count == 0 {
+		return "0 sizes"
+	}
+	return fmt.Sprintf("%d sizes: min %s, median %s, mean %s, max %s",
+		s.Count, s.Min, s.Median, s.Mean, s.Max)
+}
+
+// meanOf divides sum by n, rounding half away from zero; the result lies
+// between the smallest and largest value, so it fits in an int64
+func meanOf(sum *big.Int, n int64) int64 {
+	count := big.NewInt(n)
+	quo, rem := new(big.Int).QuoRem(sum, count, new(big.Int))
+	if rem.Lsh(rem.Abs(rem), 1).Cmp(count) >= 0 {
+		quo.Add(quo, big.NewInt(int64(sum.Sign())))
+	}
+	return quo.Int64()
+}
+
+// medianOf returns the median of sorted values without overflowing
+func medianOf(sorted []int64) int64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+
+	// halve before adding so that large values cannot overflow
+	a, b := sorted[mid-1], sorted[mid]
+	return a>>1 + b>>1 + (a&b)&1
+}
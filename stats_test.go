@@ -0,0 +1,58 @@
+package filesize
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestComputeStats tests min, max, mean and median
+func TestComputeStats(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int64
+		expected Stats
+	}{
+		{"empty", nil, Stats{}},
+		{"single", []int64{KiB}, Stats{1, Size(KiB), Size(KiB), Size(KiB), Size(KiB)}},
+		{"odd", []int64{12 * KiB, KiB, 4 * KiB}, Stats{3, Size(KiB), Size(12 * KiB), 5803, Size(4 * KiB)}},
+		{"even", []int64{10, 40, 20, 30}, Stats{4, 10, 40, 25, 25}},
+		{"median rounds down", []int64{1, 2}, Stats{2, 1, 2, 2, 1}},
+		{"mean rounds to nearest", []int64{1, 1, 2}, Stats{3, 1, 2, 1, 1}},
+		{"negative", []int64{-3, -2}, Stats{2, -3, -2, -3, -3}},
+		{"beyond int64", []int64{math.MaxInt64, math.MaxInt64 - 2}, Stats{2, math.MaxInt64 - 2, math.MaxInt64, math.MaxInt64 - 1, math.MaxInt64 - 1}},
+	}
+
+	for _, tc := range testCases {
+		if result := ComputeStats(tc.input); result != tc.expected {
+			t.Errorf("%s: ComputeStats(%v) = %+v, expected %+v", tc.name, tc.input, result, tc.expected)
+		}
+	}
+
+	// the input is left in its original order
+	input := []int64{3, 1, 2}
+	ComputeStats(input)
+	if !slices.Equal(input, []int64{3, 1, 2}) {
+		t.Errorf("ComputeStats() reordered its input to %v", input)
+	}
+}
+
+// TestStats_String tests the one-line summary
+func TestStats_String(t *testing.T) {
+	testCases := []struct {
+		input    SizeSlice
+		expected string
+	}{
+		{nil, "0 sizes"},
+		{
+			SizeSlice{Size(12 * KiB), Size(KiB), Size(4 * KiB)},
+			"3 sizes: min 1.00 KiB, median 4.00 KiB, mean 5.67 KiB, max 12.0 KiB",
+		},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.input.Stats().String(); result != tc.expected {
+			t.Errorf("Stats().String() = %q, expected %q", result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package filesize
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Sum totals sizes, returning an error matching ErrOverflow instead of a
+// wrapped-around total when the sum leaves the int64 range
+//
+// Storage scanners adding up millions of file sizes can pass 8 EiB with
+// sparse files or corrupt metadata, and a silently negative total is far
+// worse than an error. Negative entries, such as deltas, are allowed; the
+// error reports the first index at which the running total overflowed.
+// SumBig totals without limits.
+func Sum(sizes []int64) (int64, error) {
+	var total int64
+	for i, size := range sizes {
+		sum, ok := addInt64(total, size)
+		if !ok {
+			return 0, fmt.Errorf("sum at index %d: %w", i, ErrOverflow)
+		}
+		total = sum
+	}
+	return total, nil
+}
+
+// SumBig totals sizes exactly, however large the result
+//
+// It adds in int64 while it can and only switches to big.Int arithmetic
+// once the running total would overflow.
+func SumBig(sizes []int64) BigSize {
+	var total int64
+	for i, size := range sizes {
+		sum, ok := addInt64(total, size)
+		if !ok {
+			exact := big.NewInt(total)
+			for _, rest := range sizes[i:] {
+				exact.Add(exact, big.NewInt(rest))
+			}
+			return BigSize{bytes: exact}
+		}
+		total = sum
+	}
+	return BigSizeFromInt64(total)
+}
+
+// Sum totals the sizes like the package-level Sum
+func (s SizeSlice) Sum() (Size, error) {
+	var total int64
+	for i, size := range s {
+		sum, ok := addInt64(total, int64(size))
+		if !ok {
+			return 0, fmt.Errorf("sum at index %d: %w", i, ErrOverflow)
+		}
+		total = sum
+	}
+	return Size(total), nil
+}
+
+// addInt64 returns a + b, reporting false when the sum overflows
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
@@ -0,0 +1,89 @@
+package filesize
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestSum tests totaling with overflow detection
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int64
+		expected int64
+		hasError bool
+	}{
+		{"empty", nil, 0, false},
+		{"sizes", []int64{KiB, MiB, GiB}, KiB + MiB + GiB, false},
+		{"deltas", []int64{GiB, -MiB, -KiB}, GiB - MiB - KiB, false},
+		{"max", []int64{math.MaxInt64 - 1, 1}, math.MaxInt64, false},
+		{"recovers", []int64{math.MaxInt64, -1, 1}, math.MaxInt64, false},
+		{"overflow", []int64{math.MaxInt64, 1}, 0, true},
+		{"large files", []int64{5 * EiB, 5 * EiB}, 0, true},
+		{"underflow", []int64{math.MinInt64, -1}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := Sum(tc.input)
+		sliceResult, sliceErr := SizeSlice(sliceOfSizes(tc.input)).Sum()
+		if tc.hasError {
+			if !errors.Is(err, ErrOverflow) || !errors.Is(sliceErr, ErrOverflow) {
+				t.Errorf("%s: Sum() error = %v, %v, expected ErrOverflow", tc.name, err, sliceErr)
+			}
+			continue
+		}
+		if err != nil || sliceErr != nil {
+			t.Errorf("%s: Sum() unexpected error: %v, %v", tc.name, err, sliceErr)
+			continue
+		}
+		if result != tc.expected || int64(sliceResult) != tc.expected {
+			t.Errorf("%s: Sum() = %d, %d, expected %d", tc.name, result, int64(sliceResult), tc.expected)
+		}
+	}
+}
+
+// TestSumBig tests exact totals past the int64 range
+func TestSumBig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int64
+		expected string
+	}{
+		{"empty", nil, "0"},
+		{"small", []int64{KiB, MiB}, "1049600"},
+		{"overflow", []int64{5 * EiB, 5 * EiB, 1}, "11529215046068469761"},
+		{"back in range", []int64{math.MaxInt64, 1, -2}, "9223372036854775806"},
+		{"underflow", []int64{math.MinInt64, -1}, "-9223372036854775809"},
+	}
+
+	for _, tc := range testCases {
+		expected, _ := new(big.Int).SetString(tc.expected, 10)
+		if result := SumBig(tc.input).Int(); result.Cmp(expected) != 0 {
+			t.Errorf("%s: SumBig() = %s, expected %s", tc.name, result, expected)
+		}
+	}
+}
+
+// sliceOfSizes converts int64 values to sizes
+func sliceOfSizes(values []int64) []Size {
+	sizes := make([]Size, len(values))
+	for i, v := range values {
+		sizes[i] = Size(v)
+	}
+	return sizes
+}
+
+// BenchmarkSum benchmarks totaling a million sizes
+func BenchmarkSum(b *testing.B) {
+	sizes := make([]int64, 1_000_000)
+	for i := range sizes {
+		sizes[i] = int64(i) * KiB
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Sum(sizes)
+	}
+}
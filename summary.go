@@ -0,0 +1,85 @@
+package filesize
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Summary condenses a size distribution into the figures an end-of-job log
+// line needs
+//
+// Total saturates at the largest int64 instead of overflowing. Noun names
+// what was counted in String and defaults to "files".
+type Summary struct {
+	Count          int64
+	Total          Size
+	Min, Max, Mean Size
+	Median, P99    Size
+	Noun           string
+}
+
+// Summarize computes the summary of sizes
+func Summarize(sizes []int64) Summary {
+	if len(sizes) == 0 {
+		return Summary{}
+	}
+
+	// one sorted copy serves both the statistics and the percentile
+	sorted := slices.Clone(sizes)
+	slices.Sort(sorted)
+	stats := statsOfSorted(sorted)
+
+	var total Size
+	for _, size := range sizes {
+		total = saturatingAdd(total, size)
+	}
+
+	return Summary{
+		Count:  int64(stats.Count),
+		Total:  total,
+		Min:    stats.Min,
+		Max:    stats.Max,
+		Mean:   stats.Mean,
+		Median: stats.Median,
+		P99:    Size(percentileOf(sorted, 99)),
+	}
+}
+
+// String formats the summary on one line, e.g. "1.2M files, 3.40 TiB
+// total, mean 2.97 MiB, median 48.0 KiB, p99 210 MiB, min 0 B, max 12.0 GiB"
+func (s Summary) String() string {
+	noun := s.Noun
+	if noun == "" {
+		noun = "files"
+	}
+	if s.Count == 0 {
+		return "0 " + noun
+	}
+	return fmt.Sprintf("%s %s, %s total, mean %s, median %s, p99 %s, min %s, max %s",
+		formatCount(s.Count), noun, s.Total, s.Mean, s.Median, s.P99, s.Min, s.Max)
+}
+
+// formatCount abbreviates a count the way sizes are, e.g. "842", "12k" or
+// "1.2M", keeping one decimal below ten
+func formatCount(n int64) string {
+	if n > -1000 && n < 1000 {
+		return strconv.FormatInt(n, 10)
+	}
+
+	value := float64(n)
+	for _, suffix := range []string{"k", "M", "G", "T", "P", "E"} {
+		value /= 1000
+		precision := 0
+		if math.Abs(value) < 9.95 {
+			precision = 1
+		}
+
+		// values that round to 1000 move on to the next unit
+		if math.Abs(value) < 999.5 || suffix == "E" {
+			return strconv.FormatFloat(value, 'f', precision, 64) + suffix
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,97 @@
+package filesize
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSummarize tests the combined figures
+func TestSummarize(t *testing.T) {
+	sizes := make([]int64, 0, 100)
+	for i := int64(1); i <= 100; i++ {
+		sizes = append(sizes, i*KiB)
+	}
+
+	s := Summarize(sizes)
+	expected := Summary{
+		Count:  100,
+		Total:  Size(5050 * KiB),
+		Min:    Size(KiB),
+		Max:    Size(100 * KiB),
+		Mean:   Size(50*KiB + 512),
+		Median: Size(50*KiB + 512),
+		P99:    Size(99*KiB + 10),
+	}
+	if s != expected {
+		t.Errorf("Summarize() = %+v, expected %+v", s, expected)
+	}
+
+	if empty := Summarize(nil); empty != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, expected zero", empty)
+	}
+
+	overflow := Summarize([]int64{math.MaxInt64, math.MaxInt64})
+	if overflow.Total != math.MaxInt64 {
+		t.Errorf("Summarize() total = %d, expected saturation at MaxInt64", int64(overflow.Total))
+	}
+}
+
+// TestSummary_String tests the one-line form
+func TestSummary_String(t *testing.T) {
+	testCases := []struct {
+		summary  Summary
+		expected string
+	}{
+		{Summary{}, "0 files"},
+		{Summary{Noun: "objects"}, "0 objects"},
+		{
+			Summary{
+				Count:  1_234_567,
+				Total:  Size(3*TiB + 400*GiB),
+				Mean:   Size(3 * MiB),
+				Median: Size(48 * KiB),
+				P99:    Size(210 * MiB),
+				Max:    Size(12 * GiB),
+			},
+			"1.2M files, 3.39 TiB total, mean 3.00 MiB, median 48.0 KiB, p99 210 MiB, min 0 B, max 12.0 GiB",
+		},
+		{
+			Summary{Count: 3, Total: 30, Min: 8, Mean: 10, Median: 10, P99: 12, Max: 12, Noun: "objects"},
+			"3 objects, 30 B total, mean 10 B, median 10 B, p99 12 B, min 8 B, max 12 B",
+		},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.summary.String(); result != tc.expected {
+			t.Errorf("String() = %q, expected %q", result, tc.expected)
+		}
+	}
+}
+
+// TestFormatCount tests abbreviated counts
+func TestFormatCount(t *testing.T) {
+	testCases := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0"},
+		{842, "842"},
+		{999, "999"},
+		{1000, "1.0k"},
+		{1234, "1.2k"},
+		{9949, "9.9k"},
+		{9950, "10k"},
+		{12345, "12k"},
+		{999_499, "999k"},
+		{999_500, "1.0M"},
+		{1_234_567, "1.2M"},
+		{-1500, "-1.5k"},
+		{math.MaxInt64, "9.2E"},
+	}
+
+	for _, tc := range testCases {
+		if result := formatCount(tc.input); result != tc.expected {
+			t.Errorf("formatCount(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package filesize
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// SystemMemory returns the total physical memory of the machine, the
+// figure tmpfs percentages are taken of
+func SystemMemory() (Size, error) {
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err != nil {
+		return 0, os.NewSyscallError("sysinfo", err)
+	}
+	return Size(clampUint64(uint64(info.Totalram) * uint64(info.Unit))), nil
+}
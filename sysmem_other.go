@@ -0,0 +1,14 @@
+//go:build !linux
+
+package filesize
+
+import (
+	"errors"
+	"runtime"
+)
+
+// SystemMemory reports that physical memory cannot be queried on this
+// platform
+func SystemMemory() (Size, error) {
+	return 0, errors.New("system memory not supported on " + runtime.GOOS)
+}
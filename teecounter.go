@@ -0,0 +1,66 @@
+package filesize
+
+import (
+	"io"
+	"time"
+)
+
+// TeeCounter returns a reader that passes r through and reports progress to
+// fn at most once per every
+//
+// fn receives the total copied so far and the rate since the previous
+// report, and is called once more when r reaches EOF or fails, so logs of
+// long transfers always end with the final count. Reports happen on the
+// reading goroutine, between reads; a stalled reader therefore reports
+// nothing until it returns.
+func TeeCounter(r io.Reader, every time.Duration, fn func(copied Size, rate Rate)) io.Reader {
+	return newTeeCounter(r, every, fn, time.Now)
+}
+
+// newTeeCounter returns a TeeCounter reading time from now
+func newTeeCounter(r io.Reader, every time.Duration, fn func(Size, Rate), now func() time.Time) *teeCounter {
+	return &teeCounter{r: r, every: every, fn: fn, now: now, last: now()}
+}
+
+// teeCounter implements TeeCounter
+type teeCounter struct {
+	r     io.Reader
+	every time.Duration
+	fn    func(Size, Rate)
+
+	copied     int64
+	lastCopied int64
+	last       time.Time
+	done       bool
+
+	// now is the clock, replaceable in tests
+	now func() time.Time
+}
+
+// Read reads from the underlying reader and reports when due
+func (tc *teeCounter) Read(p []byte) (int, error) {
+	n, err := tc.r.Read(p)
+	tc.copied += int64(n)
+
+	now := tc.now()
+	switch {
+	case err != nil && !tc.done:
+		tc.done = true
+		tc.report(now)
+	case err == nil && now.Sub(tc.last) >= tc.every:
+		tc.report(now)
+	}
+
+	return n, err
+}
+
+// report calls fn with the rate over the interval since the last report
+func (tc *teeCounter) report(now time.Time) {
+	var rate Rate
+	if elapsed := now.Sub(tc.last); elapsed > 0 {
+		rate = Rate{Bytes: Size(tc.copied - tc.lastCopied), Per: elapsed}
+	}
+
+	tc.fn(Size(tc.copied), rate)
+	tc.lastCopied, tc.last = tc.copied, now
+}
@@ -0,0 +1,77 @@
+package filesize
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// clockedReader advances a fake clock by a fixed step on every read of at
+// most one KiB
+type clockedReader struct {
+	r     io.Reader
+	clock *fakeClock
+	step  time.Duration
+}
+
+// Read advances the clock and reads from the underlying reader
+func (cr *clockedReader) Read(p []byte) (int, error) {
+	cr.clock.Advance(cr.step)
+	return cr.r.Read(p[:min(len(p), 1024)])
+}
+
+// TestTeeCounter tests periodic and final reports
+func TestTeeCounter(t *testing.T) {
+	clock := newFakeClock()
+	type report struct {
+		copied Size
+		rate   float64
+	}
+	var reports []report
+
+	// 1 KiB every 250ms is 4 KiB/s
+	src := &clockedReader{r: strings.NewReader(strings.Repeat("x", 10*1024)), clock: clock, step: 250 * time.Millisecond}
+	r := newTeeCounter(src, time.Second, func(copied Size, rate Rate) {
+		reports = append(reports, report{copied, rate.BytesPerSecond()})
+	}, clock.Now)
+
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		t.Fatalf("io.Copy() unexpected error: %v", err)
+	}
+
+	expected := []report{
+		{Size(4 * KiB), 4096},
+		{Size(8 * KiB), 4096},
+		{Size(10 * KiB), 2048 / 0.75},
+	}
+	if len(reports) != len(expected) {
+		t.Fatalf("reported %d times (%v), expected %d", len(reports), reports, len(expected))
+	}
+	for i, got := range reports {
+		if got.copied != expected[i].copied || got.rate != expected[i].rate {
+			t.Errorf("report %d = %d bytes at %g B/s, expected %d bytes at %g B/s",
+				i, int64(got.copied), got.rate, int64(expected[i].copied), expected[i].rate)
+		}
+	}
+}
+
+// TestTeeCounter_Error tests that a failing reader still gets a final report
+func TestTeeCounter_Error(t *testing.T) {
+	calls := 0
+	var last Size
+	failing := io.MultiReader(strings.NewReader("12345"), iotest.ErrReader(errors.New("reset")))
+	r := TeeCounter(failing, time.Hour, func(copied Size, rate Rate) {
+		calls++
+		last = copied
+	})
+
+	if _, err := io.ReadAll(r); err == nil {
+		t.Fatalf("ReadAll() expected error but got none")
+	}
+	if calls != 1 || last != 5 {
+		t.Errorf("reported %d times with %d bytes, expected one final report of 5 bytes", calls, int64(last))
+	}
+}
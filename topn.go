@@ -0,0 +1,79 @@
+package filesize
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// TopN keeps the N largest entries of a stream in constant memory, for
+// "largest files" or "largest objects" reports over millions of entries
+//
+// Each Add costs O(log N). Entries of equal size are ranked by path, so
+// the result does not depend on the order entries arrive in. A TopN is not
+// safe for concurrent use; give each goroutine its own and Merge them.
+type TopN struct {
+	n       int
+	entries topHeap
+}
+
+// NewTopN returns a TopN keeping up to n entries; n of zero or less keeps
+// none
+func NewTopN(n int) *TopN {
+	return &TopN{n: max(n, 0), entries: make(topHeap, 0, max(n, 0))}
+}
+
+// Add offers an entry, keeping it if it ranks among the largest so far
+func (t *TopN) Add(path string, size int64) {
+	t.add(FileEntry{Path: path, Size: Size(size)})
+}
+
+// add offers an entry to the heap
+func (t *TopN) add(e FileEntry) {
+	if t.n == 0 {
+		return
+	}
+	if len(t.entries) < t.n {
+		heap.Push(&t.entries, e)
+		return
+	}
+
+	// replace the lowest ranked entry when e outranks it
+	if compareEntries(e, t.entries[0]) < 0 {
+		t.entries[0] = e
+		heap.Fix(&t.entries, 0)
+	}
+}
+
+// Merge offers every entry kept by other
+func (t *TopN) Merge(other *TopN) {
+	for _, e := range other.entries {
+		t.add(e)
+	}
+}
+
+// Len returns the number of entries kept, at most N
+func (t *TopN) Len() int {
+	return len(t.entries)
+}
+
+// Entries returns the kept entries, largest first
+func (t *TopN) Entries() []FileEntry {
+	entries := slices.Clone([]FileEntry(t.entries))
+	slices.SortFunc(entries, compareEntries)
+	return entries
+}
+
+// topHeap is a heap of entries with the lowest ranked on top
+type topHeap []FileEntry
+
+func (h topHeap) Len() int           { return len(h) }
+func (h topHeap) Less(i, j int) bool { return compareEntries(h[i], h[j]) > 0 }
+func (h topHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *topHeap) Push(x any)        { *h = append(*h, x.(FileEntry)) }
+
+func (h *topHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
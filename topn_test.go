@@ -0,0 +1,82 @@
+package filesize
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestTopN tests keeping the largest entries of a stream
+func TestTopN(t *testing.T) {
+	top := NewTopN(3)
+	top.Add("a", 10)
+	top.Add("b", 500)
+	top.Add("c", 20)
+	top.Add("d", 300)
+	top.Add("e", 5)
+	top.Add("f", 400)
+
+	expected := []FileEntry{{"b", 500}, {"f", 400}, {"d", 300}}
+	if result := top.Entries(); !slices.Equal(result, expected) {
+		t.Errorf("Entries() = %v, expected %v", result, expected)
+	}
+	if top.Len() != 3 {
+		t.Errorf("Len() = %d, expected 3", top.Len())
+	}
+}
+
+// TestTopN_Ties tests that equal sizes rank by path regardless of order
+func TestTopN_Ties(t *testing.T) {
+	forward, backward := NewTopN(2), NewTopN(2)
+	names := []string{"a", "b", "c", "d"}
+	for i := range names {
+		forward.Add(names[i], 100)
+		backward.Add(names[len(names)-1-i], 100)
+	}
+
+	expected := []FileEntry{{"a", 100}, {"b", 100}}
+	if result := forward.Entries(); !slices.Equal(result, expected) {
+		t.Errorf("Entries() in order = %v, expected %v", result, expected)
+	}
+	if result := backward.Entries(); !slices.Equal(result, expected) {
+		t.Errorf("Entries() in reverse = %v, expected %v", result, expected)
+	}
+}
+
+// TestTopN_Merge tests combining per-worker accumulators
+func TestTopN_Merge(t *testing.T) {
+	a, b := NewTopN(2), NewTopN(2)
+	a.Add("a1", 1)
+	a.Add("a2", 30)
+	b.Add("b1", 20)
+	b.Add("b2", 40)
+
+	a.Merge(b)
+	expected := []FileEntry{{"b2", 40}, {"a2", 30}}
+	if result := a.Entries(); !slices.Equal(result, expected) {
+		t.Errorf("Merge() entries = %v, expected %v", result, expected)
+	}
+
+	empty := NewTopN(0)
+	empty.Add("x", 1)
+	if empty.Len() != 0 || len(empty.Entries()) != 0 {
+		t.Errorf("NewTopN(0) kept %v", empty.Entries())
+	}
+}
+
+// BenchmarkTopN benchmarks streaming a million entries into a top 100
+func BenchmarkTopN(b *testing.B) {
+	paths := make([]string, 1000)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file%d", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		top := NewTopN(100)
+		for j := 0; j < 1_000_000; j++ {
+			top.Add(paths[j%len(paths)], int64(j*7919%1_000_003))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package filesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// units that ulimit values are counted in, depending on the resource and
+// the shell
+const (
+	// UlimitBytes is the unit of setrlimit itself and of unscaled limits
+	UlimitBytes int64 = 1
+
+	// UlimitBlocks is the 512-byte block POSIX ulimit uses for -f and -c,
+	// as do dash and bash in POSIX mode
+	UlimitBlocks int64 = 512
+
+	// UlimitKilobytes is the 1024-byte unit shells use for -d, -l, -m, -s
+	// and -v, and bash also uses for -f and -c by default
+	UlimitKilobytes int64 = 1024
+)
+
+// ParseRlimit parses a human-readable limit into a setrlimit value in
+// bytes, for resources such as RLIMIT_FSIZE, RLIMIT_AS and RLIMIT_CORE
+//
+// "unlimited" and systemd's "infinity" yield RlimitInfinity; anything else
+// is parsed with ParseSize, so "2GiB" or "512m" work as in configuration.
+func ParseRlimit(s string) (uint64, error) {
+	if isUnlimited(s) {
+		return RlimitInfinity, nil
+	}
+
+	bytes, err := ParseSize(s)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(bytes), nil
+}
+
+// FormatRlimit formats a setrlimit byte value in the canonical text form,
+// e.g. "2GiB", or "unlimited" for RlimitInfinity
+func FormatRlimit(value uint64) string {
+	if value == RlimitInfinity || value > 1<<63-1 {
+		return "unlimited"
+	}
+	return formatExact(int64(value), "")
+}
+
+// ParseUlimit converts a value as written for the ulimit shell builtin,
+// a count of unit or "unlimited", into a setrlimit value in bytes
+//
+// For example ParseUlimit("2048", UlimitBlocks) is 1 MiB, the limit
+// "ulimit -f 2048" sets in a POSIX shell.
+func ParseUlimit(s string, unit int64) (uint64, error) {
+	if unit <= 0 {
+		return 0, fmt.Errorf("invalid ulimit unit: %d", unit)
+	}
+	if isUnlimited(s) {
+		return RlimitInfinity, nil
+	}
+
+	count, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ulimit value: %q", s)
+	}
+	if count > (RlimitInfinity-1)/uint64(unit) {
+		return 0, fmt.Errorf("ulimit value %q: %w", s, ErrOverflow)
+	}
+	return count * uint64(unit), nil
+}
+
+// FormatUlimit converts a setrlimit value in bytes into the argument the
+// ulimit builtin takes for a resource counted in unit, rounding down as
+// shells do when they report limits
+//
+// RlimitInfinity formats as "unlimited", so the result of ParseRlimit on a
+// human string can be passed straight to "ulimit -v".
+func FormatUlimit(value uint64, unit int64) string {
+	if value == RlimitInfinity {
+		return "unlimited"
+	}
+	return strconv.FormatUint(value/uint64(max(unit, 1)), 10)
+}
+
+// isUnlimited reports whether s spells out the absence of a limit
+func isUnlimited(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.EqualFold(s, "unlimited") || strings.EqualFold(s, "infinity")
+}
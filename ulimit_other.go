@@ -0,0 +1,7 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !illumos
+
+package filesize
+
+// RlimitInfinity is the value meaning no limit; this platform has no
+// setrlimit, so it is the largest value, as on Linux
+const RlimitInfinity uint64 = 1<<64 - 1
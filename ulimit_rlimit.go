@@ -0,0 +1,9 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || illumos
+
+package filesize
+
+import "golang.org/x/sys/unix"
+
+// RlimitInfinity is the setrlimit value meaning no limit, RLIM_INFINITY on
+// this platform
+const RlimitInfinity uint64 = unix.RLIM_INFINITY
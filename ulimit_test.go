@@ -0,0 +1,125 @@
+package filesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseRlimit tests converting human strings to setrlimit values
+func TestParseRlimit(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected uint64
+		hasError bool
+	}{
+		{"unlimited", RlimitInfinity, false},
+		{"infinity", RlimitInfinity, false},
+		{" Unlimited ", RlimitInfinity, false},
+		{"0", 0, false},
+		{"2GiB", 2 << 30, false},
+		{"512m", 512 << 20, false},
+		{"1.5KB", 1500, false},
+		{"", 0, true},
+		{"-1", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseRlimit(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseRlimit(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRlimit(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("ParseRlimit(%q) = %d, expected %d", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestFormatRlimit tests formatting setrlimit values
+func TestFormatRlimit(t *testing.T) {
+	testCases := []struct {
+		input    uint64
+		expected string
+	}{
+		{RlimitInfinity, "unlimited"},
+		{0, "0B"},
+		{2 << 30, "2GiB"},
+		{1500, "1500B"},
+	}
+
+	for _, tc := range testCases {
+		if result := FormatRlimit(tc.input); result != tc.expected {
+			t.Errorf("FormatRlimit(%d) = %q, expected %q", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestParseUlimit tests scaling ulimit builtin values by their unit
+func TestParseUlimit(t *testing.T) {
+	testCases := []struct {
+		input    string
+		unit     int64
+		expected uint64
+		hasError bool
+	}{
+		{"2048", UlimitBlocks, 1 << 20, false},
+		{"2048", UlimitKilobytes, 2 << 20, false},
+		{"100", UlimitBytes, 100, false},
+		{"unlimited", UlimitKilobytes, RlimitInfinity, false},
+		{"0", UlimitBlocks, 0, false},
+		{"1k", UlimitBlocks, 0, true},
+		{"-1", UlimitBlocks, 0, true},
+		{"", UlimitBlocks, 0, true},
+		{"100", 0, 0, true},
+		{"18446744073709551615", UlimitKilobytes, 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := ParseUlimit(tc.input, tc.unit)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseUlimit(%q, %d) expected error but got none", tc.input, tc.unit)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseUlimit(%q, %d) unexpected error: %v", tc.input, tc.unit, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("ParseUlimit(%q, %d) = %d, expected %d", tc.input, tc.unit, result, tc.expected)
+		}
+	}
+
+	if _, err := ParseUlimit("18446744073709551615", UlimitKilobytes); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseUlimit() overflow error = %v, expected ErrOverflow", err)
+	}
+}
+
+// TestFormatUlimit tests converting setrlimit values for the shell
+func TestFormatUlimit(t *testing.T) {
+	testCases := []struct {
+		input    uint64
+		unit     int64
+		expected string
+	}{
+		{1 << 20, UlimitBlocks, "2048"},
+		{1 << 20, UlimitKilobytes, "1024"},
+		{1000, UlimitBlocks, "1"},
+		{RlimitInfinity, UlimitKilobytes, "unlimited"},
+		{100, UlimitBytes, "100"},
+	}
+
+	for _, tc := range testCases {
+		if result := FormatUlimit(tc.input, tc.unit); result != tc.expected {
+			t.Errorf("FormatUlimit(%d, %d) = %q, expected %q", tc.input, tc.unit, result, tc.expected)
+		}
+	}
+}
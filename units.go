@@ -0,0 +1,102 @@
+package filesize
+
+import (
+	"fmt"
+)
+
+// Convention identifies which family of units a size string was written in
+type Convention int
+
+const (
+	// ConventionNone means the string had no scaling unit, i.e. a plain number
+	// or an explicit byte unit such as "b" or "bytes"
+	ConventionNone Convention = iota
+
+	// ConventionIEC means an unambiguous 1024-based unit such as "KiB"
+	ConventionIEC
+
+	// ConventionSI means a 1000-based unit such as "KB" or "MB"
+	ConventionSI
+
+	// ConventionShort means a single-letter unit such as "k" or "M", which
+	// this package reads as 1024-based but other tools may not
+	ConventionShort
+
+	// ConventionCustom means a unit registered on a Parser
+	ConventionCustom
+
+	// ConventionJEDEC means a "KB" style unit read as 1024-based, as in the
+	// JEDEC unit system
+	ConventionJEDEC
+)
+
+// String returns a lowercase name for the convention
+func (c Convention) String() string {
+	switch c {
+	case ConventionNone:
+		return "none"
+	case ConventionIEC:
+		return "iec"
+	case ConventionSI:
+		return "si"
+	case ConventionShort:
+		return "short"
+	case ConventionCustom:
+		return "custom"
+	case ConventionJEDEC:
+		return "jedec"
+	default:
+		return fmt.Sprintf("Convention(%d)", int(c))
+	}
+}
+
+// DetectUnitSystem reports which unit convention a size string uses
+//
+// Migration tools can use this to warn about configs that mix 1000- and
+// 1024-based units, or that rely on short units whose meaning differs between
+// tools. The string must be valid for ParseSize.
+func DetectUnitSystem(sizeStr string) (Convention, error) {
+	// validate the whole string first so errors match ParseSize
+	if _, err := ParseSize(sizeStr); err != nil {
+		return ConventionNone, err
+	}
+
+	_, unitStr, err := splitSize(sizeStr)
+	if err != nil {
+		return ConventionNone, err
+	}
+
+	// plain numbers have no unit, everything else was validated above
+	unit, _ := lookupUnit(unitMap, unitStr)
+	return unit.Convention, nil
+}
+
+// UnitInfo describes a unit accepted by ParseSize
+type UnitInfo struct {
+	// Name is the canonical spelling of the unit, e.g. "MiB"
+	Name string
+
+	// Aliases are alternative spellings accepted in addition to Name
+	Aliases []string
+
+	// Multiplier is the number of bytes in one unit
+	Multiplier int64
+
+	// Convention is the unit family the unit belongs to
+	Convention Convention
+}
+
+// Units returns every unit ParseSize accepts
+//
+// The list is ordered by convention and then by multiplier, making it
+// suitable for generating help text and shell completions. Unit names and
+// aliases are matched case-insensitively when parsing. The returned slice is
+// a copy and may be modified freely.
+func Units() []UnitInfo {
+	return DefaultUnits.Units()
+}
+
+// LookupUnit finds a unit by name or alias, ignoring case
+func LookupUnit(name string) (UnitInfo, bool) {
+	return DefaultUnits.Lookup(name)
+}
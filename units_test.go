@@ -0,0 +1,103 @@
+package filesize
+
+import (
+	"testing"
+)
+
+// TestDetectUnitSystem tests the DetectUnitSystem function
+func TestDetectUnitSystem(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Convention
+		hasError bool
+	}{
+		{"1024", ConventionNone, false},
+		{"100b", ConventionNone, false},
+		{"100 bytes", ConventionNone, false},
+		{"4KiB", ConventionIEC, false},
+		{"4kib", ConventionIEC, false},
+		{"2.5GiB", ConventionIEC, false},
+		{"4KB", ConventionSI, false},
+		{"10mb", ConventionSI, false},
+		{"4k", ConventionShort, false},
+		{"10M", ConventionShort, false},
+
+		// invalid input
+		{"", ConventionNone, true},
+		{"1xy", ConventionNone, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := DetectUnitSystem(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("DetectUnitSystem(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("DetectUnitSystem(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("DetectUnitSystem(%q) = %v, expected %v", tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestUnits tests that the unit table is exposed consistently
+func TestUnits(t *testing.T) {
+	units := Units()
+	if len(units) == 0 {
+		t.Fatalf("Units() returned no units")
+	}
+
+	// every listed unit and alias must be accepted by the parser
+	for _, unit := range units {
+		for _, name := range append([]string{unit.Name}, unit.Aliases...) {
+			result, err := ParseSize("2" + name)
+			if err != nil {
+				t.Errorf("ParseSize(%q) unexpected error: %v", "2"+name, err)
+				continue
+			}
+			if result != 2*unit.Multiplier {
+				t.Errorf("ParseSize(%q) = %d, expected %d", "2"+name, result, 2*unit.Multiplier)
+			}
+		}
+	}
+
+	// modifying the returned slice must not affect the package
+	units[0].Aliases[0] = "changed"
+	if again := Units(); again[0].Aliases[0] == "changed" {
+		t.Errorf("Units() returned shared alias storage")
+	}
+}
+
+// TestLookupUnit tests the LookupUnit function
+func TestLookupUnit(t *testing.T) {
+	testCases := []struct {
+		input      string
+		name       string
+		multiplier int64
+		convention Convention
+		found      bool
+	}{
+		{"MiB", "MiB", MiB, ConventionIEC, true},
+		{"mib", "MiB", MiB, ConventionIEC, true},
+		{"KB", "KB", KB, ConventionSI, true},
+		{"K", "k", KiB, ConventionShort, true},
+		{"bytes", "B", Byte, ConventionNone, true},
+		{"ZiB", "", 0, ConventionNone, false},
+	}
+
+	for _, tc := range testCases {
+		unit, found := LookupUnit(tc.input)
+		if found != tc.found {
+			t.Errorf("LookupUnit(%q) found = %t, expected %t", tc.input, found, tc.found)
+			continue
+		}
+		if unit.Name != tc.name || unit.Multiplier != tc.multiplier || unit.Convention != tc.convention {
+			t.Errorf("LookupUnit(%q) = %+v, expected %s/%d/%v", tc.input, unit, tc.name, tc.multiplier, tc.convention)
+		}
+	}
+}
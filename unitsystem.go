@@ -0,0 +1,180 @@
+package filesize
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UnitSystem is a named set of units used for parsing and formatting
+//
+// Every unit in a system is accepted when parsing, while formatting chooses
+// from a subset of display units. The predefined systems cover the common
+// conventions and NewUnitSystem builds custom ones for domain specific units
+// or compatibility modes. UnitSystem values are immutable and safe to share.
+type UnitSystem struct {
+	// name identifies the system in errors and debugging output
+	name string
+
+	// units lists the accepted units in definition order
+	units []UnitInfo
+
+	// lookup maps lowercase unit names and aliases to their definitions
+	lookup map[string]UnitInfo
+
+	// ladder holds the display units in descending order of multiplier
+	ladder []scaleUnit
+}
+
+// predefined unit systems
+var (
+	// DefaultUnits is the mixed system used by ParseSize and FormatSize:
+	// IEC and short units are 1024-based, "KB" style units are 1000-based,
+	// and output uses IEC names
+	DefaultUnits = mustUnitSystem("default", unitTable, "KiB", "MiB", "GiB", "TiB", "PiB", "EiB")
+
+	// IEC accepts only unambiguous 1024-based units such as "KiB"
+	IEC = mustUnitSystem("iec", []UnitInfo{
+		byteUnit,
+		{Name: "KiB", Multiplier: KiB, Convention: ConventionIEC},
+		{Name: "MiB", Multiplier: MiB, Convention: ConventionIEC},
+		{Name: "GiB", Multiplier: GiB, Convention: ConventionIEC},
+		{Name: "TiB", Multiplier: TiB, Convention: ConventionIEC},
+		{Name: "PiB", Multiplier: PiB, Convention: ConventionIEC},
+		{Name: "EiB", Multiplier: EiB, Convention: ConventionIEC},
+	})
+
+	// SI accepts only 1000-based units and formats them as "kB", "MB" etc.
+	SI = mustUnitSystem("si", []UnitInfo{
+		byteUnit,
+		{Name: "kB", Multiplier: KB, Convention: ConventionSI},
+		{Name: "MB", Multiplier: MB, Convention: ConventionSI},
+		{Name: "GB", Multiplier: GB, Convention: ConventionSI},
+		{Name: "TB", Multiplier: TB, Convention: ConventionSI},
+		{Name: "PB", Multiplier: PB, Convention: ConventionSI},
+		{Name: "EB", Multiplier: EB, Convention: ConventionSI},
+	})
+
+	// JEDEC reads and writes "KB", "MB" etc. as 1024-based units, the
+	// convention used by Windows and memory datasheets
+	JEDEC = mustUnitSystem("jedec", []UnitInfo{
+		byteUnit,
+		{Name: "KB", Aliases: []string{"k"}, Multiplier: KiB, Convention: ConventionJEDEC},
+		{Name: "MB", Aliases: []string{"m"}, Multiplier: MiB, Convention: ConventionJEDEC},
+		{Name: "GB", Aliases: []string{"g"}, Multiplier: GiB, Convention: ConventionJEDEC},
+		{Name: "TB", Aliases: []string{"t"}, Multiplier: TiB, Convention: ConventionJEDEC},
+		{Name: "PB", Aliases: []string{"p"}, Multiplier: PiB, Convention: ConventionJEDEC},
+		{Name: "EB", Aliases: []string{"e"}, Multiplier: EiB, Convention: ConventionJEDEC},
+	})
+)
+
+// byteUnit is the plain byte unit shared by the predefined systems
+var byteUnit = UnitInfo{Name: "B", Aliases: []string{"byte", "bytes"}, Multiplier: Byte, Convention: ConventionNone}
+
+// NewUnitSystem builds a unit system from a list of units
+//
+// All units are accepted when parsing. Formatting uses the units named in
+// display, or when none are given every unit larger than one byte, keeping
+// the first unit listed for each multiplier. Unit names and aliases must be
+// ASCII letters, unique ignoring case, and multipliers must be positive.
+func NewUnitSystem(name string, units []UnitInfo, display ...string) (UnitSystem, error) {
+	sys := UnitSystem{
+		name:   name,
+		units:  make([]UnitInfo, len(units)),
+		lookup: make(map[string]UnitInfo, len(units)*2),
+	}
+
+	// copy and index every unit, rejecting conflicting spellings
+	for i, unit := range units {
+		if unit.Multiplier <= 0 {
+			return UnitSystem{}, fmt.Errorf("invalid multiplier for unit %s: %d", unit.Name, unit.Multiplier)
+		}
+		unit.Aliases = append([]string(nil), unit.Aliases...)
+		sys.units[i] = unit
+
+		for _, unitName := range append([]string{unit.Name}, unit.Aliases...) {
+			if !isUnitName(unitName) {
+				return UnitSystem{}, fmt.Errorf("invalid unit name: %q", unitName)
+			}
+			key := strings.ToLower(unitName)
+			if _, exists := sys.lookup[key]; exists {
+				return UnitSystem{}, fmt.Errorf("unit already defined: %s", unitName)
+			}
+			sys.lookup[key] = unit
+		}
+	}
+
+	// pick the display units, defaulting to one unit per multiplier
+	if len(display) == 0 {
+		for _, unit := range sys.units {
+			if unit.Multiplier > 1 && !slices.ContainsFunc(sys.ladder, func(u scaleUnit) bool {
+				return u.multiplier == float64(unit.Multiplier)
+			}) {
+				sys.ladder = append(sys.ladder, scaleUnit{unit.Name, float64(unit.Multiplier)})
+			}
+		}
+	}
+	for _, unitName := range display {
+		unit, ok := sys.lookup[strings.ToLower(unitName)]
+		if !ok {
+			return UnitSystem{}, fmt.Errorf("unknown display unit: %s", unitName)
+		}
+		sys.ladder = append(sys.ladder, scaleUnit{unit.Name, float64(unit.Multiplier)})
+	}
+	slices.SortStableFunc(sys.ladder, func(a, b scaleUnit) int {
+		return cmp.Compare(b.multiplier, a.multiplier)
+	})
+
+	return sys, nil
+}
+
+// mustUnitSystem is NewUnitSystem for the predefined systems
+func mustUnitSystem(name string, units []UnitInfo, display ...string) UnitSystem {
+	sys, err := NewUnitSystem(name, units, display...)
+	if err != nil {
+		panic(err)
+	}
+	return sys
+}
+
+// Name returns the name the system was created with
+func (sys UnitSystem) Name() string {
+	return sys.name
+}
+
+// Units returns a copy of the units the system accepts, in definition order
+func (sys UnitSystem) Units() []UnitInfo {
+	units := make([]UnitInfo, len(sys.units))
+	for i, unit := range sys.units {
+		units[i] = unit
+		units[i].Aliases = append([]string(nil), unit.Aliases...)
+	}
+	return units
+}
+
+// Lookup finds a unit of the system by name or alias, ignoring case
+func (sys UnitSystem) Lookup(name string) (UnitInfo, bool) {
+	unit, ok := sys.lookup[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return UnitInfo{}, false
+	}
+
+	unit.Aliases = append([]string(nil), unit.Aliases...)
+	return unit, true
+}
+
+// Parse converts a size string to bytes using only this system's units
+func (sys UnitSystem) Parse(sizeStr string) (int64, error) {
+	return parseWithUnits(sizeStr, sys.lookup)
+}
+
+// WithUnitSystem formats using the display units of the given system
+//
+// For example WithUnitSystem(SI) renders 1500000 as "1.50 MB" and
+// WithUnitSystem(JEDEC) renders 1536 as "1.50 KB".
+func WithUnitSystem(sys UnitSystem) FormatOption {
+	return func(cfg *formatConfig) {
+		cfg.units = sys.ladder
+	}
+}
@@ -0,0 +1,119 @@
+package filesize
+
+import (
+	"testing"
+)
+
+// TestUnitSystem_Parse tests parsing with the predefined unit systems
+func TestUnitSystem_Parse(t *testing.T) {
+	testCases := []struct {
+		sys      UnitSystem
+		input    string
+		expected int64
+		hasError bool
+	}{
+		{DefaultUnits, "1KB", 1000, false},
+		{DefaultUnits, "1k", 1024, false},
+		{IEC, "1KiB", 1024, false},
+		{IEC, "2 bytes", 2, false},
+		{IEC, "1KB", 0, true},
+		{IEC, "1k", 0, true},
+		{SI, "1kB", 1000, false},
+		{SI, "1.5MB", 1500000, false},
+		{SI, "1KiB", 0, true},
+		{JEDEC, "1KB", 1024, false},
+		{JEDEC, "4k", 4096, false},
+		{JEDEC, "1GB", 1024 * 1024 * 1024, false},
+		{JEDEC, "1GiB", 0, true},
+	}
+
+	for _, tc := range testCases {
+		result, err := tc.sys.Parse(tc.input)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("%s.Parse(%q) expected error but got none", tc.sys.Name(), tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s.Parse(%q) unexpected error: %v", tc.sys.Name(), tc.input, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("%s.Parse(%q) = %d, expected %d", tc.sys.Name(), tc.input, result, tc.expected)
+		}
+	}
+}
+
+// TestUnitSystem_Format tests formatting with the predefined unit systems
+func TestUnitSystem_Format(t *testing.T) {
+	testCases := []struct {
+		sys      UnitSystem
+		input    int64
+		expected string
+	}{
+		{DefaultUnits, 1536, "1.50 KiB"},
+		{DefaultUnits, 1000, "1000 B"},
+		{IEC, 1024 * 1024, "1.00 MiB"},
+		{SI, 999, "999 B"},
+		{SI, 1500, "1.50 kB"},
+		{SI, 1500000, "1.50 MB"},
+		{JEDEC, 1536, "1.50 KB"},
+	}
+
+	for _, tc := range testCases {
+		result := FormatSize(tc.input, WithUnitSystem(tc.sys))
+		if result != tc.expected {
+			t.Errorf("FormatSize(%d, WithUnitSystem(%s)) = %q, expected %q", tc.input, tc.sys.Name(), result, tc.expected)
+		}
+	}
+}
+
+// TestNewUnitSystem tests building custom unit systems
+func TestNewUnitSystem(t *testing.T) {
+	storage, err := NewUnitSystem("storage", []UnitInfo{
+		{Name: "B", Multiplier: 1},
+		{Name: "blk", Aliases: []string{"block"}, Multiplier: 512},
+		{Name: "seg", Multiplier: 8 * MiB},
+	})
+	if err != nil {
+		t.Fatalf("NewUnitSystem() unexpected error: %v", err)
+	}
+
+	if result, err := storage.Parse("3 blocks"); err == nil {
+		t.Errorf("storage.Parse(%q) = %d, expected unknown unit error", "3 blocks", result)
+	}
+	if result, err := storage.Parse("3 block"); err != nil || result != 1536 {
+		t.Errorf("storage.Parse(%q) = %d, %v, expected 1536", "3 block", result, err)
+	}
+	if result := FormatSize(16*MiB, WithUnitSystem(storage)); result != "2.00 seg" {
+		t.Errorf("FormatSize(16 MiB, WithUnitSystem(storage)) = %q, expected %q", result, "2.00 seg")
+	}
+	if result := FormatSize(1024, WithUnitSystem(storage)); result != "2.00 blk" {
+		t.Errorf("FormatSize(1024, WithUnitSystem(storage)) = %q, expected %q", result, "2.00 blk")
+	}
+
+	// parsers can combine systems, with earlier systems taking precedence
+	p := NewParser(storage, JEDEC)
+	if result, err := p.Parse("1KB"); err != nil || result != 1024 {
+		t.Errorf("NewParser(storage, JEDEC).Parse(%q) = %d, %v, expected 1024", "1KB", result, err)
+	}
+	if result, err := p.Parse("2seg"); err != nil || result != 16*MiB {
+		t.Errorf("NewParser(storage, JEDEC).Parse(%q) = %d, %v, expected %d", "2seg", result, err, 16*MiB)
+	}
+
+	// invalid definitions are rejected
+	invalid := [][]UnitInfo{
+		{{Name: "blk", Multiplier: 0}},
+		{{Name: "blk1", Multiplier: 512}},
+		{{Name: "blk", Multiplier: 512}, {Name: "BLK", Multiplier: 1024}},
+	}
+	for _, units := range invalid {
+		if _, err := NewUnitSystem("invalid", units); err == nil {
+			t.Errorf("NewUnitSystem(%+v) expected error but got none", units)
+		}
+	}
+	if _, err := NewUnitSystem("invalid", []UnitInfo{{Name: "blk", Multiplier: 512}}, "seg"); err == nil {
+		t.Errorf("NewUnitSystem() with unknown display unit expected error but got none")
+	}
+}
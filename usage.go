@@ -0,0 +1,44 @@
+package filesize
+
+import "os"
+
+// Usage is the space a file takes, as its contents and on disk
+type Usage struct {
+	// Apparent is the file's length, the size ls and FileSize report
+	Apparent Size
+
+	// Allocated is the space the filesystem reserves for the file, the
+	// size du reports
+	Allocated Size
+}
+
+// FileUsage returns the apparent and allocated sizes of the file at path,
+// following symbolic links
+//
+// The allocated size is st_blocks × 512 on Unix systems and the compressed
+// size rounded up to whole clusters on Windows; elsewhere it equals the
+// apparent size. Sparse, compressed and deduplicated files allocate less
+// than their length while small files usually allocate more, which lets
+// sparse-aware tools print "2.00 GiB apparent, 120 MiB on disk".
+func FileUsage(path string) (Usage, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	allocated, err := allocatedSize(path, info)
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{Apparent: Size(info.Size()), Allocated: Size(allocated)}, nil
+}
+
+// Sparse reports whether less space is allocated than the file's length
+func (u Usage) Sparse() bool {
+	return u.Allocated < u.Apparent
+}
+
+// String formats both sizes, e.g. "2.00 GiB apparent, 120 MiB on disk"
+func (u Usage) String() string {
+	return u.Apparent.String() + " apparent, " + u.Allocated.String() + " on disk"
+}
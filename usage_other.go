@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package filesize
+
+import "io/fs"
+
+// allocatedSize falls back to the apparent size where allocation cannot be
+// queried
+func allocatedSize(path string, info fs.FileInfo) (int64, error) {
+	return info.Size(), nil
+}
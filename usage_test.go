@@ -0,0 +1,50 @@
+package filesize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileUsage tests apparent and allocated sizes of a sparse file
+func TestFileUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sparse.img")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	// one written block far into the file leaves a hole on most filesystems
+	if _, err := f.WriteAt([]byte("data"), 64*MiB); err != nil {
+		t.Fatalf("WriteAt() unexpected error: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	usage, err := FileUsage(path)
+	if err != nil {
+		t.Fatalf("FileUsage() unexpected error: %v", err)
+	}
+	if usage.Apparent != Size(64*MiB+4) {
+		t.Errorf("FileUsage().Apparent = %d, expected %d", int64(usage.Apparent), 64*MiB+4)
+	}
+	if usage.Allocated < 0 {
+		t.Errorf("FileUsage().Allocated = %d, expected a non-negative size", int64(usage.Allocated))
+	}
+
+	if _, err := FileUsage(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("FileUsage(missing) = %v, expected a not-exist error", err)
+	}
+}
+
+// TestUsage_String tests the humanized summary
+func TestUsage_String(t *testing.T) {
+	u := Usage{Apparent: Size(2 * GiB), Allocated: Size(120 * MiB)}
+	if result := u.String(); result != "2.00 GiB apparent, 120 MiB on disk" {
+		t.Errorf("String() = %q, expected %q", result, "2.00 GiB apparent, 120 MiB on disk")
+	}
+	if !u.Sparse() {
+		t.Errorf("Sparse() = false, expected true for %v", u)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package filesize
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// allocatedSize returns the bytes allocated to a file from its block count,
+// which POSIX defines in 512-byte units regardless of the filesystem
+func allocatedSize(path string, info fs.FileInfo) (int64, error) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.Size(), nil
+	}
+	return int64(st.Blocks) * 512, nil
+}
@@ -0,0 +1,72 @@
+package filesize
+
+import (
+	"io/fs"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// kernel32 procedures that golang.org/x/sys/windows does not wrap
+var (
+	kernel32                   = windows.NewLazySystemDLL("kernel32.dll")
+	procGetCompressedFileSizeW = kernel32.NewProc("GetCompressedFileSizeW")
+	procGetDiskFreeSpaceW      = kernel32.NewProc("GetDiskFreeSpaceW")
+)
+
+// invalidFileSize is the INVALID_FILE_SIZE sentinel GetCompressedFileSizeW
+// returns on failure
+const invalidFileSize = 0xFFFFFFFF
+
+// allocatedSize returns the bytes allocated to a file: its compressed size,
+// which also accounts for sparse ranges, rounded up to whole clusters
+func allocatedSize(path string, info fs.FileInfo) (int64, error) {
+	if info.IsDir() {
+		return 0, nil
+	}
+
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, &os.PathError{Op: "GetCompressedFileSize", Path: path, Err: err}
+	}
+
+	var high uint32
+	low, _, callErr := procGetCompressedFileSizeW.Call(uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(&high)))
+	if uint32(low) == invalidFileSize && callErr != windows.ERROR_SUCCESS {
+		return 0, &os.PathError{Op: "GetCompressedFileSize", Path: path, Err: callErr}
+	}
+	size := int64(high)<<32 | int64(uint32(low))
+
+	cluster, err := clusterSize(path)
+	if err != nil {
+		return 0, err
+	}
+	return ceilDiv(size, cluster) * cluster, nil
+}
+
+// clusterSize returns the allocation unit of the volume containing path
+func clusterSize(path string) (int64, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, &os.PathError{Op: "GetVolumePathName", Path: path, Err: err}
+	}
+
+	var root [windows.MAX_PATH + 1]uint16
+	if err := windows.GetVolumePathName(name, &root[0], uint32(len(root))); err != nil {
+		return 0, &os.PathError{Op: "GetVolumePathName", Path: path, Err: err}
+	}
+
+	var sectorsPerCluster, bytesPerSector, freeClusters, totalClusters uint32
+	ok, _, callErr := procGetDiskFreeSpaceW.Call(
+		uintptr(unsafe.Pointer(&root[0])),
+		uintptr(unsafe.Pointer(&sectorsPerCluster)),
+		uintptr(unsafe.Pointer(&bytesPerSector)),
+		uintptr(unsafe.Pointer(&freeClusters)),
+		uintptr(unsafe.Pointer(&totalClusters)),
+	)
+	if ok == 0 {
+		return 0, &os.PathError{Op: "GetDiskFreeSpace", Path: path, Err: callErr}
+	}
+	return int64(sectorsPerCluster) * int64(bytesPerSector), nil
+}
@@ -0,0 +1,202 @@
+package filesize
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// sizeTag is the struct tag ValidateStruct reads constraints from
+const sizeTag = "size"
+
+// FieldError describes a struct field that failed ValidateStruct
+type FieldError struct {
+	// Field is the field's path from the validated struct, e.g. "Cache.MaxSize"
+	Field string
+
+	// Rule is the constraint that failed: "min", "max", "multiple_of", or
+	// "parse" when a string field does not hold a valid size
+	Rule string
+
+	// Value is the field's size in bytes, zero for parse failures
+	Value int64
+
+	// Limit is the constraint's parameter in bytes, zero for parse failures
+	Limit int64
+
+	// Err is the parse error for parse failures
+	Err error
+}
+
+// Error describes the failure in human-readable form
+func (e *FieldError) Error() string {
+	value, limit := formatExact(e.Value, " "), formatExact(e.Limit, " ")
+	switch e.Rule {
+	case "min":
+		return fmt.Sprintf("%s: %s is below the minimum of %s", e.Field, value, limit)
+	case "max":
+		return fmt.Sprintf("%s: %s exceeds the maximum of %s", e.Field, value, limit)
+	case "multiple_of":
+		return fmt.Sprintf("%s: %s is not a multiple of %s", e.Field, value, limit)
+	default:
+		return fmt.Sprintf("%s: %v", e.Field, e.Err)
+	}
+}
+
+// Unwrap returns the parse error, if any
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors lists every field that failed ValidateStruct
+type ValidationErrors []*FieldError
+
+// Error joins the field errors with "; "
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// sizeConstraints holds the parsed rules of one size tag
+type sizeConstraints struct {
+	min, max, multipleOf          int64
+	hasMin, hasMax, hasMultipleOf bool
+}
+
+// ValidateStruct checks the size constraints declared in struct tags
+//
+// Fields tagged like `size:"min=4k,max=1G,multiple_of=4k"` are validated
+// according to their kind: integer fields, including Size, are byte counts
+// and string fields are parsed with ParseSize, where an empty string counts
+// as unset and is skipped. Nested structs and non-nil pointers to structs are
+// walked, and field paths are joined with ".". A tag may be empty
+// (`size:""`) to only require that a string field parses.
+//
+// v must be a struct or a pointer to one. Constraint violations are returned
+// together as ValidationErrors; a malformed tag or a tag on an unsupported
+// field type is reported as a plain error since it is a programming mistake.
+func ValidateStruct(v any) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("ValidateStruct: expected a struct, got %T", v)
+	}
+
+	var errs ValidationErrors
+	if err := validateFields(value, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateFields validates the tagged fields of a struct value, appending
+// constraint violations to errs
+func validateFields(value reflect.Value, prefix string, errs *ValidationErrors) error {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + field.Name
+		fieldValue := value.Field(i)
+
+		tag, tagged := field.Tag.Lookup(sizeTag)
+		if !tagged {
+			// walk into nested structs looking for more tags
+			for fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				if err := validateFields(fieldValue, path+".", errs); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		constraints, err := parseSizeTag(tag)
+		if err != nil {
+			return fmt.Errorf("ValidateStruct: field %s: %w", path, err)
+		}
+
+		var bytes int64
+		switch fieldValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			bytes = fieldValue.Int()
+		case reflect.String:
+			if fieldValue.String() == "" {
+				continue
+			}
+			bytes, err = ParseSize(fieldValue.String())
+			if err != nil {
+				*errs = append(*errs, &FieldError{Field: path, Rule: "parse", Err: err})
+				continue
+			}
+		default:
+			return fmt.Errorf("ValidateStruct: field %s: size tag on unsupported type %s", path, field.Type)
+		}
+
+		if fieldErr := constraints.check(path, bytes); fieldErr != nil {
+			*errs = append(*errs, fieldErr)
+		}
+	}
+	return nil
+}
+
+// parseSizeTag parses a comma-separated list of key=size rules
+func parseSizeTag(tag string) (sizeConstraints, error) {
+	var c sizeConstraints
+	if strings.TrimSpace(tag) == "" {
+		return c, nil
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, ok := strings.Cut(strings.TrimSpace(rule), "=")
+		if !ok {
+			return c, fmt.Errorf("invalid size rule %q, expected key=size", rule)
+		}
+
+		bytes, err := ParseSize(param)
+		if err != nil {
+			return c, fmt.Errorf("invalid size rule %q: %w", rule, err)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "min":
+			c.min, c.hasMin = bytes, true
+		case "max":
+			c.max, c.hasMax = bytes, true
+		case "multiple_of":
+			if bytes == 0 {
+				return c, errors.New("multiple_of must be greater than zero")
+			}
+			c.multipleOf, c.hasMultipleOf = bytes, true
+		default:
+			return c, fmt.Errorf("unknown size rule %q", key)
+		}
+	}
+	return c, nil
+}
+
+// check returns the first constraint bytes violates, or nil
+func (c sizeConstraints) check(field string, bytes int64) *FieldError {
+	switch {
+	case c.hasMin && bytes < c.min:
+		return &FieldError{Field: field, Rule: "min", Value: bytes, Limit: c.min}
+	case c.hasMax && bytes > c.max:
+		return &FieldError{Field: field, Rule: "max", Value: bytes, Limit: c.max}
+	case c.hasMultipleOf && !IsAligned(bytes, c.multipleOf):
+		return &FieldError{Field: field, Rule: "multiple_of", Value: bytes, Limit: c.multipleOf}
+	}
+	return nil
+}
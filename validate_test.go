@@ -0,0 +1,134 @@
+package filesize
+
+import (
+	"errors"
+	"testing"
+)
+
+// storageConfig is a config struct with size constraints
+type storageConfig struct {
+	BlockSize Size   `size:"min=512,max=64k,multiple_of=512"`
+	CacheSize int64  `size:"min=4k,max=1G,multiple_of=4k"`
+	Reserve   string `size:"max=10GiB"`
+	Spool     string `size:""`
+	Untagged  int64
+	Cache     struct {
+		MaxSize Size `size:"max=1GiB"`
+	}
+	Archive *archiveConfig
+}
+
+// archiveConfig is a nested config reached through a pointer
+type archiveConfig struct {
+	Limit string `size:"min=1MiB"`
+}
+
+// validStorageConfig returns a config that passes every constraint
+func validStorageConfig() storageConfig {
+	cfg := storageConfig{BlockSize: 4096, CacheSize: 256 * MiB, Reserve: "5GiB", Untagged: -1}
+	cfg.Cache.MaxSize = Size(512 * MiB)
+	return cfg
+}
+
+// TestValidateStruct tests per-field constraint violations
+func TestValidateStruct(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(*storageConfig)
+		expected []string
+	}{
+		{"valid", func(cfg *storageConfig) {}, nil},
+		{"below min", func(cfg *storageConfig) { cfg.CacheSize = KiB }, []string{"CacheSize:min"}},
+		{"above max", func(cfg *storageConfig) { cfg.BlockSize = Size(128 * KiB) }, []string{"BlockSize:max"}},
+		{"not multiple", func(cfg *storageConfig) { cfg.CacheSize = 4*KiB + 1 }, []string{"CacheSize:multiple_of"}},
+		{"string max", func(cfg *storageConfig) { cfg.Reserve = "11GiB" }, []string{"Reserve:max"}},
+		{"string parse", func(cfg *storageConfig) { cfg.Spool = "lots" }, []string{"Spool:parse"}},
+		{"empty string", func(cfg *storageConfig) { cfg.Reserve = "" }, nil},
+		{"nested", func(cfg *storageConfig) { cfg.Cache.MaxSize = Size(2 * GiB) }, []string{"Cache.MaxSize:max"}},
+		{"pointer", func(cfg *storageConfig) { cfg.Archive = &archiveConfig{Limit: "1k"} }, []string{"Archive.Limit:min"}},
+		{"several", func(cfg *storageConfig) {
+			cfg.BlockSize = 100
+			cfg.Reserve = "1TiB"
+		}, []string{"BlockSize:min", "Reserve:max"}},
+	}
+
+	for _, tc := range testCases {
+		cfg := validStorageConfig()
+		tc.modify(&cfg)
+
+		err := ValidateStruct(&cfg)
+		if tc.expected == nil {
+			if err != nil {
+				t.Errorf("ValidateStruct(%s) unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+
+		var errs ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("ValidateStruct(%s) = %v, expected ValidationErrors", tc.name, err)
+			continue
+		}
+		var got []string
+		for _, fieldErr := range errs {
+			got = append(got, fieldErr.Field+":"+fieldErr.Rule)
+		}
+		if len(got) != len(tc.expected) {
+			t.Errorf("ValidateStruct(%s) = %q, expected %q", tc.name, got, tc.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Errorf("ValidateStruct(%s) = %q, expected %q", tc.name, got, tc.expected)
+				break
+			}
+		}
+	}
+}
+
+// TestValidateStruct_Messages tests the error text
+func TestValidateStruct_Messages(t *testing.T) {
+	cfg := validStorageConfig()
+	cfg.CacheSize = 6 * KiB
+	cfg.Reserve = "12GiB"
+
+	expected := "CacheSize: 6 KiB is not a multiple of 4 KiB; Reserve: 12 GiB exceeds the maximum of 10 GiB"
+	if err := ValidateStruct(cfg); err == nil || err.Error() != expected {
+		t.Errorf("ValidateStruct() = %v, expected %q", err, expected)
+	}
+}
+
+// TestValidateStruct_Invalid tests programming errors
+func TestValidateStruct_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+	}{
+		{"not a struct", 42},
+		{"nil pointer", (*storageConfig)(nil)},
+		{"bad rule", &struct {
+			Size int64 `size:"atleast=4k"`
+		}{}},
+		{"bad param", &struct {
+			Size int64 `size:"min=lots"`
+		}{}},
+		{"zero multiple", &struct {
+			Size int64 `size:"multiple_of=0"`
+		}{}},
+		{"bad type", &struct {
+			Size float64 `size:"min=1k"`
+		}{}},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateStruct(tc.input)
+		if err == nil {
+			t.Errorf("ValidateStruct(%s) expected error but got none", tc.name)
+			continue
+		}
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			t.Errorf("ValidateStruct(%s) = %v, expected a plain error", tc.name, err)
+		}
+	}
+}
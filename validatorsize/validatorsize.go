@@ -0,0 +1,91 @@
+// Package validatorsize adds human-readable size tags to
+// go-playground/validator.
+//
+// After Register, request DTOs can validate size strings and byte counts
+// declaratively:
+//
+//	type UploadRequest struct {
+//		ChunkSize string        `validate:"required,filesize,filesize_min=64k,filesize_max=1G"`
+//		Quota     filesize.Size `validate:"filesize_max=10GiB"`
+//	}
+//
+// The tags are:
+//   - filesize: a string field parses with filesize.ParseSize
+//   - filesize_min=N: the size is at least N
+//   - filesize_max=N: the size is at most N
+//
+// String fields are parsed and integer fields, including filesize.Size, are
+// taken as byte counts. Tag parameters use the same syntax as the values, and
+// like validator's built-in tags an unparsable parameter panics, since it is
+// a programming error.
+package validatorsize
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// Register adds the filesize, filesize_min and filesize_max tags to v
+func Register(v *validator.Validate) error {
+	validations := []struct {
+		tag string
+		fn  validator.Func
+	}{
+		{"filesize", isFileSize},
+		{"filesize_min", isFileSizeMin},
+		{"filesize_max", isFileSizeMax},
+	}
+
+	for _, validation := range validations {
+		if err := v.RegisterValidation(validation.tag, validation.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isFileSize validates that a field holds a parsable size
+func isFileSize(fl validator.FieldLevel) bool {
+	_, ok := fieldBytes(fl.Field())
+	return ok
+}
+
+// isFileSizeMin validates that a field is at least the tag parameter
+func isFileSizeMin(fl validator.FieldLevel) bool {
+	bytes, ok := fieldBytes(fl.Field())
+	return ok && bytes >= mustParseParam(fl)
+}
+
+// isFileSizeMax validates that a field is at most the tag parameter
+func isFileSizeMax(fl validator.FieldLevel) bool {
+	bytes, ok := fieldBytes(fl.Field())
+	return ok && bytes <= mustParseParam(fl)
+}
+
+// fieldBytes returns the byte count held by a string or integer field
+func fieldBytes(field reflect.Value) (int64, bool) {
+	switch field.Kind() {
+	case reflect.String:
+		bytes, err := filesize.ParseSize(field.String())
+		return bytes, err == nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bytes := field.Uint()
+		return int64(bytes), bytes <= 1<<63-1
+	default:
+		return 0, false
+	}
+}
+
+// mustParseParam parses the tag parameter, panicking on invalid sizes
+func mustParseParam(fl validator.FieldLevel) int64 {
+	bytes, err := filesize.ParseSize(fl.Param())
+	if err != nil {
+		panic(fmt.Sprintf("validatorsize: invalid %s parameter %q: %v", fl.GetTag(), fl.Param(), err))
+	}
+	return bytes
+}
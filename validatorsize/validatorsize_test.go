@@ -0,0 +1,91 @@
+package validatorsize
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	filesize "github.com/jessegalley/go-filesize"
+)
+
+// uploadRequest is a request DTO validated with the size tags
+type uploadRequest struct {
+	ChunkSize string        `validate:"required,filesize,filesize_min=64k,filesize_max=1G"`
+	Quota     filesize.Size `validate:"filesize_max=10GiB"`
+	Reserve   string        `validate:"omitempty,filesize"`
+}
+
+// newValidate returns a validator with the size tags registered
+func newValidate(t *testing.T) *validator.Validate {
+	t.Helper()
+
+	v := validator.New()
+	if err := Register(v); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	return v
+}
+
+// TestRegister tests validating DTOs with the size tags
+func TestRegister(t *testing.T) {
+	v := newValidate(t)
+
+	testCases := []struct {
+		input     uploadRequest
+		failedTag string
+	}{
+		{uploadRequest{ChunkSize: "4MiB", Quota: filesize.Size(filesize.GiB)}, ""},
+		{uploadRequest{ChunkSize: "64k"}, ""},
+		{uploadRequest{ChunkSize: "1G"}, ""},
+		{uploadRequest{ChunkSize: "4MiB", Reserve: "100MB"}, ""},
+		{uploadRequest{ChunkSize: "4 megs"}, "filesize"},
+		{uploadRequest{ChunkSize: "63k"}, "filesize_min"},
+		{uploadRequest{ChunkSize: "1.5G"}, "filesize_max"},
+		{uploadRequest{ChunkSize: "4MiB", Quota: filesize.Size(11 * filesize.GiB)}, "filesize_max"},
+		{uploadRequest{ChunkSize: "4MiB", Reserve: "lots"}, "filesize"},
+		{uploadRequest{}, "required"},
+	}
+
+	for _, tc := range testCases {
+		err := v.Struct(tc.input)
+		if tc.failedTag == "" {
+			if err != nil {
+				t.Errorf("Struct(%+v) unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) || len(validationErrors) != 1 {
+			t.Errorf("Struct(%+v) = %v, expected one %s failure", tc.input, err, tc.failedTag)
+			continue
+		}
+		if tag := validationErrors[0].Tag(); tag != tc.failedTag {
+			t.Errorf("Struct(%+v) failed tag %q, expected %q", tc.input, tag, tc.failedTag)
+		}
+	}
+}
+
+// TestRegister_Var tests validating single values
+func TestRegister_Var(t *testing.T) {
+	v := newValidate(t)
+
+	if err := v.Var("512MiB", "filesize_max=1GiB"); err != nil {
+		t.Errorf("Var(512MiB, filesize_max=1GiB) unexpected error: %v", err)
+	}
+	if err := v.Var(int64(2048), "filesize_min=4k"); err == nil {
+		t.Errorf("Var(2048, filesize_min=4k) expected error but got none")
+	}
+}
+
+// TestRegister_InvalidParam tests that bad tag parameters panic
+func TestRegister_InvalidParam(t *testing.T) {
+	v := newValidate(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Var(1k, filesize_min=lots) expected panic but got none")
+		}
+	}()
+	_ = v.Var("1k", "filesize_min=lots")
+}
@@ -0,0 +1,53 @@
+package filesize
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// MarshalXML implements xml.Marshaler, writing the canonical text form as
+// the element's content, e.g. <limit>2GiB</limit>
+func (s Size) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(formatExact(int64(s), ""), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+//
+// Two layouts are understood. The element text form <limit>2GiB</limit> is
+// parsed like UnmarshalText. The attribute form <limit value="2" unit="GiB"/>
+// used by older device configs combines the value attribute with the
+// optional unit attribute, so <limit value="4096"/> is a byte count. When a
+// value attribute is present the element text is ignored.
+func (s *Size) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value, unit string
+	var hasValue, hasUnit bool
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "value":
+			value, hasValue = attr.Value, true
+		case "unit":
+			unit, hasUnit = attr.Value, true
+		}
+	}
+
+	// always consume the element so the decoder stays positioned correctly
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+
+	if !hasValue {
+		if hasUnit {
+			return fmt.Errorf("xml element <%s> has a unit attribute but no value", start.Name.Local)
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+
+	// the unit must be a single word so that value="1" unit="k b" is rejected
+	value, unit = strings.TrimSpace(value), strings.TrimSpace(unit)
+	if strings.ContainsAny(unit, " \t\n\r\f") {
+		return fmt.Errorf("invalid unit attribute: %q", unit)
+	}
+	return s.UnmarshalText([]byte(value + unit))
+}
@@ -0,0 +1,94 @@
+package filesize
+
+import (
+	"encoding/xml"
+	"math"
+	"testing"
+)
+
+// deviceConfig is a legacy XML config with size elements
+type deviceConfig struct {
+	XMLName xml.Name `xml:"device"`
+	Limit   Size     `xml:"limit"`
+	Buffer  Size     `xml:"buffer,attr"`
+}
+
+// TestSize_UnmarshalXML tests the element text and attribute layouts
+func TestSize_UnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Size
+		hasError bool
+	}{
+		{`<device><limit>2GiB</limit></device>`, Size(2 * GiB), false},
+		{`<device><limit> 1.5 MB </limit></device>`, Size(1500 * KB), false},
+		{`<device><limit value="2" unit="GiB"/></device>`, Size(2 * GiB), false},
+		{`<device><limit value='1.5' unit='k'/></device>`, 1536, false},
+		{`<device><limit value="4096"/></device>`, 4096, false},
+		{`<device><limit value="-1" unit="k"/></device>`, -1024, false},
+		{`<device><limit unit="GiB"/></device>`, 0, true},
+		{`<device><limit value="1" unit="XB"/></device>`, 0, true},
+		{`<device><limit value="1" unit="k b"/></device>`, 0, true},
+		{`<device><limit>lots</limit></device>`, 0, true},
+	}
+
+	for _, tc := range testCases {
+		var cfg deviceConfig
+		err := xml.Unmarshal([]byte(tc.input), &cfg)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("xml.Unmarshal(%s) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("xml.Unmarshal(%s) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if cfg.Limit != tc.expected {
+			t.Errorf("xml.Unmarshal(%s) = %d, expected %d", tc.input, int64(cfg.Limit), int64(tc.expected))
+		}
+	}
+}
+
+// TestSize_MarshalXML tests element and attribute output
+func TestSize_MarshalXML(t *testing.T) {
+	data, err := xml.Marshal(deviceConfig{Limit: Size(2 * GiB), Buffer: Size(64 * KiB)})
+	if err != nil {
+		t.Fatalf("xml.Marshal() unexpected error: %v", err)
+	}
+
+	expected := `<device buffer="64KiB"><limit>2GiB</limit></device>`
+	if string(data) != expected {
+		t.Errorf("xml.Marshal() = %s, expected %s", data, expected)
+	}
+
+	var decoded deviceConfig
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("xml.Unmarshal(%s) unexpected error: %v", data, err)
+	}
+	if decoded.Limit != Size(2*GiB) || decoded.Buffer != Size(64*KiB) {
+		t.Errorf("xml round trip = %+v, expected limit %d and buffer %d", decoded, 2*GiB, 64*KiB)
+	}
+}
+
+// TestSize_XMLRoundTrip tests that large sizes survive elements and attributes
+func TestSize_XMLRoundTrip(t *testing.T) {
+	sizes := []Size{9007199254740993, 1<<62 + 512, math.MaxInt64}
+	for _, size := range sizes {
+		data, err := xml.Marshal(deviceConfig{Limit: size, Buffer: size})
+		if err != nil {
+			t.Errorf("xml.Marshal(%d) unexpected error: %v", int64(size), err)
+			continue
+		}
+
+		var decoded deviceConfig
+		if err := xml.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("xml.Unmarshal(%s) unexpected error: %v", data, err)
+			continue
+		}
+		if decoded.Limit != size || decoded.Buffer != size {
+			t.Errorf("xml.Unmarshal(%s) = %+v, expected limit and buffer %d", data, decoded, int64(size))
+		}
+	}
+}
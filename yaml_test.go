@@ -0,0 +1,102 @@
+package filesize
+
+import (
+	"testing"
+	"time"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// cacheConfig is a YAML-configured struct with size fields
+type cacheConfig struct {
+	MaxCacheSize Size `yaml:"maxCacheSize" json:"maxCacheSize"`
+	BlockSize    Size `yaml:"blockSize" json:"blockSize"`
+}
+
+// TestSize_YAMLv3 tests decoding and encoding with gopkg.in/yaml.v3
+func TestSize_YAMLv3(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected cacheConfig
+		hasError bool
+	}{
+		{"maxCacheSize: 2GiB\nblockSize: 4k\n", cacheConfig{Size(2 * GiB), 4096}, false},
+		{"maxCacheSize: 1.5 GB\nblockSize: 512\n", cacheConfig{Size(1500 * MB), 512}, false},
+		{"maxCacheSize: \"10MiB\"\n", cacheConfig{MaxCacheSize: Size(10 * MiB)}, false},
+		{"maxCacheSize: 2XB\n", cacheConfig{}, true},
+	}
+
+	for _, tc := range testCases {
+		var cfg cacheConfig
+		err := yamlv3.Unmarshal([]byte(tc.input), &cfg)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("yaml.v3 Unmarshal(%q) expected error but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("yaml.v3 Unmarshal(%q) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if cfg != tc.expected {
+			t.Errorf("yaml.v3 Unmarshal(%q) = %+v, expected %+v", tc.input, cfg, tc.expected)
+		}
+	}
+
+	data, err := yamlv3.Marshal(cacheConfig{Size(2 * GiB), 4096})
+	if err != nil {
+		t.Fatalf("yaml.v3 Marshal() unexpected error: %v", err)
+	}
+	if string(data) != "maxCacheSize: 2GiB\nblockSize: 4KiB\n" {
+		t.Errorf("yaml.v3 Marshal() = %q, expected %q", data, "maxCacheSize: 2GiB\nblockSize: 4KiB\n")
+	}
+}
+
+// TestSize_K8sYAML tests decoding with sigs.k8s.io/yaml, which converts YAML
+// to JSON and relies on Size.UnmarshalJSON
+func TestSize_K8sYAML(t *testing.T) {
+	var cfg cacheConfig
+	err := k8syaml.Unmarshal([]byte("maxCacheSize: 2GiB\nblockSize: 4096\n"), &cfg)
+	if err != nil {
+		t.Fatalf("sigs.k8s.io/yaml Unmarshal() unexpected error: %v", err)
+	}
+	if cfg != (cacheConfig{Size(2 * GiB), 4096}) {
+		t.Errorf("sigs.k8s.io/yaml Unmarshal() = %+v, expected %+v", cfg, cacheConfig{Size(2 * GiB), 4096})
+	}
+}
+
+// TestRate_YAML tests rates in YAML documents through both libraries
+func TestRate_YAML(t *testing.T) {
+	type backupConfig struct {
+		Bandwidth Rate `yaml:"bandwidth" json:"bandwidth"`
+		Quota     Rate `yaml:"quota" json:"quota"`
+	}
+	input := "bandwidth: 10MB/s\nquota: 1GiB/day\n"
+	expected := backupConfig{PerSecond(10 * MB), Rate{Bytes: Size(GiB), Per: 24 * time.Hour}}
+
+	var v3 backupConfig
+	if err := yamlv3.Unmarshal([]byte(input), &v3); err != nil {
+		t.Fatalf("yaml.v3 Unmarshal(%q) unexpected error: %v", input, err)
+	}
+	if v3 != expected {
+		t.Errorf("yaml.v3 Unmarshal(%q) = %+v, expected %+v", input, v3, expected)
+	}
+
+	var k8s backupConfig
+	if err := k8syaml.Unmarshal([]byte(input), &k8s); err != nil {
+		t.Fatalf("sigs.k8s.io/yaml Unmarshal(%q) unexpected error: %v", input, err)
+	}
+	if k8s != expected {
+		t.Errorf("sigs.k8s.io/yaml Unmarshal(%q) = %+v, expected %+v", input, k8s, expected)
+	}
+
+	data, err := yamlv3.Marshal(expected)
+	if err != nil {
+		t.Fatalf("yaml.v3 Marshal() unexpected error: %v", err)
+	}
+	if string(data) != "bandwidth: 9765.625KiB/s\nquota: 1GiB/day\n" {
+		t.Errorf("yaml.v3 Marshal() = %q, expected %q", data, "bandwidth: 9765.625KiB/s\nquota: 1GiB/day\n")
+	}
+}